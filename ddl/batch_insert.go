@@ -41,8 +41,9 @@ type BatchInsert struct {
 	// (SQL Server). Postgres or SQL Server only. If provided:
 	//
 	// (Postgres) The identity sequence for each identity column will be
-	// updated accordingly at the end of the batch insert e.g. SELECT
-	// setval(pg_get_serial_sequence('table', 'id'), max(id)) FROM table;
+	// resynced at the end of the batch insert e.g. SELECT
+	// setval(pg_get_serial_sequence('table', 'id'), max(id)) FROM table; The
+	// sequence is never moved backwards.
 	//
 	// (SQL Server) SET IDENTITY_INSERT will be enabled for the table at the
 	// start of batch insert and disabled at the end. It is vital to pass in a
@@ -409,11 +410,18 @@ func (bi *BatchInsert) ExecContext(ctx context.Context, db DB, next func([]any)
 	}
 
 	// SELECT setval(pg_get_serial_sequence('table', 'id'), max(id)) FROM table;
+	//
+	// The sequence is only ever moved forward: if it is already past the
+	// highest inserted value (or has not been used yet and starts past it,
+	// e.g. START WITH 1000), it is left alone.
 	if len(identityColumns) > 0 && bi.Dialect == DialectPostgres {
 		for _, column := range identityColumns {
 			columnName := QuoteIdentifier(bi.Dialect, column)
 			_, err = db.ExecContext(ctx,
-				"SELECT setval(pg_get_serial_sequence($1, $2), MAX("+columnName+")) FROM "+tableName,
+				"SELECT setval(pg_get_serial_sequence($1, $2), MAX("+columnName+")) FROM "+tableName+
+					" HAVING MAX("+columnName+") >= ("+
+					"SELECT COALESCE(pg_sequence_last_value(seqrelid), seqstart) FROM pg_sequence"+
+					" WHERE seqrelid = pg_get_serial_sequence($1, $2)::regclass)",
 				tableName, columnName,
 			)
 			if err != nil {
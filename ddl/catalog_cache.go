@@ -12,6 +12,7 @@ type CatalogCache struct {
 	schemas     map[string]int
 	enums       map[[2]string]int
 	domains     map[[2]string]int
+	sequences   map[[2]string]int
 	routines    map[[3]string]int
 	views       map[[2]string]int
 	tables      map[[2]string]int
@@ -31,6 +32,7 @@ func NewCatalogCache(catalog *Catalog) *CatalogCache {
 		schemas:     make(map[string]int),
 		enums:       make(map[[2]string]int),
 		domains:     make(map[[2]string]int),
+		sequences:   make(map[[2]string]int),
 		routines:    make(map[[3]string]int),
 		views:       make(map[[2]string]int),
 		tables:      make(map[[2]string]int),
@@ -54,6 +56,10 @@ func NewCatalogCache(catalog *Catalog) *CatalogCache {
 			domainID := [2]string{schema.SchemaName, domain.DomainName}
 			cache.domains[domainID] = j
 		}
+		for j, sequence := range schema.Sequences {
+			sequenceID := [2]string{schema.SchemaName, sequence.SequenceName}
+			cache.sequences[sequenceID] = j
+		}
 		for j, routine := range schema.Routines {
 			identityArguments := ""
 			if cache.dialect == "postgres" {
@@ -160,6 +166,16 @@ func (c *CatalogCache) AddOrUpdateSchema(catalog *Catalog, schema Schema) {
 	c.schemas[schema.SchemaName] = i
 }
 
+// AddExtension adds the given extension to the Catalog if it doesn't already
+// exist.
+func (c *CatalogCache) AddExtension(catalog *Catalog, extension string) {
+	if _, ok := c.extensions[extension]; ok {
+		return
+	}
+	catalog.Extensions = append(catalog.Extensions, extension)
+	c.extensions[extension] = len(catalog.Extensions) - 1
+}
+
 // GetEnum gets a Enum with the given enumName from the Schema, or returns nil
 // if it doesn't exist. If a nil schema is passed in, GetEnum returns nil.
 //
@@ -263,6 +279,58 @@ func (c *CatalogCache) AddOrUpdateDomain(schema *Schema, domain Domain) {
 	c.domains[[2]string{schema.SchemaName, domain.DomainName}] = i
 }
 
+// GetSequence gets a Sequence with the given sequenceName from the Schema, or
+// returns nil if it doesn't exist. If a nil schema is passed in, GetSequence
+// returns nil.
+//
+// The returning Sequence pointer is valid as long as no new Sequence is added
+// to the Schema; if a new Sequence is added, the pointer may now be pointing at
+// a stale Sequence. Call GetSequence again in order to get the new pointer.
+func (c *CatalogCache) GetSequence(schema *Schema, sequenceName string) *Sequence {
+	if schema == nil {
+		return nil
+	}
+	i, ok := c.sequences[[2]string{schema.SchemaName, sequenceName}]
+	if ok && !schema.Sequences[i].Ignore {
+		return &schema.Sequences[i]
+	}
+	return nil
+}
+
+// GetOrCreateSequence gets a Sequence with the given sequenceName from the
+// Schema, or creates it if it doesn't exist.
+//
+// The returning Sequence pointer is valid as long as no new Sequence is added
+// to the Schema; if a new Sequence is added, the pointer may now be pointing at
+// a stale Sequence. Call GetSequence again in order to get the new pointer.
+func (c *CatalogCache) GetOrCreateSequence(schema *Schema, sequenceName string) *Sequence {
+	i, ok := c.sequences[[2]string{schema.SchemaName, sequenceName}]
+	if ok && !schema.Sequences[i].Ignore {
+		return &schema.Sequences[i]
+	}
+	schema.Sequences = append(schema.Sequences, Sequence{
+		SequenceSchema: schema.SchemaName,
+		SequenceName:   sequenceName,
+	})
+	i = len(schema.Sequences) - 1
+	c.sequences[[2]string{schema.SchemaName, sequenceName}] = i
+	return &schema.Sequences[i]
+}
+
+// AddOrUpdateSequence adds the given Sequence to the Schema, or updates it if
+// it already exists.
+func (c *CatalogCache) AddOrUpdateSequence(schema *Schema, sequence Sequence) {
+	sequence.SequenceSchema = schema.SchemaName
+	i, ok := c.sequences[[2]string{schema.SchemaName, sequence.SequenceName}]
+	if ok && !schema.Sequences[i].Ignore {
+		schema.Sequences[i] = sequence
+		return
+	}
+	schema.Sequences = append(schema.Sequences, sequence)
+	i = len(schema.Sequences) - 1
+	c.sequences[[2]string{schema.SchemaName, sequence.SequenceName}] = i
+}
+
 // GetRoutine gets a Routine with the given routineName (and identityArguments)
 // from the Schema, or returns nil if it doesn't exist. If a nil schema is
 // passed in, GetRoutine returns nil. The identityArguments string only applies
@@ -507,7 +575,7 @@ func (c *CatalogCache) GetConstraint(table *Table, constraintName string) *Const
 // stale Constraint. Call GetConstraint again in order to get the new pointer.
 func (c *CatalogCache) GetOrCreateConstraint(table *Table, constraintName, constraintType string, columnNames []string) *Constraint {
 	i, ok := c.constraints[[3]string{table.TableSchema, table.TableName, constraintName}]
-	if ok && !table.Constraints[i].Ignore {
+	if ok && constraintName != "" && !table.Constraints[i].Ignore {
 		return &table.Constraints[i]
 	}
 	table.Constraints = append(table.Constraints, Constraint{
@@ -525,7 +593,9 @@ func (c *CatalogCache) GetOrCreateConstraint(table *Table, constraintName, const
 	case FOREIGN_KEY:
 		c.fkeys[tableID] = append(c.fkeys[tableID], i)
 	}
-	c.constraints[[3]string{table.TableSchema, table.TableName, constraintName}] = i
+	if constraintName != "" { // SQLite constraints will have no name, skip
+		c.constraints[[3]string{table.TableSchema, table.TableName, constraintName}] = i
+	}
 	return &table.Constraints[i]
 }
 
@@ -807,6 +877,7 @@ func (src *Catalog) WriteCatalog(dest *Catalog) error {
 	cache := NewCatalogCache(dest)
 	dest.Dialect = src.Dialect
 	dest.VersionNums = cloneSlice(src.VersionNums)
+	dest.Edition = src.Edition
 	dest.CatalogName = src.CatalogName
 	dest.CurrentSchema = src.CurrentSchema
 	dest.DefaultCollation = src.DefaultCollation
@@ -821,6 +892,7 @@ func (src *Catalog) WriteCatalog(dest *Catalog) error {
 		destSchema.Ignore = srcSchema.Ignore
 		destSchema.EnumsValid = srcSchema.EnumsValid
 		destSchema.DomainsValid = srcSchema.DomainsValid
+		destSchema.SequencesValid = srcSchema.SequencesValid
 		destSchema.RoutinesValid = srcSchema.RoutinesValid
 		destSchema.ViewsValid = srcSchema.ViewsValid
 
@@ -847,6 +919,23 @@ func (src *Catalog) WriteCatalog(dest *Catalog) error {
 			destDomain.Ignore = srcDomain.Ignore
 		}
 
+		for _, srcSequence := range srcSchema.Sequences {
+			destSequence := cache.GetOrCreateSequence(destSchema, srcSequence.SequenceName)
+			destSequence.SequenceSchema = srcSequence.SequenceSchema
+			destSequence.SequenceName = srcSequence.SequenceName
+			destSequence.DataType = srcSequence.DataType
+			destSequence.StartValue = srcSequence.StartValue
+			destSequence.IncrementBy = srcSequence.IncrementBy
+			destSequence.MinValue = srcSequence.MinValue
+			destSequence.MaxValue = srcSequence.MaxValue
+			destSequence.IsCycle = srcSequence.IsCycle
+			destSequence.OwnedByTable = srcSequence.OwnedByTable
+			destSequence.OwnedByColumn = srcSequence.OwnedByColumn
+			destSequence.LastValue = srcSequence.LastValue
+			destSequence.Comment = srcSequence.Comment
+			destSequence.Ignore = srcSequence.Ignore
+		}
+
 		for _, srcRoutine := range srcSchema.Routines {
 			destRoutine := cache.GetOrCreateRoutine(destSchema, srcRoutine.RoutineName, srcRoutine.IdentityArguments)
 			destRoutine.RoutineType = srcRoutine.RoutineType
@@ -888,6 +977,17 @@ func (src *Catalog) WriteCatalog(dest *Catalog) error {
 			destTable.TableSchema = srcTable.TableSchema
 			destTable.TableName = srcTable.TableName
 			destTable.SQL = srcTable.SQL
+			destTable.IsVirtual = srcTable.IsVirtual
+			destTable.PartitionStrategy = srcTable.PartitionStrategy
+			destTable.PartitionKey = srcTable.PartitionKey
+			destTable.Partitions = cloneSlice(srcTable.Partitions)
+			destTable.Engine = srcTable.Engine
+			destTable.Charset = srcTable.Charset
+			destTable.StorageParams = cloneSlice(srcTable.StorageParams)
+			destTable.IsUnlogged = srcTable.IsUnlogged
+			destTable.IsStrict = srcTable.IsStrict
+			destTable.IsWithoutRowid = srcTable.IsWithoutRowid
+			destTable.RenamedFrom = srcTable.RenamedFrom
 			destTable.Comment = srcTable.Comment
 			destTable.Ignore = srcTable.Ignore
 			for _, srcColumn := range srcTable.Columns {
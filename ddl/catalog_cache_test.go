@@ -29,6 +29,36 @@ func Test_Catalog_WriteCatalog(t *testing.T) {
 	}
 }
 
+func Test_Catalog_WriteCatalog_Unnamed(t *testing.T) {
+	// SQLite constraints have no names, and the RenamedFrom fields are
+	// only ever populated by the StructParser.
+	c1 := &Catalog{
+		Dialect: DialectSQLite,
+		Schemas: []Schema{{
+			Tables: []Table{{
+				TableName:   "actor",
+				RenamedFrom: "actors",
+				Columns: []Column{
+					{TableName: "actor", ColumnName: "actor_id", ColumnType: "INTEGER"},
+					{TableName: "actor", ColumnName: "full_name", ColumnType: "TEXT", RenamedFrom: "name"},
+				},
+				Constraints: []Constraint{
+					{TableName: "actor", ConstraintType: PRIMARY_KEY, Columns: []string{"actor_id"}},
+					{TableName: "actor", ConstraintType: UNIQUE, Columns: []string{"full_name"}},
+				},
+			}},
+		}},
+	}
+	c2 := &Catalog{}
+	err := c1.WriteCatalog(c2)
+	if err != nil {
+		t.Fatal(testutil.Callers(), err)
+	}
+	if diff := testutil.Diff(c2, c1); diff != "" {
+		t.Error(testutil.Callers(), diff)
+	}
+}
+
 func TestCatalogCache(t *testing.T) {
 	f, err := os.Open("testdata/postgres/schema.json")
 	if err != nil {
@@ -119,6 +149,32 @@ func TestCatalogCache(t *testing.T) {
 		}
 	})
 
+	t.Run("sequence", func(t *testing.T) {
+		schema := cache.GetOrCreateSchema(catalog, lorem_ipsum)
+		// get nonexistent sequence
+		gotSequence := cache.GetSequence(schema, lorem_ipsum)
+		if diff := testutil.Diff(gotSequence, (*Sequence)(nil)); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+		// create sequence and assert it was created
+		wantSequence := Sequence{
+			SequenceSchema: lorem_ipsum,
+			SequenceName:   lorem_ipsum,
+		}
+		cache.AddOrUpdateSequence(schema, wantSequence)
+		gotSequence = cache.GetSequence(schema, lorem_ipsum)
+		if diff := testutil.Diff(*gotSequence, wantSequence); diff != "" {
+			t.Fatal(testutil.Callers(), diff)
+		}
+		// modify sequence and assert it was modified
+		wantSequence.IncrementBy = 10
+		cache.AddOrUpdateSequence(schema, wantSequence)
+		gotSequence = cache.GetOrCreateSequence(schema, lorem_ipsum)
+		if diff := testutil.Diff(*gotSequence, wantSequence); diff != "" {
+			t.Fatal(testutil.Callers(), diff)
+		}
+	})
+
 	t.Run("routine", func(t *testing.T) {
 		schema := cache.GetOrCreateSchema(catalog, lorem_ipsum)
 		// get nonexistent routine
@@ -0,0 +1,324 @@
+package ddl
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bokwoon95/sqddl/internal/testutil"
+)
+
+func TestConvertCmd(t *testing.T) {
+	t.Parallel()
+	postgresCatalog := func() *Catalog {
+		return &Catalog{
+			Dialect:       DialectPostgres,
+			VersionNums:   VersionNums{14, 2},
+			CurrentSchema: "public",
+			Extensions:    []string{"btree_gist"},
+			Schemas: []Schema{{
+				SchemaName: "public",
+				Enums: []Enum{{
+					EnumSchema: "public",
+					EnumName:   "mpaa_rating",
+					EnumLabels: []string{"G", "PG", "R"},
+				}},
+				Sequences: []Sequence{{
+					SequenceSchema: "public",
+					SequenceName:   "film_film_id_seq",
+					DataType:       "integer",
+					OwnedByTable:   "film",
+					OwnedByColumn:  "film_id",
+				}},
+				Views: []View{{
+					ViewSchema: "public",
+					ViewName:   "film_list",
+					SQL:        "SELECT film_id FROM film",
+				}},
+				Tables: []Table{{
+					TableSchema: "public",
+					TableName:   "film",
+					Columns: []Column{{
+						TableSchema:   "public",
+						TableName:     "film",
+						ColumnName:    "film_id",
+						ColumnType:    "INT",
+						IsNotNull:     true,
+						IsPrimaryKey:  true,
+						ColumnDefault: "nextval('film_film_id_seq'::regclass)",
+					}, {
+						TableSchema:     "public",
+						TableName:       "film",
+						ColumnName:      "title",
+						ColumnType:      "CHARACTER VARYING(255)",
+						CharacterLength: "255",
+						IsNotNull:       true,
+						CollationName:   "C",
+					}, {
+						TableSchema: "public",
+						TableName:   "film",
+						ColumnName:  "rating",
+						ColumnType:  "mpaa_rating",
+						IsEnum:      true,
+					}, {
+						TableSchema:   "public",
+						TableName:     "film",
+						ColumnName:    "is_active",
+						ColumnType:    "BOOLEAN",
+						ColumnDefault: "true",
+					}, {
+						TableSchema:   "public",
+						TableName:     "film",
+						ColumnName:    "last_update",
+						ColumnType:    "TIMESTAMPTZ",
+						ColumnDefault: "now()",
+					}, {
+						TableSchema:   "public",
+						TableName:     "film",
+						ColumnName:    "uid",
+						ColumnType:    "UUID",
+						ColumnDefault: "gen_random_uuid()",
+					}, {
+						TableSchema: "public",
+						TableName:   "film",
+						ColumnName:  "special_features",
+						ColumnType:  "TEXT[]",
+					}},
+					Constraints: []Constraint{{
+						TableSchema:    "public",
+						TableName:      "film",
+						ConstraintName: "film_film_id_pkey",
+						ConstraintType: PRIMARY_KEY,
+						Columns:        []string{"film_id"},
+					}, {
+						TableSchema:        "public",
+						TableName:          "film",
+						ConstraintName:     "film_title_excl",
+						ConstraintType:     EXCLUDE,
+						Columns:            []string{"title"},
+						ExclusionOperators: []string{"="},
+						ExclusionIndexType: "GIST",
+					}},
+					Indexes: []Index{{
+						TableSchema: "public",
+						TableName:   "film",
+						IndexName:   "film_title_idx",
+						IndexType:   "GIN",
+						Columns:     []string{"title"},
+						Opclasses:   []string{"gin_trgm_ops"},
+					}},
+				}},
+			}},
+		}
+	}
+
+	t.Run("postgres to sqlserver", func(t *testing.T) {
+		t.Parallel()
+		convertCmd := &ConvertCmd{
+			SrcCatalog: postgresCatalog(),
+			Dialect:    DialectSQLServer,
+		}
+		gotCatalog, gotWarnings, err := convertCmd.Results()
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		wantCatalog := &Catalog{
+			Dialect:       DialectSQLServer,
+			CurrentSchema: "dbo",
+			Schemas: []Schema{{
+				SchemaName: "dbo",
+				Tables: []Table{{
+					TableSchema: "dbo",
+					TableName:   "film",
+					Columns: []Column{{
+						TableSchema:    "dbo",
+						TableName:      "film",
+						ColumnName:     "film_id",
+						ColumnType:     "INT",
+						IsNotNull:      true,
+						IsPrimaryKey:   true,
+						ColumnIdentity: IDENTITY,
+					}, {
+						TableSchema:     "dbo",
+						TableName:       "film",
+						ColumnName:      "title",
+						ColumnType:      "NVARCHAR(255)",
+						CharacterLength: "255",
+						IsNotNull:       true,
+					}, {
+						TableSchema:     "dbo",
+						TableName:       "film",
+						ColumnName:      "rating",
+						ColumnType:      "NVARCHAR(255)",
+						CharacterLength: "255",
+					}, {
+						TableSchema:   "dbo",
+						TableName:     "film",
+						ColumnName:    "is_active",
+						ColumnType:    "BIT",
+						ColumnDefault: "1",
+					}, {
+						TableSchema:   "dbo",
+						TableName:     "film",
+						ColumnName:    "last_update",
+						ColumnType:    "DATETIMEOFFSET",
+						ColumnDefault: "CURRENT_TIMESTAMP",
+					}, {
+						TableSchema:   "dbo",
+						TableName:     "film",
+						ColumnName:    "uid",
+						ColumnType:    "UNIQUEIDENTIFIER",
+						ColumnDefault: "NEWID()",
+					}, {
+						TableSchema:     "dbo",
+						TableName:       "film",
+						ColumnName:      "special_features",
+						ColumnType:      "NVARCHAR(MAX)",
+						CharacterLength: "MAX",
+					}},
+					Constraints: []Constraint{{
+						TableSchema:    "dbo",
+						TableName:      "film",
+						ConstraintName: "film_film_id_pkey",
+						ConstraintType: PRIMARY_KEY,
+						Columns:        []string{"film_id"},
+					}},
+					Indexes: []Index{{
+						TableSchema: "dbo",
+						TableName:   "film",
+						IndexName:   "film_title_idx",
+						Columns:     []string{"title"},
+					}},
+				}},
+			}},
+		}
+		if diff := testutil.Diff(gotCatalog, wantCatalog); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+		wantWarnings := []string{
+			"extensions btree_gist are not supported in sqlserver and were left out",
+			`film: collation C of column "title" cannot be converted to sqlserver and was left out`,
+			`film: column "special_features" is an array, which is not supported in sqlserver, it was converted to NVARCHAR(MAX)`,
+			`film: EXCLUDE constraint "film_title_excl" is not supported in sqlserver and was left out`,
+			`film: index "film_title_idx" of type GIN is not supported in sqlserver, it was converted to a regular index`,
+			`film: operator classes of index "film_title_idx" are not supported in sqlserver and were left out`,
+			`enum "mpaa_rating": enum types are not supported in sqlserver and were left out, the columns that use it were converted to strings`,
+			`view "film_list": views cannot be converted to sqlserver and were left out`,
+		}
+		if diff := testutil.Diff(gotWarnings, wantWarnings); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+	})
+
+	t.Run("postgres to mysql", func(t *testing.T) {
+		t.Parallel()
+		convertCmd := &ConvertCmd{
+			SrcCatalog: postgresCatalog(),
+			Dialect:    DialectMySQL,
+		}
+		gotCatalog, _, err := convertCmd.Results()
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		columns := gotCatalog.Schemas[0].Tables[0].Columns
+		if !columns[0].IsAutoincrement || columns[0].ColumnDefault != "" {
+			t.Errorf(testutil.Callers()+" film_id: expected AUTO_INCREMENT without a default, got %+v", columns[0])
+		}
+		if columns[2].ColumnType != "ENUM('G', 'PG', 'R')" {
+			t.Errorf(testutil.Callers()+" rating: expected ENUM('G', 'PG', 'R'), got %s", columns[2].ColumnType)
+		}
+		if columns[5].ColumnType != "BINARY(16)" || columns[5].ColumnDefault != "(UUID_TO_BIN(UUID()))" {
+			t.Errorf(testutil.Callers()+" uid: expected BINARY(16) DEFAULT (UUID_TO_BIN(UUID())), got %s DEFAULT %s", columns[5].ColumnType, columns[5].ColumnDefault)
+		}
+		if len(gotCatalog.Schemas[0].Sequences) != 0 {
+			t.Errorf(testutil.Callers()+" expected no sequences, got %+v", gotCatalog.Schemas[0].Sequences)
+		}
+	})
+
+	t.Run("sqlite to postgres", func(t *testing.T) {
+		t.Parallel()
+		convertCmd := &ConvertCmd{
+			SrcCatalog: &Catalog{
+				Dialect: DialectSQLite,
+				Schemas: []Schema{{
+					Tables: []Table{{
+						TableName: "actor",
+						Columns: []Column{{
+							TableName:    "actor",
+							ColumnName:   "actor_id",
+							ColumnType:   "INTEGER",
+							IsPrimaryKey: true,
+						}, {
+							TableName:     "actor",
+							ColumnName:    "last_update",
+							ColumnType:    "DATETIME",
+							IsNotNull:     true,
+							ColumnDefault: "datetime('now')",
+						}},
+					}},
+				}},
+			},
+			Dialect: DialectPostgres,
+		}
+		gotCatalog, gotWarnings, err := convertCmd.Results()
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		wantCatalog := &Catalog{
+			Dialect:       DialectPostgres,
+			CurrentSchema: "public",
+			Schemas: []Schema{{
+				SchemaName: "public",
+				Tables: []Table{{
+					TableSchema: "public",
+					TableName:   "actor",
+					Columns: []Column{{
+						TableSchema:    "public",
+						TableName:      "actor",
+						ColumnName:     "actor_id",
+						ColumnType:     "INT",
+						IsPrimaryKey:   true,
+						ColumnIdentity: DEFAULT_IDENTITY,
+					}, {
+						TableSchema:   "public",
+						TableName:     "actor",
+						ColumnName:    "last_update",
+						ColumnType:    "TIMESTAMP",
+						IsNotNull:     true,
+						ColumnDefault: "CURRENT_TIMESTAMP",
+					}},
+				}},
+			}},
+		}
+		if diff := testutil.Diff(gotCatalog, wantCatalog); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+		if len(gotWarnings) != 0 {
+			t.Errorf(testutil.Callers()+" expected no warnings, got %v", gotWarnings)
+		}
+	})
+
+	t.Run("sql output", func(t *testing.T) {
+		t.Parallel()
+		buf := &bytes.Buffer{}
+		convertCmd := &ConvertCmd{
+			SrcCatalog: postgresCatalog(),
+			Dialect:    DialectSQLite,
+			SQL:        true,
+			Stdout:     buf,
+			Stderr:     io.Discard,
+		}
+		err := convertCmd.Run()
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		gotOutput := buf.String()
+		if !strings.Contains(gotOutput, "CREATE TABLE film (") {
+			t.Errorf(testutil.Callers()+" expected CREATE TABLE film, got:\n%s", gotOutput)
+		}
+		if !strings.Contains(gotOutput, "film_id INTEGER PRIMARY KEY AUTOINCREMENT") {
+			t.Errorf(testutil.Callers()+" expected film_id INTEGER PRIMARY KEY AUTOINCREMENT, got:\n%s", gotOutput)
+		}
+	})
+}
@@ -30,7 +30,7 @@ type DatabaseIntrospector struct {
 	Filter
 
 	// The dialect of the database being introspected. Possible values:
-	// "sqlite", "postgres", "mysql", "sqlserver".
+	// "sqlite", "postgres", "mysql", "sqlserver", "oracle".
 	Dialect string
 
 	// DB is the database connection used to introspect the database.
@@ -66,6 +66,20 @@ func (dbi *DatabaseIntrospector) WriteCatalog(catalog *Catalog) error {
 		return err
 	}
 	dbi.Filter.VersionNums = catalog.VersionNums
+	// The Oracle introspection scripts rely on all_tab_cols.data_default_vc
+	// and all_users.oracle_maintained, which do not exist before 12.2.
+	if dbi.Dialect == DialectOracle && catalog.VersionNums.LowerThan(12, 2) {
+		strs := make([]string, len(catalog.VersionNums))
+		for i, num := range catalog.VersionNums {
+			strs[i] = strconv.Itoa(num)
+		}
+		return fmt.Errorf("oracle %s is not supported, introspection requires Oracle 12.2 or higher", strings.Join(strs, "."))
+	}
+
+	catalog.Edition, err = dbi.GetEdition()
+	if err != nil {
+		return err
+	}
 
 	catalog.CatalogName, err = dbi.GetDatabaseName()
 	if err != nil {
@@ -118,6 +132,18 @@ func (dbi *DatabaseIntrospector) WriteCatalog(catalog *Catalog) error {
 		}
 	}
 
+	if includeObjectType("SEQUENCES") {
+		sequences, err := dbi.GetSequences()
+		if err != nil {
+			return err
+		}
+		for _, sequence := range sequences {
+			schema := cache.GetOrCreateSchema(catalog, sequence.SequenceSchema)
+			schema.SequencesValid = true
+			cache.AddOrUpdateSequence(schema, sequence)
+		}
+	}
+
 	if includeObjectType("ROUTINES") {
 		routines, err := dbi.GetRoutines()
 		if err != nil {
@@ -153,6 +179,22 @@ func (dbi *DatabaseIntrospector) WriteCatalog(catalog *Catalog) error {
 			cache.AddOrUpdateTable(schema, table)
 		}
 
+		partitions, err := dbi.GetPartitions()
+		if err != nil {
+			return err
+		}
+		for _, partition := range partitions {
+			schema := cache.GetSchema(catalog, partition.TableSchema)
+			if schema == nil {
+				continue
+			}
+			table := cache.GetTable(schema, partition.TableName)
+			if table == nil {
+				continue
+			}
+			table.Partitions = append(table.Partitions, partition)
+		}
+
 		columns, err := dbi.GetColumns()
 		if err != nil {
 			return err
@@ -290,6 +332,11 @@ func (dbi *DatabaseIntrospector) GetVersion() (version string, err error) {
 		if err != nil {
 			return "", err
 		}
+	case DialectOracle:
+		rows, err = dbi.DB.QueryContext(ctx, "SELECT version FROM product_component_version WHERE product LIKE 'Oracle%' AND ROWNUM = 1")
+		if err != nil {
+			return "", err
+		}
 	default:
 		return "", fmt.Errorf("unsupported dialect: %s", dbi.Dialect)
 	}
@@ -331,6 +378,27 @@ func (dbi *DatabaseIntrospector) GetVersionNums() (versionNums VersionNums, err
 	return versionNums, nil
 }
 
+// GetEdition returns the edition of the database e.g. "Enterprise Edition
+// (64-bit)", "Standard Edition (64-bit)" or "SQL Azure". It is always empty
+// for dialects other than SQL Server.
+func (dbi *DatabaseIntrospector) GetEdition() (edition string, err error) {
+	if dbi.Dialect != DialectSQLServer {
+		return "", nil
+	}
+	rows, err := dbi.DB.QueryContext(context.Background(), "SELECT SERVERPROPERTY('Edition')")
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	if rows.Next() {
+		err = rows.Scan(&edition)
+		if err != nil {
+			return "", fmt.Errorf("scanning edition: %w", err)
+		}
+	}
+	return edition, closeRows(rows)
+}
+
 // GetDatabaseName returns the database name.
 func (dbi *DatabaseIntrospector) GetDatabaseName() (databaseName string, err error) {
 	ctx := context.Background()
@@ -353,6 +421,11 @@ func (dbi *DatabaseIntrospector) GetDatabaseName() (databaseName string, err err
 		if err != nil {
 			return "", err
 		}
+	case DialectOracle:
+		rows, err = dbi.DB.QueryContext(ctx, "SELECT SYS_CONTEXT('USERENV', 'DB_NAME') FROM dual")
+		if err != nil {
+			return "", err
+		}
 	default:
 		return "", fmt.Errorf("unsupported dialect: %s", dbi.Dialect)
 	}
@@ -388,6 +461,11 @@ func (dbi *DatabaseIntrospector) GetCurrentSchema() (currentSchema string, err e
 		if err != nil {
 			return "", err
 		}
+	case DialectOracle:
+		rows, err = dbi.DB.QueryContext(ctx, "SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') FROM dual")
+		if err != nil {
+			return "", err
+		}
 	default:
 		return "", fmt.Errorf("unsupported dialect: %s", dbi.Dialect)
 	}
@@ -398,6 +476,9 @@ func (dbi *DatabaseIntrospector) GetCurrentSchema() (currentSchema string, err e
 			return "", fmt.Errorf("scanning currentSchema: %w", err)
 		}
 	}
+	if dbi.Dialect == DialectOracle {
+		currentSchema = oracleName(currentSchema)
+	}
 	return currentSchema, closeRows(rows)
 }
 
@@ -406,7 +487,7 @@ func (dbi *DatabaseIntrospector) GetDefaultCollation() (defaultCollation string,
 	ctx := context.Background()
 	var rows *sql.Rows
 	switch dbi.Dialect {
-	case DialectSQLite:
+	case DialectSQLite, DialectOracle:
 		return "", nil
 	case DialectPostgres:
 		rows, err = dbi.DB.QueryContext(ctx, "SELECT datcollate AS collation FROM pg_database WHERE datname = current_database()")
@@ -467,6 +548,11 @@ func (dbi *DatabaseIntrospector) GetColumns() ([]Column, error) {
 		if err != nil {
 			return nil, err
 		}
+	case DialectOracle:
+		rows, err = dbi.queryContext(ctx, "introspection_scripts/oracle_columns.sql", oracleFilter(&dbi.Filter))
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unsupported dialect: %s", dbi.Dialect)
 	}
@@ -507,6 +593,8 @@ func (dbi *DatabaseIntrospector) GetColumns() ([]Column, error) {
 				&column.NumericPrecision,
 				&column.NumericScale,
 				&column.ColumnIdentity,
+				&column.IdentityStart,
+				&column.IdentityIncrement,
 				&column.IsNotNull,
 				&column.GeneratedExpr,
 				&column.GeneratedExprStored,
@@ -607,11 +695,14 @@ func (dbi *DatabaseIntrospector) GetColumns() ([]Column, error) {
 				&column.NumericPrecision,
 				&column.NumericScale,
 				&column.ColumnIdentity,
+				&column.IdentityStart,
+				&column.IdentityIncrement,
 				&column.IsNotNull,
 				&column.GeneratedExpr,
 				&column.GeneratedExprStored,
 				&column.CollationName,
 				&column.ColumnDefault,
+				&column.Comment,
 			)
 			if err != nil {
 				return nil, fmt.Errorf("scanning Column: %w", err)
@@ -640,6 +731,69 @@ func (dbi *DatabaseIntrospector) GetColumns() ([]Column, error) {
 			if !isLiteral(column.ColumnDefault) {
 				column.ColumnDefault = wrapBrackets(column.ColumnDefault)
 			}
+		case DialectOracle:
+			// Oracle treats empty strings as NULL, so every optional field
+			// has to be scanned as an sql.NullString.
+			var characterLength, numericPrecision, numericScale, columnIdentity, generatedExpr, columnDefault, comment sql.NullString
+			var identityStart, identityIncrement sql.NullInt64
+			err = rows.Scan(
+				&column.TableSchema,
+				&column.TableName,
+				&column.ColumnName,
+				&column.ColumnType,
+				&characterLength,
+				&numericPrecision,
+				&numericScale,
+				&columnIdentity,
+				&identityStart,
+				&identityIncrement,
+				&column.IsNotNull,
+				&generatedExpr,
+				&columnDefault,
+				&comment,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("scanning Column: %w", err)
+			}
+			column.TableSchema = oracleName(column.TableSchema)
+			column.TableName = oracleName(column.TableName)
+			column.ColumnName = oracleName(column.ColumnName)
+			column.ColumnIdentity = columnIdentity.String
+			column.IdentityStart = identityStart.Int64
+			column.IdentityIncrement = identityIncrement.Int64
+			column.GeneratedExpr = strings.TrimSpace(generatedExpr.String)
+			column.ColumnDefault = strings.TrimSpace(columnDefault.String)
+			column.Comment = comment.String
+			switch column.ColumnType {
+			case "VARCHAR2", "NVARCHAR2", "CHAR", "NCHAR", "RAW":
+				column.CharacterLength = characterLength.String
+				column.ColumnType = column.ColumnType + "(" + column.CharacterLength + ")"
+			case "NUMBER":
+				column.NumericPrecision, column.NumericScale = numericPrecision.String, numericScale.String
+				if column.NumericPrecision == "" && column.NumericScale == "0" {
+					// INTEGER columns are stored as a NUMBER with no
+					// precision and a scale of 0.
+					column.NumericPrecision = "38"
+				}
+				if column.NumericPrecision != "" && column.NumericScale != "" && column.NumericScale != "0" {
+					column.ColumnType = column.ColumnType + "(" + column.NumericPrecision + "," + column.NumericScale + ")"
+				} else if column.NumericPrecision != "" {
+					column.ColumnType = column.ColumnType + "(" + column.NumericPrecision + ")"
+				}
+			case "FLOAT":
+				column.NumericPrecision = numericPrecision.String
+				if column.NumericPrecision != "" {
+					column.ColumnType = column.ColumnType + "(" + column.NumericPrecision + ")"
+				}
+			}
+		}
+		// Identity options that match the database default of 1 are left
+		// zero, the same way they are when declared without them.
+		if column.IdentityStart == 1 {
+			column.IdentityStart = 0
+		}
+		if column.IdentityIncrement == 1 {
+			column.IdentityIncrement = 0
 		}
 		columns = append(columns, column)
 	}
@@ -681,6 +835,11 @@ func (dbi *DatabaseIntrospector) GetConstraints() ([]Constraint, error) {
 		if err != nil {
 			return nil, err
 		}
+	case DialectOracle:
+		rows, err = dbi.queryContext(ctx, "introspection_scripts/oracle_constraints.sql", oracleFilter(&dbi.Filter))
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unsupported dialect: %s", dbi.Dialect)
 	}
@@ -802,6 +961,43 @@ func (dbi *DatabaseIntrospector) GetConstraints() ([]Constraint, error) {
 			constraint.UpdateRule = strings.ReplaceAll(constraint.UpdateRule, "_", " ")
 			constraint.DeleteRule = strings.ReplaceAll(constraint.DeleteRule, "_", " ")
 			constraint.CheckExpr = unwrapBrackets(constraint.CheckExpr)
+		case DialectOracle:
+			var columns, referencesSchema, referencesTable, referencesColumns, deleteRule, checkExpr sql.NullString
+			err = rows.Scan(
+				&constraint.TableSchema,
+				&constraint.TableName,
+				&constraint.ConstraintName,
+				&constraint.ConstraintType,
+				&columns,
+				&referencesSchema,
+				&referencesTable,
+				&referencesColumns,
+				&deleteRule,
+				&checkExpr,
+				&constraint.IsDeferrable,
+				&constraint.IsInitiallyDeferred,
+				&constraint.IsNotValid,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("scanning Constraint: %w", err)
+			}
+			constraint.TableSchema = oracleName(constraint.TableSchema)
+			constraint.TableName = oracleName(constraint.TableName)
+			constraint.ConstraintName = oracleName(constraint.ConstraintName)
+			constraint.ReferencesSchema = oracleName(referencesSchema.String)
+			constraint.ReferencesTable = oracleName(referencesTable.String)
+			if columns.String != "" {
+				for _, column := range strings.Split(columns.String, ",") {
+					constraint.Columns = append(constraint.Columns, oracleName(column))
+				}
+			}
+			if referencesColumns.String != "" {
+				for _, column := range strings.Split(referencesColumns.String, ",") {
+					constraint.ReferencesColumns = append(constraint.ReferencesColumns, oracleName(column))
+				}
+			}
+			constraint.DeleteRule = deleteRule.String
+			constraint.CheckExpr = unwrapBrackets(strings.TrimSpace(checkExpr.String))
 		}
 		// When deserializing a Catalog from JSON, empty slices are set as nil
 		// (because of omitempty). If we want to follow that precedent we have
@@ -823,7 +1019,22 @@ func (dbi *DatabaseIntrospector) GetConstraints() ([]Constraint, error) {
 		}
 		constraints = append(constraints, constraint)
 	}
-	return constraints, closeRows(rows)
+	err = closeRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite does not expose CHECK constraints through its pragmas, so we
+	// have to extract them from the CREATE TABLE statements instead.
+	if dbi.Dialect == DialectSQLite && dbi.Filter.IncludeConstraintType(CHECK) {
+		tables, err := dbi.GetTables()
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range tables {
+			constraints = append(constraints, sqliteCheckConstraints(table.TableName, table.SQL)...)
+		}
+	}
+	return constraints, nil
 }
 
 // GetDomains returns the domains in the database. Postgres only.
@@ -924,6 +1135,103 @@ func (dbi *DatabaseIntrospector) GetEnums() ([]Enum, error) {
 	return enums, closeRows(rows)
 }
 
+// GetSequences returns the sequences in the database. Postgres, SQL Server
+// and Oracle only. The sequences backing identity columns are not included.
+//
+// To search for specific sequences, add the sequence names into the
+// DatabaseIntrospector.Filter.Sequences slice. To exclude specific sequences
+// from your search, add the sequence names into the
+// DatabaseIntrospector.Filter.ExcludeSequences slice.
+//
+// To narrow down your search to a specific schema, pass the schema name into
+// the DatabaseIntrospector.Filter.Schemas slice.
+func (dbi *DatabaseIntrospector) GetSequences() ([]Sequence, error) {
+	ctx := context.Background()
+	var err error
+	var rows *sql.Rows
+	switch dbi.Dialect {
+	case DialectSQLite, DialectMySQL:
+		return nil, nil
+	case DialectPostgres:
+		// pg_sequence was only added in Postgres 10.
+		if dbi.VersionNums.LowerThan(10) {
+			return nil, nil
+		}
+		rows, err = dbi.queryContext(ctx, "introspection_scripts/postgres_sequences.sql", &dbi.Filter)
+		if err != nil {
+			return nil, err
+		}
+	case DialectSQLServer:
+		rows, err = dbi.queryContext(ctx, "introspection_scripts/sqlserver_sequences.sql", &dbi.Filter)
+		if err != nil {
+			return nil, err
+		}
+	case DialectOracle:
+		rows, err = dbi.queryContext(ctx, "introspection_scripts/oracle_sequences.sql", oracleFilter(&dbi.Filter))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported dialect: %s", dbi.Dialect)
+	}
+	defer rows.Close()
+	var sequences []Sequence
+	for rows.Next() {
+		var sequence Sequence
+		switch dbi.Dialect {
+		case DialectPostgres:
+			err = rows.Scan(
+				&sequence.SequenceSchema,
+				&sequence.SequenceName,
+				&sequence.DataType,
+				&sequence.StartValue,
+				&sequence.IncrementBy,
+				&sequence.MinValue,
+				&sequence.MaxValue,
+				&sequence.IsCycle,
+				&sequence.OwnedByTable,
+				&sequence.OwnedByColumn,
+				&sequence.LastValue,
+				&sequence.Comment,
+			)
+			sequence.DataType = strings.ToUpper(sequence.DataType)
+		case DialectSQLServer:
+			err = rows.Scan(
+				&sequence.SequenceSchema,
+				&sequence.SequenceName,
+				&sequence.DataType,
+				&sequence.StartValue,
+				&sequence.IncrementBy,
+				&sequence.MinValue,
+				&sequence.MaxValue,
+				&sequence.IsCycle,
+				&sequence.LastValue,
+				&sequence.Comment,
+			)
+			sequence.DataType = strings.ToUpper(sequence.DataType)
+		case DialectOracle:
+			var cycleFlag string
+			err = rows.Scan(
+				&sequence.SequenceSchema,
+				&sequence.SequenceName,
+				&sequence.IncrementBy,
+				&sequence.MinValue,
+				&sequence.MaxValue,
+				&cycleFlag,
+				&sequence.LastValue,
+			)
+			sequence.SequenceSchema = oracleName(sequence.SequenceSchema)
+			sequence.SequenceName = oracleName(sequence.SequenceName)
+			sequence.IsCycle = cycleFlag == "Y"
+		}
+		if err != nil {
+			return nil, fmt.Errorf("scanning sequence: %w", err)
+		}
+		sequences = append(sequences, sequence)
+	}
+	return sequences, closeRows(rows)
+}
+
 // GetExtensions returns the extensions in the database. Postgres only.
 //
 // To search for specific extensions, add the extension names into the
@@ -990,6 +1298,11 @@ func (dbi *DatabaseIntrospector) GetIndexes() ([]Index, error) {
 		if err != nil {
 			return nil, err
 		}
+	case DialectOracle:
+		rows, err = dbi.queryContext(ctx, "introspection_scripts/oracle_indexes.sql", oracleFilter(&dbi.Filter))
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unsupported dialect: %s", dbi.Dialect)
 	}
@@ -999,12 +1312,13 @@ func (dbi *DatabaseIntrospector) GetIndexes() ([]Index, error) {
 		var index Index
 		switch dbi.Dialect {
 		case DialectSQLite:
-			var columns string
+			var columns, descending string
 			err = rows.Scan(
 				&index.TableName,
 				&index.IndexName,
 				&index.IsUnique,
 				&columns,
+				&descending,
 				&index.SQL,
 			)
 			if err != nil {
@@ -1013,8 +1327,15 @@ func (dbi *DatabaseIntrospector) GetIndexes() ([]Index, error) {
 			if columns != "" {
 				index.Columns = strings.Split(columns, ",")
 			}
+			// Only keep the descending flags if any column is descending.
+			if strings.Contains(descending, "1") {
+				for _, s := range strings.Split(descending, ",") {
+					index.Descending = append(index.Descending, s == "1")
+				}
+			}
+			index.Predicate = sqliteIndexPredicate(index.SQL)
 		case DialectPostgres:
-			var columns, opclasses []byte
+			var columns, opclasses, descending []byte
 			var numKeyColumns int
 			err = rows.Scan(
 				&index.TableSchema,
@@ -1026,6 +1347,7 @@ func (dbi *DatabaseIntrospector) GetIndexes() ([]Index, error) {
 				&numKeyColumns,
 				&columns,
 				&opclasses,
+				&descending,
 				&index.Predicate,
 				&index.SQL,
 			)
@@ -1040,7 +1362,22 @@ func (dbi *DatabaseIntrospector) GetIndexes() ([]Index, error) {
 			if err != nil {
 				return nil, fmt.Errorf("unmarshaling %s into %T: %w", opclasses, index.Opclasses, err)
 			}
+			err = json.Unmarshal(descending, &index.Descending)
+			if err != nil {
+				return nil, fmt.Errorf("unmarshaling %s into %T: %w", descending, index.Descending, err)
+			}
 			index.Columns, index.IncludeColumns = index.Columns[:numKeyColumns], index.Columns[numKeyColumns:]
+			// Only keep the descending flags if any key column is descending.
+			if len(index.Descending) > numKeyColumns {
+				index.Descending = index.Descending[:numKeyColumns]
+			}
+			hasDescending := false
+			for _, isDescending := range index.Descending {
+				hasDescending = hasDescending || isDescending
+			}
+			if !hasDescending {
+				index.Descending = nil
+			}
 		case DialectMySQL:
 			var columns, descending string
 			err = rows.Scan(
@@ -1106,6 +1443,35 @@ func (dbi *DatabaseIntrospector) GetIndexes() ([]Index, error) {
 				}
 				index.Descending = index.Descending[len(index.IncludeColumns):]
 			}
+		case DialectOracle:
+			var columns, descending string
+			err = rows.Scan(
+				&index.TableSchema,
+				&index.TableName,
+				&index.IndexName,
+				&index.IndexType,
+				&index.IsViewIndex,
+				&index.IsUnique,
+				&columns,
+				&descending,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("scanning Index: %w", err)
+			}
+			index.TableSchema = oracleName(index.TableSchema)
+			index.TableName = oracleName(index.TableName)
+			index.IndexName = oracleName(index.IndexName)
+			if columns != "" {
+				for _, column := range strings.Split(columns, ",") {
+					index.Columns = append(index.Columns, oracleName(column))
+				}
+			}
+			if descending != "" {
+				for _, str := range strings.Split(descending, ",") {
+					b, _ := strconv.ParseBool(str)
+					index.Descending = append(index.Descending, b)
+				}
+			}
 		}
 		// When deserializing a Catalog from JSON, empty slices are set as nil
 		// (because of omitempty). If we want to follow that precedent we have
@@ -1159,6 +1525,11 @@ func (dbi *DatabaseIntrospector) GetRoutines() ([]Routine, error) {
 		if err != nil {
 			return nil, err
 		}
+	case DialectOracle:
+		rows, err = dbi.queryContext(ctx, "introspection_scripts/oracle_routines.sql", oracleFilter(&dbi.Filter))
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unsupported dialect: %s", dbi.Dialect)
 	}
@@ -1224,6 +1595,19 @@ func (dbi *DatabaseIntrospector) GetRoutines() ([]Routine, error) {
 			if err != nil {
 				return nil, fmt.Errorf("scanning Routine: %w", err)
 			}
+		case DialectOracle:
+			err = rows.Scan(
+				&routine.RoutineSchema,
+				&routine.RoutineName,
+				&routine.RoutineType,
+				&routine.SQL,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("scanning Routine: %w", err)
+			}
+			routine.RoutineSchema = oracleName(routine.RoutineSchema)
+			routine.RoutineName = oracleName(routine.RoutineName)
+			routine.SQL = strings.TrimSpace(routine.SQL)
 		}
 		routine.SQL = strings.ReplaceAll(routine.SQL, "\r\n", "\n")
 		routines = append(routines, routine)
@@ -1268,6 +1652,11 @@ func (dbi *DatabaseIntrospector) GetTables() ([]Table, error) {
 		if err != nil {
 			return nil, err
 		}
+	case DialectOracle:
+		rows, err = dbi.queryContext(ctx, "introspection_scripts/oracle_tables.sql", oracleFilter(&dbi.Filter))
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unsupported dialect: %s", dbi.Dialect)
 	}
@@ -1281,16 +1670,47 @@ func (dbi *DatabaseIntrospector) GetTables() ([]Table, error) {
 			if err != nil {
 				return nil, fmt.Errorf("scanning Table: %w", err)
 			}
-		case DialectPostgres, DialectMySQL:
-			err = rows.Scan(&table.TableSchema, &table.TableName, &table.Comment)
+			table.IsStrict, table.IsWithoutRowid = sqliteTableOptions(table.SQL)
+		case DialectPostgres:
+			var partitionKey, storageParams string
+			err = rows.Scan(&table.TableSchema, &table.TableName, &table.Comment, &partitionKey, &table.IsUnlogged, &storageParams)
 			if err != nil {
 				return nil, fmt.Errorf("scanning Table: %w", err)
 			}
+			if storageParams != "" {
+				table.StorageParams = strings.Split(storageParams, ",")
+			}
+			// pg_get_partkeydef returns the partition key in the form
+			// "RANGE (created_at)".
+			if i := strings.IndexByte(partitionKey, ' '); i >= 0 {
+				table.PartitionStrategy = strings.ToUpper(partitionKey[:i])
+				table.PartitionKey = strings.TrimSpace(partitionKey[i+1:])
+				if strings.HasPrefix(table.PartitionKey, "(") && strings.HasSuffix(table.PartitionKey, ")") {
+					table.PartitionKey = table.PartitionKey[1 : len(table.PartitionKey)-1]
+				}
+			}
+		case DialectMySQL:
+			err = rows.Scan(&table.TableSchema, &table.TableName, &table.Comment, &table.PartitionStrategy, &table.PartitionKey, &table.Engine, &table.Charset)
+			if err != nil {
+				return nil, fmt.Errorf("scanning Table: %w", err)
+			}
+			// Backticks cannot be represented in a struct tag, so strip them
+			// from the partition key.
+			table.PartitionKey = strings.ReplaceAll(table.PartitionKey, "`", "")
 		case DialectSQLServer:
-			err = rows.Scan(&table.TableSchema, &table.TableName)
+			err = rows.Scan(&table.TableSchema, &table.TableName, &table.Comment)
 			if err != nil {
 				return nil, fmt.Errorf("scanning Table: %w", err)
 			}
+		case DialectOracle:
+			var comment sql.NullString
+			err = rows.Scan(&table.TableSchema, &table.TableName, &comment)
+			if err != nil {
+				return nil, fmt.Errorf("scanning Table: %w", err)
+			}
+			table.TableSchema = oracleName(table.TableSchema)
+			table.TableName = oracleName(table.TableName)
+			table.Comment = comment.String
 		}
 		table.SQL = strings.ReplaceAll(table.SQL, "\r\n", "\n")
 		tables = append(tables, table)
@@ -1298,6 +1718,85 @@ func (dbi *DatabaseIntrospector) GetTables() ([]Table, error) {
 	return tables, closeRows(rows)
 }
 
+// sqliteTableOptions reports whether an SQLite CREATE TABLE statement
+// declares a STRICT and/or WITHOUT ROWID table. The table options come after
+// the closing bracket of the column definitions.
+func sqliteTableOptions(sql string) (isStrict, isWithoutRowid bool) {
+	i := strings.LastIndexByte(sql, ')')
+	if i < 0 {
+		return false, false
+	}
+	for _, option := range strings.Split(strings.TrimSuffix(strings.TrimSpace(sql[i+1:]), ";"), ",") {
+		switch strings.ToUpper(strings.Join(strings.Fields(option), " ")) {
+		case "STRICT":
+			isStrict = true
+		case "WITHOUT ROWID":
+			isWithoutRowid = true
+		}
+	}
+	return isStrict, isWithoutRowid
+}
+
+// GetPartitions returns the partitions of the partitioned tables in the
+// database. Only Postgres and MySQL are supported, other dialects return no
+// partitions.
+//
+// To narrow down your search to a specific schema and table, pass the schema
+// and table names into the DatabaseIntrospector.Filter.Schemas slice and
+// the DatabaseIntrospector.Filter.Tables slice respectively.
+func (dbi *DatabaseIntrospector) GetPartitions() ([]Partition, error) {
+	ctx := context.Background()
+	var err error
+	var rows *sql.Rows
+	switch dbi.Dialect {
+	case DialectSQLite, DialectSQLServer, DialectOracle:
+		return nil, nil
+	case DialectPostgres:
+		rows, err = dbi.queryContext(ctx, "introspection_scripts/postgres_partitions.sql", &dbi.Filter)
+		if err != nil {
+			return nil, err
+		}
+	case DialectMySQL:
+		rows, err = dbi.queryContext(ctx, "introspection_scripts/mysql_partitions.sql", &dbi.Filter)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported dialect: %s", dbi.Dialect)
+	}
+	defer rows.Close()
+	var partitions []Partition
+	for rows.Next() {
+		var partition Partition
+		switch dbi.Dialect {
+		case DialectPostgres:
+			err = rows.Scan(&partition.TableSchema, &partition.TableName, &partition.PartitionName, &partition.PartitionBound)
+			if err != nil {
+				return nil, fmt.Errorf("scanning Partition: %w", err)
+			}
+		case DialectMySQL:
+			var partitionMethod, partitionDescription string
+			err = rows.Scan(&partition.TableSchema, &partition.TableName, &partition.PartitionName, &partitionMethod, &partitionDescription)
+			if err != nil {
+				return nil, fmt.Errorf("scanning Partition: %w", err)
+			}
+			// HASH and KEY partitions have no partition bound.
+			switch {
+			case strings.HasPrefix(partitionMethod, "RANGE"):
+				if partitionDescription == "MAXVALUE" {
+					partition.PartitionBound = "VALUES LESS THAN MAXVALUE"
+				} else {
+					partition.PartitionBound = "VALUES LESS THAN (" + partitionDescription + ")"
+				}
+			case strings.HasPrefix(partitionMethod, "LIST"):
+				partition.PartitionBound = "VALUES IN (" + partitionDescription + ")"
+			}
+		}
+		partitions = append(partitions, partition)
+	}
+	return partitions, closeRows(rows)
+}
+
 // GetTriggers returns the triggers in the database.
 //
 // To narrow down your search to a specific schema and table, pass the schema
@@ -1328,6 +1827,11 @@ func (dbi *DatabaseIntrospector) GetTriggers() ([]Trigger, error) {
 		if err != nil {
 			return nil, err
 		}
+	case DialectOracle:
+		rows, err = dbi.queryContext(ctx, "introspection_scripts/oracle_triggers.sql", oracleFilter(&dbi.Filter))
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unsupported dialect: %s", dbi.Dialect)
 	}
@@ -1353,7 +1857,7 @@ func (dbi *DatabaseIntrospector) GetTriggers() ([]Trigger, error) {
 			if err != nil {
 				return nil, fmt.Errorf("scanning Trigger: %w", err)
 			}
-		case DialectSQLServer:
+		case DialectSQLServer, DialectOracle:
 			err = rows.Scan(
 				&trigger.TableSchema,
 				&trigger.TableName,
@@ -1364,6 +1868,12 @@ func (dbi *DatabaseIntrospector) GetTriggers() ([]Trigger, error) {
 			if err != nil {
 				return nil, fmt.Errorf("scanning Trigger: %w", err)
 			}
+			if dbi.Dialect == DialectOracle {
+				trigger.TableSchema = oracleName(trigger.TableSchema)
+				trigger.TableName = oracleName(trigger.TableName)
+				trigger.TriggerName = oracleName(trigger.TriggerName)
+				trigger.SQL = strings.TrimSpace(trigger.SQL)
+			}
 		case DialectMySQL:
 			var actionTiming, eventManipulation string
 			err = rows.Scan(
@@ -1422,6 +1932,11 @@ func (dbi *DatabaseIntrospector) GetViews() ([]View, error) {
 		if err != nil {
 			return nil, err
 		}
+	case DialectOracle:
+		rows, err = dbi.queryContext(ctx, "introspection_scripts/oracle_views.sql", oracleFilter(&dbi.Filter))
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unsupported dialect: %s", dbi.Dialect)
 	}
@@ -1486,6 +2001,23 @@ func (dbi *DatabaseIntrospector) GetViews() ([]View, error) {
 			if s2 != "" {
 				view.ColumnTypes = strings.Split(s2, "|")
 			}
+		case DialectOracle:
+			var s1, s2 string
+			err = rows.Scan(&view.ViewSchema, &view.ViewName, &view.SQL, &s1, &s2)
+			if err != nil {
+				return nil, fmt.Errorf("scanning View: %w", err)
+			}
+			view.ViewSchema = oracleName(view.ViewSchema)
+			view.ViewName = oracleName(view.ViewName)
+			view.SQL = strings.TrimSpace(view.SQL)
+			if s1 != "" {
+				for _, column := range strings.Split(s1, "|") {
+					view.Columns = append(view.Columns, oracleName(column))
+				}
+			}
+			if s2 != "" {
+				view.ColumnTypes = strings.Split(s2, "|")
+			}
 		}
 		view.SQL = strings.ReplaceAll(view.SQL, "\r\n", "\n")
 		views = append(views, view)
@@ -1527,6 +2059,155 @@ func (dbi *DatabaseIntrospector) queryContext(ctx context.Context, filename stri
 	return rows, nil
 }
 
+// oracleName converts an Oracle identifier into the form used by the rest of
+// the package. Oracle stores unquoted identifiers in uppercase, so an
+// all-uppercase identifier is lowercased to match the names used in the
+// table structs. Identifiers containing lowercase characters must have been
+// quoted and are returned as-is.
+func oracleName(name string) string {
+	if name != strings.ToUpper(name) {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+// oracleFilter returns a copy of the filter with the names converted back
+// into the uppercase form that Oracle stores them in. It is the inverse of
+// oracleName.
+func oracleFilter(filter *Filter) *Filter {
+	upper := func(names []string) []string {
+		if len(names) == 0 {
+			return names
+		}
+		result := make([]string, len(names))
+		for i, name := range names {
+			if name == strings.ToLower(name) {
+				name = strings.ToUpper(name)
+			}
+			result[i] = name
+		}
+		return result
+	}
+	f := *filter
+	f.Schemas = upper(f.Schemas)
+	f.ExcludeSchemas = upper(f.ExcludeSchemas)
+	f.Tables = upper(f.Tables)
+	f.ExcludeTables = upper(f.ExcludeTables)
+	f.Views = upper(f.Views)
+	f.ExcludeViews = upper(f.ExcludeViews)
+	f.Routines = upper(f.Routines)
+	f.ExcludeRoutines = upper(f.ExcludeRoutines)
+	f.Sequences = upper(f.Sequences)
+	f.ExcludeSequences = upper(f.ExcludeSequences)
+	return &f
+}
+
+// sqliteCheckConstraints extracts the named table-level CHECK constraints
+// from an SQLite CREATE TABLE statement. Unnamed CHECK constraints are skipped
+// because there is no name to identify them by.
+func sqliteCheckConstraints(tableName, createTable string) []Constraint {
+	start := strings.Index(createTable, "(")
+	end := strings.LastIndex(createTable, ")")
+	if start < 0 || end < start {
+		return nil
+	}
+	var constraints []Constraint
+	for _, arg := range splitArgs(createTable[start+1 : end]) {
+		arg = strings.TrimSpace(arg)
+		if len(arg) < len("CONSTRAINT ") || !strings.EqualFold(arg[:len("CONSTRAINT ")], "CONSTRAINT ") {
+			continue
+		}
+		arg = strings.TrimSpace(arg[len("CONSTRAINT "):])
+		var constraintName string
+		if arg != "" && (arg[0] == '"' || arg[0] == '`' || arg[0] == '[') {
+			closingQuote := arg[0]
+			if closingQuote == '[' {
+				closingQuote = ']'
+			}
+			i := 1
+			for i < len(arg) {
+				if arg[i] == closingQuote {
+					// A doubled quote is an escaped quote.
+					if i+1 < len(arg) && arg[i+1] == closingQuote {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			if i >= len(arg) {
+				continue
+			}
+			constraintName = strings.ReplaceAll(arg[1:i], string(closingQuote)+string(closingQuote), string(closingQuote))
+			arg = strings.TrimSpace(arg[i+1:])
+		} else {
+			i := strings.IndexAny(arg, " \t\n")
+			if i < 0 {
+				continue
+			}
+			constraintName = arg[:i]
+			arg = strings.TrimSpace(arg[i:])
+		}
+		if len(arg) < len("CHECK") || !strings.EqualFold(arg[:len("CHECK")], "CHECK") {
+			continue
+		}
+		constraints = append(constraints, Constraint{
+			TableName:      tableName,
+			ConstraintName: constraintName,
+			ConstraintType: CHECK,
+			CheckExpr:      unwrapBrackets(strings.TrimSpace(arg[len("CHECK"):])),
+		})
+	}
+	return constraints
+}
+
+// sqliteIndexPredicate extracts the WHERE predicate (if any) from an SQLite
+// CREATE INDEX statement.
+func sqliteIndexPredicate(createIndex string) string {
+	i := strings.Index(strings.ToUpper(createIndex), " ON ")
+	if i < 0 {
+		return ""
+	}
+	start := strings.IndexByte(createIndex[i:], '(')
+	if start < 0 {
+		return ""
+	}
+	// Find the bracket that closes the column list.
+	end := -1
+	bracketLevel := 0
+	insideString := false
+	for j := i + start; j < len(createIndex); j++ {
+		char := createIndex[j]
+		if insideString {
+			if char == '\'' {
+				insideString = false
+			}
+			continue
+		}
+		switch char {
+		case '\'':
+			insideString = true
+		case '(':
+			bracketLevel++
+		case ')':
+			bracketLevel--
+		}
+		if bracketLevel == 0 {
+			end = j
+			break
+		}
+	}
+	if end < 0 {
+		return ""
+	}
+	remainder := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(createIndex[end+1:]), ";"))
+	if len(remainder) < len("WHERE ") || !strings.EqualFold(remainder[:len("WHERE ")], "WHERE ") {
+		return ""
+	}
+	return strings.TrimSpace(remainder[len("WHERE "):])
+}
+
 func closeRows(rows *sql.Rows) error {
 	err := rows.Close()
 	if err != nil {
@@ -1566,8 +2247,8 @@ type Filter struct {
 
 	// ObjectTypes controls what object types will be included in the search.
 	// An empty slice means all object types will be included. The possible
-	// object types are: "EXTENSIONS", "ENUMS", "DOMAINS", "ROUTINES", "VIEWS"
-	// and "TABLES".
+	// object types are: "EXTENSIONS", "ENUMS", "DOMAINS", "SEQUENCES",
+	// "ROUTINES", "VIEWS" and "TABLES".
 	ObjectTypes []string
 
 	// Tables is the list of tables to be included in the search. If empty, all
@@ -1612,6 +2293,14 @@ type Filter struct {
 	// ExcludeDomains is the list of domains to be excluded from the search.
 	ExcludeDomains []string
 
+	// Sequences is the list of sequences to be included in the search. If
+	// empty, all sequences will be included.
+	Sequences []string
+
+	// ExcludeSequences is the list of sequences to be excluded from the
+	// search.
+	ExcludeSequences []string
+
 	// Extensions is the list of extensions to include in the search by
 	// DatabaseIntrospector.GetExtensions. If empty, all extensions will be
 	// included.
@@ -59,6 +59,10 @@ type Catalog struct {
 	// Example: Postgres 14.2 would be represented as []int{14, 2}.
 	VersionNums VersionNums `json:",omitempty"`
 
+	// Edition is the edition of the database e.g. "Enterprise Edition
+	// (64-bit)". SQL Server only.
+	Edition string `json:",omitempty"`
+
 	// Database name.
 	CatalogName string `json:",omitempty"`
 
@@ -117,6 +121,13 @@ type Schema struct {
 	// If DomainsValid is false, the schema's domain types are unknown.
 	DomainsValid bool `json:",omitempty"`
 
+	// The list of sequences within the schema. Postgres, SQL Server and
+	// Oracle only.
+	Sequences []Sequence `json:",omitempty"`
+
+	// If SequencesValid is false, the schema's sequences are unknown.
+	SequencesValid bool `json:",omitempty"`
+
 	// Comment stores the comment on the schema object.
 	Comment string `json:",omitempty"`
 
@@ -179,6 +190,59 @@ type Domain struct {
 	Ignore bool `json:",omitempty"`
 }
 
+// Sequence represents a database sequence. Postgres, SQL Server and Oracle
+// only.
+type Sequence struct {
+	// SequenceSchema is the name of schema that the sequence belongs to.
+	SequenceSchema string `json:",omitempty"`
+
+	// SequenceName is the name of the sequence.
+	SequenceName string `json:",omitempty"`
+
+	// DataType is the data type of the sequence e.g. "BIGINT". Postgres and
+	// SQL Server only.
+	DataType string `json:",omitempty"`
+
+	// StartValue is the value that the sequence starts with. A zero value
+	// means the database default is used.
+	StartValue int64 `json:",omitempty"`
+
+	// IncrementBy is the value that the sequence is incremented by. A zero
+	// value means the database default is used.
+	IncrementBy int64 `json:",omitempty"`
+
+	// MinValue is the minimum value of the sequence. A zero value means the
+	// database default is used.
+	MinValue int64 `json:",omitempty"`
+
+	// MaxValue is the maximum value of the sequence. A zero value means the
+	// database default is used.
+	MaxValue int64 `json:",omitempty"`
+
+	// IsCycle indicates if the sequence wraps around once it reaches its
+	// maximum (or minimum) value.
+	IsCycle bool `json:",omitempty"`
+
+	// OwnedByTable and OwnedByColumn are the table and column that own the
+	// sequence (the sequence is dropped together with the column). Postgres
+	// only.
+	OwnedByTable  string `json:",omitempty"`
+	OwnedByColumn string `json:",omitempty"`
+
+	// LastValue is the last value returned by the sequence. It is only
+	// populated by introspection and is used when dumping data so that the
+	// sequence can be restored to its current position. A zero value means the
+	// sequence has not been used yet.
+	LastValue int64 `json:",omitempty"`
+
+	// Comment stores the comment on the sequence.
+	Comment string `json:",omitempty"`
+
+	// If Ignore is true, the sequence should be treated like it doesn't exist
+	// (a soft delete flag).
+	Ignore bool `json:",omitempty"`
+}
+
 // Routine represents a database routine (either a stored procedure or a
 // function).
 type Routine struct {
@@ -262,6 +326,39 @@ type Table struct {
 	// IsVirtual indicates if the table is a virtual table. SQLite only.
 	IsVirtual bool `json:",omitempty"`
 
+	// PartitionStrategy is the partitioning strategy of the table if it is a
+	// partitioned table e.g. "RANGE", "LIST", "HASH". Postgres and MySQL only.
+	PartitionStrategy string `json:",omitempty"`
+
+	// PartitionKey is the partition key of the table if it is a partitioned
+	// table, without the surrounding brackets. Postgres and MySQL only.
+	PartitionKey string `json:",omitempty"`
+
+	// Partitions is the list of partitions of the table. Postgres and MySQL
+	// only.
+	Partitions []Partition `json:",omitempty"`
+
+	// Engine is the storage engine of the table e.g. "InnoDB". MySQL only.
+	Engine string `json:",omitempty"`
+
+	// Charset is the default character set of the table e.g. "utf8mb4".
+	// MySQL only.
+	Charset string `json:",omitempty"`
+
+	// StorageParams is the list of storage parameters of the table e.g.
+	// "fillfactor=70". Postgres only.
+	StorageParams []string `json:",omitempty"`
+
+	// IsUnlogged indicates if the table is an unlogged table. Postgres only.
+	IsUnlogged bool `json:",omitempty"`
+
+	// IsStrict indicates if the table is a STRICT table. SQLite only.
+	IsStrict bool `json:",omitempty"`
+
+	// IsWithoutRowid indicates if the table is a WITHOUT ROWID table. SQLite
+	// only.
+	IsWithoutRowid bool `json:",omitempty"`
+
 	// Columns is the list of columns within the table.
 	Columns []Column `json:",omitempty"`
 
@@ -274,6 +371,11 @@ type Table struct {
 	// Triggers is the list of triggers within the table.
 	Triggers []Trigger `json:",omitempty"`
 
+	// RenamedFrom stores the previous name of the table if the table was
+	// renamed. It is used when generating migrations to rename the existing
+	// table instead of dropping it and creating a new one.
+	RenamedFrom string `json:",omitempty"`
+
 	// Comment stores the comment on the table.
 	Comment string `json:",omitempty"`
 
@@ -282,6 +384,30 @@ type Table struct {
 	Ignore bool `json:",omitempty"`
 }
 
+// Partition represents a partition of a partitioned table. For Postgres, a
+// partition is a table of its own that is attached to the partitioned table.
+type Partition struct {
+	// TableSchema is the name of schema that the partitioned table belongs
+	// to.
+	TableSchema string `json:",omitempty"`
+
+	// TableName is the name of the partitioned table.
+	TableName string `json:",omitempty"`
+
+	// PartitionName is the name of the partition.
+	PartitionName string `json:",omitempty"`
+
+	// PartitionBound is the partition bound of the partition.
+	//
+	// Example: "FOR VALUES FROM ('2022-01-01') TO ('2023-01-01')" (Postgres),
+	// "VALUES LESS THAN (2023)" (MySQL).
+	PartitionBound string `json:",omitempty"`
+
+	// If Ignore is true, the partition should be treated like it doesn't
+	// exist (a soft delete flag).
+	Ignore bool `json:",omitempty"`
+}
+
 // Column represents a database column.
 type Column struct {
 	// TableSchema is the name of the schema that the table and column belong to.
@@ -371,6 +497,14 @@ type Column struct {
 	// AS IDENTITY" (Postgres), "IDENTITY" (SQLServer).
 	ColumnIdentity string `json:",omitempty"`
 
+	// IdentityStart stores the START WITH (seed) value of the identity column.
+	// Zero means the database default (1).
+	IdentityStart int64 `json:",omitempty"`
+
+	// IdentityIncrement stores the INCREMENT BY value of the identity column.
+	// Zero means the database default (1).
+	IdentityIncrement int64 `json:",omitempty"`
+
 	// ColumnDefault stores the default value of the column as it is literally
 	// represented in SQL. So if the default value is a string, the value
 	// should be surrounded by 'single quotes'. If the default value is a
@@ -398,6 +532,11 @@ type Column struct {
 	// collation is assumed to follow the DefaultCollation of the Catalog.
 	CollationName string `json:",omitempty"`
 
+	// RenamedFrom stores the previous name of the column if the column was
+	// renamed. It is used when generating migrations to rename the existing
+	// column instead of dropping it and adding a new one.
+	RenamedFrom string `json:",omitempty"`
+
 	// Comment stores the comment on the column.
 	Comment string `json:",omitempty"`
 
@@ -524,6 +663,16 @@ type Index struct {
 	// Predicate stores the index predicate i.e. the index is a partial index.
 	Predicate string `json:",omitempty"`
 
+	// Online indicates if the index should be created with WITH (ONLINE =
+	// ON). It is not introspected, it only affects generated migrations. SQL
+	// Server only.
+	Online bool `json:",omitempty"`
+
+	// Resumable indicates if the index should be created with WITH (ONLINE =
+	// ON, RESUMABLE = ON). It is not introspected, it only affects generated
+	// migrations. SQL Server only.
+	Resumable bool `json:",omitempty"`
+
 	// SQL is the SQL definition of the index.
 	SQL string `json:",omitempty"`
 
@@ -866,6 +1015,40 @@ func normalizeColumnType(dialect string, columnType string) (normalizedType, arg
 		case "DEC", "DECIMAL":
 			normalizedType = "NUMERIC"
 		}
+	case DialectOracle:
+		// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/Data-Types.html
+		switch strings.ReplaceAll(normalizedType, " ", "") {
+		// Numeric
+		case "INTEGER", "INT", "SMALLINT":
+			normalizedType, arg1, arg2 = "NUMBER", "38", ""
+		case "DEC", "DECIMAL", "NUMERIC", "NUMBER":
+			normalizedType = "NUMBER"
+			if arg2 == "0" {
+				arg2 = ""
+			}
+		case "DOUBLEPRECISION":
+			normalizedType, arg1, arg2 = "FLOAT", "126", ""
+		case "REAL":
+			normalizedType, arg1, arg2 = "FLOAT", "63", ""
+		// Character
+		case "VARCHAR", "CHARACTERVARYING", "CHARVARYING":
+			normalizedType = "VARCHAR2"
+			arg1 = strings.TrimSuffix(arg1, " BYTE")
+		case "VARCHAR2":
+			arg1 = strings.TrimSuffix(arg1, " BYTE")
+		case "NATIONALCHARACTERVARYING", "NATIONALCHARVARYING", "NCHARVARYING":
+			normalizedType = "NVARCHAR2"
+		case "CHARACTER":
+			normalizedType = "CHAR"
+		// TimeField
+		case "TIMESTAMP":
+			if arg1 == "6" {
+				arg1 = ""
+			}
+			if suffix != "" {
+				normalizedType = "TIMESTAMP " + strings.Join(strings.Fields(suffix), " ")
+			}
+		}
 	}
 	if isPostgresArray {
 		normalizedType = normalizedType + "[]"
@@ -909,10 +1092,226 @@ func normalizeColumnDefault(dialect string, columnDefault string) (normalizedDef
 		if upperDefault == "GETDATE()" {
 			return "CURRENT_TIMESTAMP"
 		}
+	case DialectOracle:
+		if upperDefault == "SYSDATE" || upperDefault == "SYSTIMESTAMP" {
+			return "CURRENT_TIMESTAMP"
+		}
 	}
 	return columnDefault
 }
 
+// isDefaultOpclass reports if an opclass is (probably) the default opclass for
+// its column type. Default opclasses are named after the type they operate on
+// e.g. int4_ops, text_ops, while non-default opclasses carry additional
+// qualifiers e.g. text_pattern_ops, gin_trgm_ops.
+func isDefaultOpclass(opclass string) bool {
+	return strings.Count(opclass, "_") <= 1
+}
+
+// normalizeIndexExpr will normalize an index column or index predicate so that
+// expressions written by hand can be meaningfully compared with the
+// expressions reported by the database (which may add brackets, quotes and
+// type casts).
+func normalizeIndexExpr(expr string) string {
+	var b strings.Builder
+	b.Grow(len(expr))
+	insideString := false
+	for i := 0; i < len(expr); i++ {
+		char := expr[i]
+		if insideString {
+			b.WriteByte(char)
+			if char == '\'' {
+				insideString = false
+			}
+			continue
+		}
+		switch char {
+		case '\'':
+			insideString = true
+			b.WriteByte(char)
+		case ' ', '\t', '\r', '\n', '(', ')', '[', ']', '"', '`':
+			continue
+		case ':':
+			if i+1 >= len(expr) || expr[i+1] != ':' {
+				b.WriteByte(char)
+				continue
+			}
+			// Skip Postgres type casts e.g. ::text, ::character varying.
+			i += 2
+			for i < len(expr) && (expr[i] == '_' || ('a' <= expr[i] && expr[i] <= 'z') || ('A' <= expr[i] && expr[i] <= 'Z') || ('0' <= expr[i] && expr[i] <= '9')) {
+				i++
+			}
+			for _, suffix := range []string{" varying", " precision", " with time zone", " without time zone", "[]"} {
+				if len(expr[i:]) >= len(suffix) && strings.EqualFold(expr[i:i+len(suffix)], suffix) {
+					i += len(suffix)
+					break
+				}
+			}
+			i--
+		default:
+			if 'A' <= char && char <= 'Z' {
+				char += 'a' - 'A'
+			}
+			b.WriteByte(char)
+		}
+	}
+	return b.String()
+}
+
+// indexesAreDifferent reports if two indexes with the same name differ in any
+// way that requires the index to be dropped and recreated.
+func indexesAreDifferent(dialect string, srcIndex, destIndex *Index) bool {
+	if srcIndex.IsUnique != destIndex.IsUnique {
+		return true
+	}
+	// The index type can only be set for Postgres and MySQL, other dialects
+	// report index types (CLUSTERED, NORMAL, etc) that cannot be expressed.
+	if dialect == DialectPostgres || dialect == DialectMySQL {
+		srcIndexType, destIndexType := strings.ToUpper(srcIndex.IndexType), strings.ToUpper(destIndex.IndexType)
+		if srcIndexType == "" {
+			srcIndexType = "BTREE"
+		}
+		if destIndexType == "" {
+			destIndexType = "BTREE"
+		}
+		if srcIndexType != destIndexType {
+			return true
+		}
+	}
+	if len(srcIndex.Columns) != len(destIndex.Columns) {
+		return true
+	}
+	for i := range srcIndex.Columns {
+		if normalizeIndexExpr(srcIndex.Columns[i]) != normalizeIndexExpr(destIndex.Columns[i]) {
+			return true
+		}
+		srcDescending := i < len(srcIndex.Descending) && srcIndex.Descending[i]
+		destDescending := i < len(destIndex.Descending) && destIndex.Descending[i]
+		if srcDescending != destDescending {
+			return true
+		}
+		if dialect == DialectPostgres {
+			var srcOpclass, destOpclass string
+			if i < len(srcIndex.Opclasses) && !isDefaultOpclass(srcIndex.Opclasses[i]) {
+				srcOpclass = srcIndex.Opclasses[i]
+			}
+			if i < len(destIndex.Opclasses) && !isDefaultOpclass(destIndex.Opclasses[i]) {
+				destOpclass = destIndex.Opclasses[i]
+			}
+			if srcOpclass != destOpclass {
+				return true
+			}
+		}
+	}
+	if len(srcIndex.IncludeColumns) != len(destIndex.IncludeColumns) {
+		return true
+	}
+	for i := range srcIndex.IncludeColumns {
+		if srcIndex.IncludeColumns[i] != destIndex.IncludeColumns[i] {
+			return true
+		}
+	}
+	return normalizeIndexExpr(srcIndex.Predicate) != normalizeIndexExpr(destIndex.Predicate)
+}
+
+// exclusionsAreDifferent reports if two EXCLUDE constraints with the same name
+// differ in any way that requires the constraint to be dropped and re-added.
+func exclusionsAreDifferent(srcConstraint, destConstraint *Constraint) bool {
+	// Postgres only supports ALTER CONSTRAINT for foreign keys, so a change
+	// in deferrability also requires the constraint to be re-added.
+	if srcConstraint.IsDeferrable != destConstraint.IsDeferrable || srcConstraint.IsInitiallyDeferred != destConstraint.IsInitiallyDeferred {
+		return true
+	}
+	srcIndexType, destIndexType := strings.ToUpper(srcConstraint.ExclusionIndexType), strings.ToUpper(destConstraint.ExclusionIndexType)
+	if srcIndexType == "" {
+		srcIndexType = "BTREE"
+	}
+	if destIndexType == "" {
+		destIndexType = "BTREE"
+	}
+	if srcIndexType != destIndexType {
+		return true
+	}
+	if len(srcConstraint.Columns) != len(destConstraint.Columns) {
+		return true
+	}
+	for i := range srcConstraint.Columns {
+		if normalizeIndexExpr(srcConstraint.Columns[i]) != normalizeIndexExpr(destConstraint.Columns[i]) {
+			return true
+		}
+		var srcOperator, destOperator string
+		if i < len(srcConstraint.ExclusionOperators) {
+			srcOperator = srcConstraint.ExclusionOperators[i]
+		}
+		if i < len(destConstraint.ExclusionOperators) {
+			destOperator = destConstraint.ExclusionOperators[i]
+		}
+		if srcOperator != destOperator {
+			return true
+		}
+	}
+	return normalizeIndexExpr(srcConstraint.ExclusionPredicate) != normalizeIndexExpr(destConstraint.ExclusionPredicate)
+}
+
+// identityOptions returns the start and increment of an identity column,
+// filling in the database default of 1 for any option that is not set.
+func identityOptions(column *Column) (start, increment int64) {
+	start, increment = column.IdentityStart, column.IdentityIncrement
+	if start == 0 {
+		start = 1
+	}
+	if increment == 0 {
+		increment = 1
+	}
+	return start, increment
+}
+
+// sequenceOptions holds the options changed by an ALTER SEQUENCE statement.
+// Zero values mean the option is left unchanged.
+type sequenceOptions struct {
+	dataType    string
+	startValue  int64
+	incrementBy int64
+	minValue    int64
+	maxValue    int64
+	cycle       string // "CYCLE" or "NO CYCLE".
+}
+
+// diffSequences returns the options that turn srcSequence into destSequence,
+// as well as the options that revert the change. Options left unspecified in
+// destSequence (zero values) are not compared.
+func diffSequences(srcSequence, destSequence *Sequence) (forward, backward sequenceOptions, isDifferent bool) {
+	if destSequence.DataType != "" && !strings.EqualFold(srcSequence.DataType, destSequence.DataType) {
+		forward.dataType, backward.dataType = destSequence.DataType, srcSequence.DataType
+		isDifferent = true
+	}
+	if destSequence.StartValue != 0 && srcSequence.StartValue != destSequence.StartValue {
+		forward.startValue, backward.startValue = destSequence.StartValue, srcSequence.StartValue
+		isDifferent = true
+	}
+	if destSequence.IncrementBy != 0 && srcSequence.IncrementBy != destSequence.IncrementBy {
+		forward.incrementBy, backward.incrementBy = destSequence.IncrementBy, srcSequence.IncrementBy
+		isDifferent = true
+	}
+	if destSequence.MinValue != 0 && srcSequence.MinValue != destSequence.MinValue {
+		forward.minValue, backward.minValue = destSequence.MinValue, srcSequence.MinValue
+		isDifferent = true
+	}
+	if destSequence.MaxValue != 0 && srcSequence.MaxValue != destSequence.MaxValue {
+		forward.maxValue, backward.maxValue = destSequence.MaxValue, srcSequence.MaxValue
+		isDifferent = true
+	}
+	if srcSequence.IsCycle != destSequence.IsCycle {
+		if destSequence.IsCycle {
+			forward.cycle, backward.cycle = "CYCLE", "NO CYCLE"
+		} else {
+			forward.cycle, backward.cycle = "NO CYCLE", "CYCLE"
+		}
+		isDifferent = true
+	}
+	return forward, backward, isDifferent
+}
+
 // dirFS is like os.DirFS without the restriction of banning filenames like
 // '../../somefile.sql'.
 type dirFS string
@@ -513,6 +513,37 @@ func Test_normalizeColumnDefault(t *testing.T) {
 	}})
 }
 
+func Test_normalizeIndexExpr(t *testing.T) {
+	type TT struct {
+		inputs   []string
+		wantExpr string
+	}
+
+	tests := []TT{{
+		inputs:   []string{"deleted_at IS NULL", "(deleted_at IS NULL)", "([deleted_at] IS NULL)"},
+		wantExpr: "deleted_atisnull",
+	}, {
+		inputs:   []string{"status = 'Active'", "(status = 'Active'::text)", "((status)::text = 'Active'::character varying)", "([status]='Active')"},
+		wantExpr: "status='Active'",
+	}, {
+		inputs:   []string{"created_at > '2020-01-01'", "(created_at > '2020-01-01'::timestamp with time zone)"},
+		wantExpr: "created_at>'2020-01-01'",
+	}}
+
+	for _, tt := range tests {
+		tt := tt
+		for _, input := range tt.inputs {
+			input := input
+			t.Run(input, func(t *testing.T) {
+				gotExpr := normalizeIndexExpr(input)
+				if diff := testutil.Diff(gotExpr, tt.wantExpr); diff != "" {
+					t.Error(testutil.Callers(), diff)
+				}
+			})
+		}
+	}
+}
+
 func TestNormalizeDSN(t *testing.T) {
 	type TT struct {
 		dsn               string
@@ -0,0 +1,817 @@
+package ddl
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The change types of a Change.
+const (
+	ChangeAdd   = "ADD"
+	ChangeDrop  = "DROP"
+	ChangeAlter = "ALTER"
+)
+
+// Change represents a difference between two catalogs, as reported by Diff.
+type Change struct {
+	// ChangeType is the type of change: "ADD", "DROP" or "ALTER".
+	ChangeType string
+
+	// ObjectType is the type of the object that was changed e.g. "SCHEMA",
+	// "TABLE", "COLUMN", "CONSTRAINT", "INDEX", "TRIGGER", "PARTITION",
+	// "VIEW", "ROUTINE", "ENUM", "DOMAIN", "SEQUENCE" or "EXTENSION".
+	ObjectType string
+
+	// Path is the path to the object, starting from the schema (the schema
+	// name is empty for SQLite). For example a column is identified by
+	// []string{schemaName, tableName, columnName}. Extensions are identified
+	// by []string{extensionName} and routines by their name followed by their
+	// identity arguments in brackets.
+	Path []string
+
+	// Field is the name of the field that was changed e.g. "ColumnType".
+	// Only set if the ChangeType is "ALTER".
+	Field string `json:",omitempty"`
+
+	// Before is the object in the source catalog (or the value of the field
+	// if the ChangeType is "ALTER"). It is nil if the object was added.
+	Before any `json:",omitempty"`
+
+	// After is the object in the destination catalog (or the value of the
+	// field if the ChangeType is "ALTER"). It is nil if the object was
+	// dropped.
+	After any `json:",omitempty"`
+}
+
+// String returns a one-line description of the change.
+func (change Change) String() string {
+	path := strings.Join(change.Path, ".")
+	if len(change.Path) > 0 && change.Path[0] == "" {
+		path = strings.Join(change.Path[1:], ".")
+	}
+	if change.ChangeType == ChangeAlter {
+		return fmt.Sprintf("%s %s %s %s: %s -> %s", change.ChangeType, change.ObjectType, path, change.Field, formatChangeValue(change.Before), formatChangeValue(change.After))
+	}
+	return change.ChangeType + " " + change.ObjectType + " " + path
+}
+
+// Diff returns the list of changes needed to turn the src catalog into the
+// dest catalog. Objects with Ignore set to true are treated as if they do not
+// exist. Column types and defaults are normalized before being compared, every
+// other field is compared as-is.
+//
+// Unlike the generate command, Diff does not produce any SQL and does not
+// detect renames: a renamed object is reported as one object being dropped and
+// another being added.
+func Diff(src, dest *Catalog) []Change {
+	if src == nil {
+		src = &Catalog{}
+	}
+	if dest == nil {
+		dest = &Catalog{}
+	}
+	dialect := dest.Dialect
+	if dialect == "" {
+		dialect = src.Dialect
+	}
+	d := &differ{
+		dialect:   dialect,
+		srcCache:  NewCatalogCache(src),
+		destCache: NewCatalogCache(dest),
+	}
+	// EXTENSION.
+	srcExtensions := make(map[string]bool)
+	for _, extension := range src.Extensions {
+		srcExtensions[extension] = true
+	}
+	destExtensions := make(map[string]bool)
+	for _, extension := range dest.Extensions {
+		destExtensions[extension] = true
+		if !srcExtensions[extension] {
+			d.add(ChangeAdd, "EXTENSION", []string{extension}, nil, extension)
+		}
+	}
+	for _, extension := range src.Extensions {
+		if !destExtensions[extension] {
+			d.add(ChangeDrop, "EXTENSION", []string{extension}, extension, nil)
+		}
+	}
+	// SCHEMA.
+	for i := range dest.Schemas {
+		destSchema := &dest.Schemas[i]
+		if destSchema.Ignore {
+			continue
+		}
+		srcSchema := d.srcCache.GetSchema(src, destSchema.SchemaName)
+		if srcSchema == nil {
+			// The default schema always exists, even if it has no objects.
+			if destSchema.SchemaName == "" || destSchema.SchemaName == src.CurrentSchema {
+				d.diffSchema(&Schema{SchemaName: destSchema.SchemaName}, destSchema)
+				continue
+			}
+			d.add(ChangeAdd, "SCHEMA", []string{destSchema.SchemaName}, nil, destSchema)
+			continue
+		}
+		d.diffSchema(srcSchema, destSchema)
+	}
+	for i := range src.Schemas {
+		srcSchema := &src.Schemas[i]
+		if srcSchema.Ignore {
+			continue
+		}
+		if d.destCache.GetSchema(dest, srcSchema.SchemaName) == nil {
+			if srcSchema.SchemaName == "" || srcSchema.SchemaName == dest.CurrentSchema {
+				d.diffSchema(srcSchema, &Schema{SchemaName: srcSchema.SchemaName})
+				continue
+			}
+			d.add(ChangeDrop, "SCHEMA", []string{srcSchema.SchemaName}, srcSchema, nil)
+		}
+	}
+	return d.changes
+}
+
+type differ struct {
+	dialect   string
+	srcCache  *CatalogCache
+	destCache *CatalogCache
+	changes   []Change
+}
+
+func (d *differ) add(changeType, objectType string, path []string, before, after any) {
+	d.changes = append(d.changes, Change{
+		ChangeType: changeType,
+		ObjectType: objectType,
+		Path:       path,
+		Before:     before,
+		After:      after,
+	})
+}
+
+func diffField[T comparable](d *differ, objectType string, path []string, field string, before, after T) {
+	if before == after {
+		return
+	}
+	d.changes = append(d.changes, Change{
+		ChangeType: ChangeAlter,
+		ObjectType: objectType,
+		Path:       path,
+		Field:      field,
+		Before:     before,
+		After:      after,
+	})
+}
+
+func diffSliceField[T comparable](d *differ, objectType string, path []string, field string, before, after []T) {
+	if len(before) == len(after) {
+		equal := true
+		for i := range before {
+			if before[i] != after[i] {
+				equal = false
+				break
+			}
+		}
+		if equal {
+			return
+		}
+	}
+	d.changes = append(d.changes, Change{
+		ChangeType: ChangeAlter,
+		ObjectType: objectType,
+		Path:       path,
+		Field:      field,
+		Before:     before,
+		After:      after,
+	})
+}
+
+// childPath returns a new path made up of the parent path followed by name.
+func childPath(path []string, name string) []string {
+	childPath := make([]string, len(path)+1)
+	copy(childPath, path)
+	childPath[len(path)] = name
+	return childPath
+}
+
+func (d *differ) diffSchema(srcSchema, destSchema *Schema) {
+	path := []string{destSchema.SchemaName}
+	diffField(d, "SCHEMA", path, "Comment", srcSchema.Comment, destSchema.Comment)
+	// ENUM.
+	for i := range destSchema.Enums {
+		destEnum := &destSchema.Enums[i]
+		if destEnum.Ignore {
+			continue
+		}
+		enumPath := childPath(path, destEnum.EnumName)
+		srcEnum := d.srcCache.GetEnum(srcSchema, destEnum.EnumName)
+		if srcEnum == nil {
+			d.add(ChangeAdd, "ENUM", enumPath, nil, destEnum)
+			continue
+		}
+		diffSliceField(d, "ENUM", enumPath, "EnumLabels", srcEnum.EnumLabels, destEnum.EnumLabels)
+		diffField(d, "ENUM", enumPath, "Comment", srcEnum.Comment, destEnum.Comment)
+	}
+	for i := range srcSchema.Enums {
+		srcEnum := &srcSchema.Enums[i]
+		if !srcEnum.Ignore && d.destCache.GetEnum(destSchema, srcEnum.EnumName) == nil {
+			d.add(ChangeDrop, "ENUM", childPath(path, srcEnum.EnumName), srcEnum, nil)
+		}
+	}
+	// DOMAIN.
+	for i := range destSchema.Domains {
+		destDomain := &destSchema.Domains[i]
+		if destDomain.Ignore {
+			continue
+		}
+		domainPath := childPath(path, destDomain.DomainName)
+		srcDomain := d.srcCache.GetDomain(srcSchema, destDomain.DomainName)
+		if srcDomain == nil {
+			d.add(ChangeAdd, "DOMAIN", domainPath, nil, destDomain)
+			continue
+		}
+		diffField(d, "DOMAIN", domainPath, "UnderlyingType", srcDomain.UnderlyingType, destDomain.UnderlyingType)
+		diffField(d, "DOMAIN", domainPath, "CollationName", srcDomain.CollationName, destDomain.CollationName)
+		diffField(d, "DOMAIN", domainPath, "IsNotNull", srcDomain.IsNotNull, destDomain.IsNotNull)
+		diffField(d, "DOMAIN", domainPath, "ColumnDefault", srcDomain.ColumnDefault, destDomain.ColumnDefault)
+		diffSliceField(d, "DOMAIN", domainPath, "CheckExprs", srcDomain.CheckExprs, destDomain.CheckExprs)
+		diffField(d, "DOMAIN", domainPath, "Comment", srcDomain.Comment, destDomain.Comment)
+	}
+	for i := range srcSchema.Domains {
+		srcDomain := &srcSchema.Domains[i]
+		if !srcDomain.Ignore && d.destCache.GetDomain(destSchema, srcDomain.DomainName) == nil {
+			d.add(ChangeDrop, "DOMAIN", childPath(path, srcDomain.DomainName), srcDomain, nil)
+		}
+	}
+	// SEQUENCE.
+	for i := range destSchema.Sequences {
+		destSequence := &destSchema.Sequences[i]
+		if destSequence.Ignore {
+			continue
+		}
+		sequencePath := childPath(path, destSequence.SequenceName)
+		srcSequence := d.srcCache.GetSequence(srcSchema, destSequence.SequenceName)
+		if srcSequence == nil {
+			d.add(ChangeAdd, "SEQUENCE", sequencePath, nil, destSequence)
+			continue
+		}
+		diffField(d, "SEQUENCE", sequencePath, "DataType", strings.ToUpper(srcSequence.DataType), strings.ToUpper(destSequence.DataType))
+		diffField(d, "SEQUENCE", sequencePath, "StartValue", srcSequence.StartValue, destSequence.StartValue)
+		diffField(d, "SEQUENCE", sequencePath, "IncrementBy", srcSequence.IncrementBy, destSequence.IncrementBy)
+		diffField(d, "SEQUENCE", sequencePath, "MinValue", srcSequence.MinValue, destSequence.MinValue)
+		diffField(d, "SEQUENCE", sequencePath, "MaxValue", srcSequence.MaxValue, destSequence.MaxValue)
+		diffField(d, "SEQUENCE", sequencePath, "IsCycle", srcSequence.IsCycle, destSequence.IsCycle)
+		diffField(d, "SEQUENCE", sequencePath, "OwnedByTable", srcSequence.OwnedByTable, destSequence.OwnedByTable)
+		diffField(d, "SEQUENCE", sequencePath, "OwnedByColumn", srcSequence.OwnedByColumn, destSequence.OwnedByColumn)
+		diffField(d, "SEQUENCE", sequencePath, "Comment", srcSequence.Comment, destSequence.Comment)
+	}
+	for i := range srcSchema.Sequences {
+		srcSequence := &srcSchema.Sequences[i]
+		if !srcSequence.Ignore && d.destCache.GetSequence(destSchema, srcSequence.SequenceName) == nil {
+			d.add(ChangeDrop, "SEQUENCE", childPath(path, srcSequence.SequenceName), srcSequence, nil)
+		}
+	}
+	// TABLE.
+	for i := range destSchema.Tables {
+		destTable := &destSchema.Tables[i]
+		if destTable.Ignore {
+			continue
+		}
+		srcTable := d.srcCache.GetTable(srcSchema, destTable.TableName)
+		if srcTable == nil {
+			d.add(ChangeAdd, "TABLE", childPath(path, destTable.TableName), nil, destTable)
+			continue
+		}
+		d.diffTable(childPath(path, destTable.TableName), srcTable, destTable)
+	}
+	for i := range srcSchema.Tables {
+		srcTable := &srcSchema.Tables[i]
+		if !srcTable.Ignore && d.destCache.GetTable(destSchema, srcTable.TableName) == nil {
+			d.add(ChangeDrop, "TABLE", childPath(path, srcTable.TableName), srcTable, nil)
+		}
+	}
+	// VIEW.
+	for i := range destSchema.Views {
+		destView := &destSchema.Views[i]
+		if destView.Ignore {
+			continue
+		}
+		viewPath := childPath(path, destView.ViewName)
+		srcView := d.srcCache.GetView(srcSchema, destView.ViewName)
+		if srcView == nil {
+			d.add(ChangeAdd, "VIEW", viewPath, nil, destView)
+			continue
+		}
+		diffField(d, "VIEW", viewPath, "IsMaterialized", srcView.IsMaterialized, destView.IsMaterialized)
+		diffField(d, "VIEW", viewPath, "SQL", strings.TrimSpace(srcView.SQL), strings.TrimSpace(destView.SQL))
+		diffField(d, "VIEW", viewPath, "Comment", srcView.Comment, destView.Comment)
+		for j := range destView.Indexes {
+			destIndex := &destView.Indexes[j]
+			if destIndex.Ignore {
+				continue
+			}
+			srcIndex := d.srcCache.GetViewIndex(srcView, destIndex.IndexName)
+			if srcIndex == nil {
+				d.add(ChangeAdd, "INDEX", childPath(viewPath, destIndex.IndexName), nil, destIndex)
+				continue
+			}
+			d.diffIndex(childPath(viewPath, destIndex.IndexName), srcIndex, destIndex)
+		}
+		for j := range srcView.Indexes {
+			srcIndex := &srcView.Indexes[j]
+			if !srcIndex.Ignore && d.destCache.GetViewIndex(destView, srcIndex.IndexName) == nil {
+				d.add(ChangeDrop, "INDEX", childPath(viewPath, srcIndex.IndexName), srcIndex, nil)
+			}
+		}
+		for j := range destView.Triggers {
+			destTrigger := &destView.Triggers[j]
+			if destTrigger.Ignore {
+				continue
+			}
+			srcTrigger := d.srcCache.GetViewTrigger(srcView, destTrigger.TriggerName)
+			if srcTrigger == nil {
+				d.add(ChangeAdd, "TRIGGER", childPath(viewPath, destTrigger.TriggerName), nil, destTrigger)
+				continue
+			}
+			d.diffTrigger(childPath(viewPath, destTrigger.TriggerName), srcTrigger, destTrigger)
+		}
+		for j := range srcView.Triggers {
+			srcTrigger := &srcView.Triggers[j]
+			if !srcTrigger.Ignore && d.destCache.GetViewTrigger(destView, srcTrigger.TriggerName) == nil {
+				d.add(ChangeDrop, "TRIGGER", childPath(viewPath, srcTrigger.TriggerName), srcTrigger, nil)
+			}
+		}
+	}
+	for i := range srcSchema.Views {
+		srcView := &srcSchema.Views[i]
+		if !srcView.Ignore && d.destCache.GetView(destSchema, srcView.ViewName) == nil {
+			d.add(ChangeDrop, "VIEW", childPath(path, srcView.ViewName), srcView, nil)
+		}
+	}
+	// ROUTINE.
+	for i := range destSchema.Routines {
+		destRoutine := &destSchema.Routines[i]
+		if destRoutine.Ignore {
+			continue
+		}
+		routinePath := childPath(path, destRoutine.RoutineName+"("+destRoutine.IdentityArguments+")")
+		srcRoutine := d.srcCache.GetRoutine(srcSchema, destRoutine.RoutineName, destRoutine.IdentityArguments)
+		if srcRoutine == nil {
+			d.add(ChangeAdd, "ROUTINE", routinePath, nil, destRoutine)
+			continue
+		}
+		diffField(d, "ROUTINE", routinePath, "RoutineType", srcRoutine.RoutineType, destRoutine.RoutineType)
+		diffField(d, "ROUTINE", routinePath, "SQL", strings.TrimSpace(srcRoutine.SQL), strings.TrimSpace(destRoutine.SQL))
+		diffField(d, "ROUTINE", routinePath, "Comment", srcRoutine.Comment, destRoutine.Comment)
+	}
+	for i := range srcSchema.Routines {
+		srcRoutine := &srcSchema.Routines[i]
+		if !srcRoutine.Ignore && d.destCache.GetRoutine(destSchema, srcRoutine.RoutineName, srcRoutine.IdentityArguments) == nil {
+			d.add(ChangeDrop, "ROUTINE", childPath(path, srcRoutine.RoutineName+"("+srcRoutine.IdentityArguments+")"), srcRoutine, nil)
+		}
+	}
+}
+
+func (d *differ) diffTable(path []string, srcTable, destTable *Table) {
+	diffField(d, "TABLE", path, "IsVirtual", srcTable.IsVirtual, destTable.IsVirtual)
+	diffField(d, "TABLE", path, "PartitionStrategy", strings.ToUpper(srcTable.PartitionStrategy), strings.ToUpper(destTable.PartitionStrategy))
+	diffField(d, "TABLE", path, "PartitionKey", srcTable.PartitionKey, destTable.PartitionKey)
+	diffField(d, "TABLE", path, "Engine", srcTable.Engine, destTable.Engine)
+	diffField(d, "TABLE", path, "Charset", srcTable.Charset, destTable.Charset)
+	diffSliceField(d, "TABLE", path, "StorageParams", srcTable.StorageParams, destTable.StorageParams)
+	diffField(d, "TABLE", path, "IsUnlogged", srcTable.IsUnlogged, destTable.IsUnlogged)
+	diffField(d, "TABLE", path, "IsStrict", srcTable.IsStrict, destTable.IsStrict)
+	diffField(d, "TABLE", path, "IsWithoutRowid", srcTable.IsWithoutRowid, destTable.IsWithoutRowid)
+	diffField(d, "TABLE", path, "Comment", srcTable.Comment, destTable.Comment)
+	// COLUMN.
+	for i := range destTable.Columns {
+		destColumn := &destTable.Columns[i]
+		if destColumn.Ignore {
+			continue
+		}
+		columnPath := childPath(path, destColumn.ColumnName)
+		srcColumn := d.srcCache.GetColumn(srcTable, destColumn.ColumnName)
+		if srcColumn == nil {
+			d.add(ChangeAdd, "COLUMN", columnPath, nil, destColumn)
+			continue
+		}
+		srcType, srcArg1, srcArg2 := normalizeColumnType(d.dialect, srcColumn.ColumnType)
+		destType, destArg1, destArg2 := normalizeColumnType(d.dialect, destColumn.ColumnType)
+		if [3]string{srcType, srcArg1, srcArg2} != [3]string{destType, destArg1, destArg2} {
+			diffField(d, "COLUMN", columnPath, "ColumnType", srcColumn.ColumnType, destColumn.ColumnType)
+		}
+		if normalizeColumnDefault(d.dialect, srcColumn.ColumnDefault) != normalizeColumnDefault(d.dialect, destColumn.ColumnDefault) {
+			diffField(d, "COLUMN", columnPath, "ColumnDefault", srcColumn.ColumnDefault, destColumn.ColumnDefault)
+		}
+		diffField(d, "COLUMN", columnPath, "IsNotNull", srcColumn.IsNotNull, destColumn.IsNotNull)
+		diffField(d, "COLUMN", columnPath, "IsAutoincrement", srcColumn.IsAutoincrement, destColumn.IsAutoincrement)
+		diffField(d, "COLUMN", columnPath, "ColumnIdentity", srcColumn.ColumnIdentity, destColumn.ColumnIdentity)
+		diffField(d, "COLUMN", columnPath, "OnUpdateCurrentTimestamp", srcColumn.OnUpdateCurrentTimestamp, destColumn.OnUpdateCurrentTimestamp)
+		diffField(d, "COLUMN", columnPath, "GeneratedExpr", srcColumn.GeneratedExpr, destColumn.GeneratedExpr)
+		diffField(d, "COLUMN", columnPath, "GeneratedExprStored", srcColumn.GeneratedExprStored, destColumn.GeneratedExprStored)
+		diffField(d, "COLUMN", columnPath, "CollationName", srcColumn.CollationName, destColumn.CollationName)
+		diffField(d, "COLUMN", columnPath, "Comment", srcColumn.Comment, destColumn.Comment)
+	}
+	for i := range srcTable.Columns {
+		srcColumn := &srcTable.Columns[i]
+		if !srcColumn.Ignore && d.destCache.GetColumn(destTable, srcColumn.ColumnName) == nil {
+			d.add(ChangeDrop, "COLUMN", childPath(path, srcColumn.ColumnName), srcColumn, nil)
+		}
+	}
+	// CONSTRAINT.
+	for i := range destTable.Constraints {
+		destConstraint := &destTable.Constraints[i]
+		if destConstraint.Ignore {
+			continue
+		}
+		constraintPath := childPath(path, constraintLabel(destConstraint))
+		srcConstraint := getConstraint(d.srcCache, srcTable, destConstraint)
+		if srcConstraint == nil {
+			d.add(ChangeAdd, "CONSTRAINT", constraintPath, nil, destConstraint)
+			continue
+		}
+		diffField(d, "CONSTRAINT", constraintPath, "ConstraintType", srcConstraint.ConstraintType, destConstraint.ConstraintType)
+		diffSliceField(d, "CONSTRAINT", constraintPath, "Columns", srcConstraint.Columns, destConstraint.Columns)
+		diffField(d, "CONSTRAINT", constraintPath, "ReferencesSchema", srcConstraint.ReferencesSchema, destConstraint.ReferencesSchema)
+		diffField(d, "CONSTRAINT", constraintPath, "ReferencesTable", srcConstraint.ReferencesTable, destConstraint.ReferencesTable)
+		diffSliceField(d, "CONSTRAINT", constraintPath, "ReferencesColumns", srcConstraint.ReferencesColumns, destConstraint.ReferencesColumns)
+		diffField(d, "CONSTRAINT", constraintPath, "UpdateRule", srcConstraint.UpdateRule, destConstraint.UpdateRule)
+		diffField(d, "CONSTRAINT", constraintPath, "DeleteRule", srcConstraint.DeleteRule, destConstraint.DeleteRule)
+		diffField(d, "CONSTRAINT", constraintPath, "CheckExpr", srcConstraint.CheckExpr, destConstraint.CheckExpr)
+		diffSliceField(d, "CONSTRAINT", constraintPath, "ExclusionOperators", srcConstraint.ExclusionOperators, destConstraint.ExclusionOperators)
+		diffField(d, "CONSTRAINT", constraintPath, "ExclusionIndexType", srcConstraint.ExclusionIndexType, destConstraint.ExclusionIndexType)
+		diffField(d, "CONSTRAINT", constraintPath, "ExclusionPredicate", srcConstraint.ExclusionPredicate, destConstraint.ExclusionPredicate)
+		diffField(d, "CONSTRAINT", constraintPath, "IsDeferrable", srcConstraint.IsDeferrable, destConstraint.IsDeferrable)
+		diffField(d, "CONSTRAINT", constraintPath, "IsInitiallyDeferred", srcConstraint.IsInitiallyDeferred, destConstraint.IsInitiallyDeferred)
+		diffField(d, "CONSTRAINT", constraintPath, "Comment", srcConstraint.Comment, destConstraint.Comment)
+	}
+	for i := range srcTable.Constraints {
+		srcConstraint := &srcTable.Constraints[i]
+		if !srcConstraint.Ignore && getConstraint(d.destCache, destTable, srcConstraint) == nil {
+			d.add(ChangeDrop, "CONSTRAINT", childPath(path, constraintLabel(srcConstraint)), srcConstraint, nil)
+		}
+	}
+	// INDEX.
+	for i := range destTable.Indexes {
+		destIndex := &destTable.Indexes[i]
+		if destIndex.Ignore {
+			continue
+		}
+		srcIndex := d.srcCache.GetIndex(srcTable, destIndex.IndexName)
+		if srcIndex == nil {
+			d.add(ChangeAdd, "INDEX", childPath(path, destIndex.IndexName), nil, destIndex)
+			continue
+		}
+		d.diffIndex(childPath(path, destIndex.IndexName), srcIndex, destIndex)
+	}
+	for i := range srcTable.Indexes {
+		srcIndex := &srcTable.Indexes[i]
+		if !srcIndex.Ignore && d.destCache.GetIndex(destTable, srcIndex.IndexName) == nil {
+			d.add(ChangeDrop, "INDEX", childPath(path, srcIndex.IndexName), srcIndex, nil)
+		}
+	}
+	// TRIGGER.
+	for i := range destTable.Triggers {
+		destTrigger := &destTable.Triggers[i]
+		if destTrigger.Ignore {
+			continue
+		}
+		srcTrigger := d.srcCache.GetTrigger(srcTable, destTrigger.TriggerName)
+		if srcTrigger == nil {
+			d.add(ChangeAdd, "TRIGGER", childPath(path, destTrigger.TriggerName), nil, destTrigger)
+			continue
+		}
+		d.diffTrigger(childPath(path, destTrigger.TriggerName), srcTrigger, destTrigger)
+	}
+	for i := range srcTable.Triggers {
+		srcTrigger := &srcTable.Triggers[i]
+		if !srcTrigger.Ignore && d.destCache.GetTrigger(destTable, srcTrigger.TriggerName) == nil {
+			d.add(ChangeDrop, "TRIGGER", childPath(path, srcTrigger.TriggerName), srcTrigger, nil)
+		}
+	}
+	// PARTITION.
+	srcPartitions := make(map[string]*Partition)
+	for i := range srcTable.Partitions {
+		if !srcTable.Partitions[i].Ignore {
+			srcPartitions[srcTable.Partitions[i].PartitionName] = &srcTable.Partitions[i]
+		}
+	}
+	destPartitions := make(map[string]*Partition)
+	for i := range destTable.Partitions {
+		destPartition := &destTable.Partitions[i]
+		if destPartition.Ignore {
+			continue
+		}
+		destPartitions[destPartition.PartitionName] = destPartition
+		partitionPath := childPath(path, destPartition.PartitionName)
+		srcPartition := srcPartitions[destPartition.PartitionName]
+		if srcPartition == nil {
+			d.add(ChangeAdd, "PARTITION", partitionPath, nil, destPartition)
+			continue
+		}
+		diffField(d, "PARTITION", partitionPath, "PartitionBound", srcPartition.PartitionBound, destPartition.PartitionBound)
+	}
+	for i := range srcTable.Partitions {
+		srcPartition := &srcTable.Partitions[i]
+		if !srcPartition.Ignore && destPartitions[srcPartition.PartitionName] == nil {
+			d.add(ChangeDrop, "PARTITION", childPath(path, srcPartition.PartitionName), srcPartition, nil)
+		}
+	}
+}
+
+// getConstraint gets the Constraint in the table that matches the given
+// constraint. Unnamed constraints (SQLite) are matched by their type, columns
+// and referenced columns.
+func getConstraint(cache *CatalogCache, table *Table, constraint *Constraint) *Constraint {
+	if constraint.ConstraintName != "" {
+		return cache.GetConstraint(table, constraint.ConstraintName)
+	}
+	for i := range table.Constraints {
+		c := &table.Constraints[i]
+		if c.Ignore || c.ConstraintName != "" || c.ConstraintType != constraint.ConstraintType {
+			continue
+		}
+		if strings.Join(c.Columns, ",") != strings.Join(constraint.Columns, ",") {
+			continue
+		}
+		if c.ReferencesTable != constraint.ReferencesTable || strings.Join(c.ReferencesColumns, ",") != strings.Join(constraint.ReferencesColumns, ",") {
+			continue
+		}
+		if c.ConstraintType == CHECK && c.CheckExpr != constraint.CheckExpr {
+			continue
+		}
+		return c
+	}
+	return nil
+}
+
+// constraintLabel returns the name of the constraint, or a description of the
+// constraint if it is unnamed (SQLite) e.g. "PRIMARY KEY (actor_id)".
+func constraintLabel(constraint *Constraint) string {
+	if constraint.ConstraintName != "" {
+		return constraint.ConstraintName
+	}
+	if constraint.ConstraintType == CHECK {
+		return "CHECK (" + constraint.CheckExpr + ")"
+	}
+	return constraint.ConstraintType + " (" + strings.Join(constraint.Columns, ", ") + ")"
+}
+
+func (d *differ) diffIndex(path []string, srcIndex, destIndex *Index) {
+	diffField(d, "INDEX", path, "IndexType", strings.ToUpper(srcIndex.IndexType), strings.ToUpper(destIndex.IndexType))
+	diffField(d, "INDEX", path, "IsUnique", srcIndex.IsUnique, destIndex.IsUnique)
+	diffSliceField(d, "INDEX", path, "Columns", srcIndex.Columns, destIndex.Columns)
+	diffSliceField(d, "INDEX", path, "IncludeColumns", srcIndex.IncludeColumns, destIndex.IncludeColumns)
+	diffSliceField(d, "INDEX", path, "Descending", srcIndex.Descending, destIndex.Descending)
+	diffSliceField(d, "INDEX", path, "Opclasses", srcIndex.Opclasses, destIndex.Opclasses)
+	diffField(d, "INDEX", path, "Predicate", srcIndex.Predicate, destIndex.Predicate)
+	diffField(d, "INDEX", path, "Comment", srcIndex.Comment, destIndex.Comment)
+}
+
+func (d *differ) diffTrigger(path []string, srcTrigger, destTrigger *Trigger) {
+	diffField(d, "TRIGGER", path, "SQL", strings.TrimSpace(srcTrigger.SQL), strings.TrimSpace(destTrigger.SQL))
+	diffField(d, "TRIGGER", path, "Comment", srcTrigger.Comment, destTrigger.Comment)
+}
+
+// formatChangeValue formats the Before or After value of an ALTER change for
+// display.
+func formatChangeValue(value any) string {
+	switch value := value.(type) {
+	case string:
+		if strings.ContainsAny(value, "\n") {
+			return fmt.Sprintf("%q", value)
+		}
+		return "'" + value + "'"
+	case []string:
+		return "[" + strings.Join(value, ", ") + "]"
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// unifiedDiff returns the unified diff (with 3 lines of context) that turns
+// the text a into the text b. It returns an empty string if a and b are the
+// same.
+func unifiedDiff(aName, bName string, a, b string) string {
+	if a == b {
+		return ""
+	}
+	const context = 3
+	ops := diffLines(strings.SplitAfter(a, "\n"), strings.SplitAfter(b, "\n"))
+	buf := &bytes.Buffer{}
+	buf.WriteString("--- " + aName + "\n")
+	buf.WriteString("+++ " + bName + "\n")
+	// Line numbers (0-based) of ops[i] in a and b.
+	aLines := make([]int, len(ops)+1)
+	bLines := make([]int, len(ops)+1)
+	for i, op := range ops {
+		aLines[i+1], bLines[i+1] = aLines[i], bLines[i]
+		if op.kind != '+' {
+			aLines[i+1]++
+		}
+		if op.kind != '-' {
+			bLines[i+1]++
+		}
+	}
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		// Extend the hunk until there are more than 2*context unchanged lines
+		// in a row.
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+				continue
+			}
+			if j-end >= 2*context {
+				break
+			}
+		}
+		stop := end + context
+		if stop > len(ops) {
+			stop = len(ops)
+		}
+		fmt.Fprintf(buf, "@@ -%s +%s @@\n", hunkRange(aLines[start], aLines[stop]-aLines[start]), hunkRange(bLines[start], bLines[stop]-bLines[start]))
+		for _, op := range ops[start:stop] {
+			buf.WriteByte(op.kind)
+			buf.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				buf.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = stop
+	}
+	return buf.String()
+}
+
+// hunkRange formats the range of a unified diff hunk.
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+type diffOp struct {
+	kind byte // ' ' (unchanged), '-' (deleted from a) or '+' (inserted from b).
+	line string
+}
+
+// diffLines returns the shortest edit script that turns the lines a into the
+// lines b, using the linear space variant of Myers' diff algorithm (so that
+// diffing large files does not need memory proportional to the square of
+// their length).
+func diffLines(a, b []string) []diffOp {
+	if len(a) > 0 && a[len(a)-1] == "" {
+		a = a[:len(a)-1]
+	}
+	if len(b) > 0 && b[len(b)-1] == "" {
+		b = b[:len(b)-1]
+	}
+	ops := appendDiffOps(make([]diffOp, 0, len(a)+len(b)), a, b)
+	// Within each run of changed lines, list the deleted lines before the
+	// inserted lines.
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		j := i
+		for j < len(ops) && ops[j].kind != ' ' {
+			j++
+		}
+		sort.SliceStable(ops[i:j], func(x, y int) bool {
+			return ops[i+x].kind == '-' && ops[i+y].kind == '+'
+		})
+		i = j
+	}
+	return ops
+}
+
+// appendDiffOps appends the edit script that turns the lines a into the lines
+// b to ops. The common prefix and suffix (usually the bulk of the lines) are
+// trimmed, then the rest is split in two at the middle of its edit script and
+// each half is diffed recursively.
+func appendDiffOps(ops []diffOp, a, b []string) []diffOp {
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		ops = append(ops, diffOp{kind: ' ', line: a[0]})
+		a, b = a[1:], b[1:]
+	}
+	suffixLen := 0
+	for suffixLen < len(a) && suffixLen < len(b) && a[len(a)-1-suffixLen] == b[len(b)-1-suffixLen] {
+		suffixLen++
+	}
+	suffix := a[len(a)-suffixLen:]
+	a, b = a[:len(a)-suffixLen], b[:len(b)-suffixLen]
+	switch {
+	case len(a) == 0:
+		for _, line := range b {
+			ops = append(ops, diffOp{kind: '+', line: line})
+		}
+	case len(b) == 0:
+		for _, line := range a {
+			ops = append(ops, diffOp{kind: '-', line: line})
+		}
+	default:
+		x, y := diffSplit(a, b)
+		if (x == 0 && y == 0) || (x == len(a) && y == len(b)) {
+			// Nothing in common.
+			for _, line := range a {
+				ops = append(ops, diffOp{kind: '-', line: line})
+			}
+			for _, line := range b {
+				ops = append(ops, diffOp{kind: '+', line: line})
+			}
+			break
+		}
+		ops = appendDiffOps(ops, a[:x], b[:y])
+		ops = appendDiffOps(ops, a[x:], b[y:])
+	}
+	for _, line := range suffix {
+		ops = append(ops, diffOp{kind: ' ', line: line})
+	}
+	return ops
+}
+
+// diffSplit returns the point (x, y) where the shortest edit script that
+// turns the lines a into the lines b crosses its middle, by searching forward
+// from the start and backward from the end at the same time until the two
+// searches overlap. Only the furthest reaching paths of the current depth are
+// kept, so the memory used is linear in len(a)+len(b).
+func diffSplit(a, b []string) (x, y int) {
+	n, m := len(a), len(b)
+	maxDepth := (n + m + 1) / 2
+	offset := maxDepth + 1
+	forward := make([]int, 2*maxDepth+3)
+	backward := make([]int, 2*maxDepth+3)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+	delta := n - m
+	// If delta is odd the paths overlap during the forward search, otherwise
+	// during the backward search.
+	odd := delta%2 != 0
+	// The diagonals that run off the edges of a and b are skipped.
+	forwardStart, forwardEnd, backwardStart, backwardEnd := 0, 0, 0, 0
+	for depth := 0; depth <= maxDepth; depth++ {
+		for k := -depth + forwardStart; k <= depth-forwardEnd; k += 2 {
+			var x int
+			if k == -depth || (k != depth && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			forward[offset+k] = x
+			if x > n {
+				forwardEnd += 2
+			} else if y > m {
+				forwardStart += 2
+			} else if odd {
+				i := offset + delta - k
+				if i >= 0 && i < len(backward) && backward[i] != -1 && x >= n-backward[i] {
+					return x, y
+				}
+			}
+		}
+		for k := -depth + backwardStart; k <= depth-backwardEnd; k += 2 {
+			var x int
+			if k == -depth || (k != depth && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x, y = x+1, y+1
+			}
+			backward[offset+k] = x
+			if x > n {
+				backwardEnd += 2
+			} else if y > m {
+				backwardStart += 2
+			} else if !odd {
+				i := offset + delta - k
+				if i >= 0 && i < len(forward) && forward[i] != -1 && forward[i] >= n-x {
+					forwardX := forward[i]
+					return forwardX, forwardX - (i - offset)
+				}
+			}
+		}
+	}
+	return 0, 0
+}
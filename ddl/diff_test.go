@@ -0,0 +1,335 @@
+package ddl
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bokwoon95/sqddl/internal/testutil"
+)
+
+func diffTestCatalogs() (src, dest *Catalog) {
+	src = &Catalog{
+		Dialect:       DialectPostgres,
+		CurrentSchema: "public",
+		Extensions:    []string{"btree_gist"},
+		Schemas: []Schema{{
+			SchemaName: "public",
+			Enums: []Enum{{
+				EnumSchema: "public",
+				EnumName:   "mpaa_rating",
+				EnumLabels: []string{"G", "PG"},
+			}},
+			Tables: []Table{{
+				TableSchema: "public",
+				TableName:   "actor",
+				Columns: []Column{{
+					TableSchema: "public",
+					TableName:   "actor",
+					ColumnName:  "actor_id",
+					ColumnType:  "INT",
+					IsNotNull:   true,
+				}, {
+					TableSchema: "public",
+					TableName:   "actor",
+					ColumnName:  "first_name",
+					ColumnType:  "TEXT",
+				}, {
+					TableSchema: "public",
+					TableName:   "actor",
+					ColumnName:  "nickname",
+					ColumnType:  "TEXT",
+				}},
+				Indexes: []Index{{
+					TableSchema: "public",
+					TableName:   "actor",
+					IndexName:   "actor_first_name_idx",
+					IndexType:   "BTREE",
+					Columns:     []string{"first_name"},
+				}},
+			}, {
+				TableSchema: "public",
+				TableName:   "staff",
+				Columns: []Column{{
+					TableSchema: "public",
+					TableName:   "staff",
+					ColumnName:  "staff_id",
+					ColumnType:  "INT",
+				}},
+			}},
+		}},
+	}
+	dest = &Catalog{
+		Dialect:       DialectPostgres,
+		CurrentSchema: "public",
+		Extensions:    []string{"btree_gist"},
+		Schemas: []Schema{{
+			SchemaName: "public",
+			Enums: []Enum{{
+				EnumSchema: "public",
+				EnumName:   "mpaa_rating",
+				EnumLabels: []string{"G", "PG", "R"},
+			}},
+			Tables: []Table{{
+				TableSchema: "public",
+				TableName:   "actor",
+				Comment:     "actors",
+				Columns: []Column{{
+					TableSchema: "public",
+					TableName:   "actor",
+					ColumnName:  "actor_id",
+					ColumnType:  "INTEGER",
+					IsNotNull:   true,
+				}, {
+					TableSchema: "public",
+					TableName:   "actor",
+					ColumnName:  "first_name",
+					ColumnType:  "VARCHAR(45)",
+				}, {
+					TableSchema: "public",
+					TableName:   "actor",
+					ColumnName:  "last_name",
+					ColumnType:  "VARCHAR(45)",
+				}},
+				Indexes: []Index{{
+					TableSchema: "public",
+					TableName:   "actor",
+					IndexName:   "actor_first_name_idx",
+					IndexType:   "btree",
+					Columns:     []string{"first_name"},
+				}},
+			}, {
+				TableSchema: "public",
+				TableName:   "film",
+				Columns: []Column{{
+					TableSchema: "public",
+					TableName:   "film",
+					ColumnName:  "film_id",
+					ColumnType:  "INT",
+				}},
+			}},
+		}},
+	}
+	return src, dest
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	t.Run("no differences", func(t *testing.T) {
+		t.Parallel()
+		src, _ := diffTestCatalogs()
+		_, dest := diffTestCatalogs()
+		dest.Schemas[0].Tables[0].Columns[0].ColumnType = "INT4"
+		dest.Schemas[0].Tables[1].Ignore = true
+		src.Schemas[0].Tables[0].Columns[1].ColumnType = "VARCHAR(45)"
+		src.Schemas[0].Tables[0].Columns[2].ColumnName = "last_name"
+		src.Schemas[0].Tables[0].Columns[2].ColumnType = "VARCHAR(45)"
+		src.Schemas[0].Tables[0].Comment = "actors"
+		src.Schemas[0].Tables[1].Ignore = true
+		src.Schemas[0].Enums[0].EnumLabels = append(src.Schemas[0].Enums[0].EnumLabels, "R")
+		changes := Diff(src, dest)
+		if len(changes) != 0 {
+			t.Errorf(testutil.Callers()+" expected no changes, got %v", changes)
+		}
+	})
+	t.Run("default schema", func(t *testing.T) {
+		t.Parallel()
+		_, dest := diffTestCatalogs()
+		src := &Catalog{Dialect: DialectPostgres, CurrentSchema: "public", Extensions: []string{"btree_gist"}}
+		dest.Schemas[0].Tables = dest.Schemas[0].Tables[1:]
+		gotChanges := Diff(src, dest)
+		wantChanges := []Change{{
+			ChangeType: ChangeAdd,
+			ObjectType: "ENUM",
+			Path:       []string{"public", "mpaa_rating"},
+			After:      &dest.Schemas[0].Enums[0],
+		}, {
+			ChangeType: ChangeAdd,
+			ObjectType: "TABLE",
+			Path:       []string{"public", "film"},
+			After:      &dest.Schemas[0].Tables[0],
+		}}
+		if diff := testutil.Diff(gotChanges, wantChanges); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+	})
+	t.Run("changes", func(t *testing.T) {
+		t.Parallel()
+		src, dest := diffTestCatalogs()
+		gotChanges := Diff(src, dest)
+		wantChanges := []Change{{
+			ChangeType: ChangeAlter,
+			ObjectType: "ENUM",
+			Path:       []string{"public", "mpaa_rating"},
+			Field:      "EnumLabels",
+			Before:     []string{"G", "PG"},
+			After:      []string{"G", "PG", "R"},
+		}, {
+			ChangeType: ChangeAlter,
+			ObjectType: "TABLE",
+			Path:       []string{"public", "actor"},
+			Field:      "Comment",
+			Before:     "",
+			After:      "actors",
+		}, {
+			ChangeType: ChangeAlter,
+			ObjectType: "COLUMN",
+			Path:       []string{"public", "actor", "first_name"},
+			Field:      "ColumnType",
+			Before:     "TEXT",
+			After:      "VARCHAR(45)",
+		}, {
+			ChangeType: ChangeAdd,
+			ObjectType: "COLUMN",
+			Path:       []string{"public", "actor", "last_name"},
+			After:      &dest.Schemas[0].Tables[0].Columns[2],
+		}, {
+			ChangeType: ChangeDrop,
+			ObjectType: "COLUMN",
+			Path:       []string{"public", "actor", "nickname"},
+			Before:     &src.Schemas[0].Tables[0].Columns[2],
+		}, {
+			ChangeType: ChangeAdd,
+			ObjectType: "TABLE",
+			Path:       []string{"public", "film"},
+			After:      &dest.Schemas[0].Tables[1],
+		}, {
+			ChangeType: ChangeDrop,
+			ObjectType: "TABLE",
+			Path:       []string{"public", "staff"},
+			Before:     &src.Schemas[0].Tables[1],
+		}}
+		if diff := testutil.Diff(gotChanges, wantChanges); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+	})
+}
+
+func TestDiffCmd(t *testing.T) {
+	t.Parallel()
+	t.Run("tree", func(t *testing.T) {
+		t.Parallel()
+		src, dest := diffTestCatalogs()
+		buf := &bytes.Buffer{}
+		cmd := &DiffCmd{SrcCatalog: src, DestCatalog: dest, Stdout: buf}
+		err := cmd.Run()
+		if !errors.Is(err, ErrDiffFound) {
+			t.Fatalf(testutil.Callers()+" expected ErrDiffFound, got %v", err)
+		}
+		wantOutput := "schema public\n" +
+			"  ~ enum mpaa_rating: EnumLabels [G, PG] -> [G, PG, R]\n" +
+			"  table actor\n" +
+			"    ~ Comment: '' -> 'actors'\n" +
+			"    ~ column first_name: ColumnType 'TEXT' -> 'VARCHAR(45)'\n" +
+			"    + column last_name\n" +
+			"    - column nickname\n" +
+			"  + table film\n" +
+			"  - table staff\n"
+		if diff := testutil.Diff(buf.String(), wantOutput); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+	})
+	t.Run("no differences", func(t *testing.T) {
+		t.Parallel()
+		src, _ := diffTestCatalogs()
+		dest, _ := diffTestCatalogs()
+		buf := &bytes.Buffer{}
+		cmd := &DiffCmd{SrcCatalog: src, DestCatalog: dest, Format: "unified", Stdout: buf}
+		err := cmd.Run()
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf(testutil.Callers()+" expected no output, got %q", buf.String())
+		}
+	})
+}
+
+func Test_unifiedDiff(t *testing.T) {
+	a := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	b := "a\nb\nc\nd\nE\nf\ng\nh\ni\nj\nk\n"
+	wantDiff := "--- src\n" +
+		"+++ dest\n" +
+		"@@ -2,9 +2,10 @@\n" +
+		" b\n" +
+		" c\n" +
+		" d\n" +
+		"-e\n" +
+		"+E\n" +
+		" f\n" +
+		" g\n" +
+		" h\n" +
+		" i\n" +
+		" j\n" +
+		"+k\n"
+	if diff := testutil.Diff(unifiedDiff("src", "dest", a, b), wantDiff); diff != "" {
+		t.Error(testutil.Callers(), diff)
+	}
+	if gotDiff := unifiedDiff("src", "dest", a, a); gotDiff != "" {
+		t.Errorf(testutil.Callers()+" expected empty diff, got %q", gotDiff)
+	}
+}
+
+func Test_diffLines(t *testing.T) {
+	type TT struct {
+		description string
+		a, b        []string
+		wantOps     string
+	}
+	tests := []TT{{
+		description: "interleaved",
+		a:           []string{"a", "b", "c", "a", "b", "b", "a"},
+		b:           []string{"c", "b", "a", "b", "a", "c"},
+		wantOps:     "-a +c  b -c  a  b -b  a +c",
+	}, {
+		description: "nothing in common",
+		a:           []string{"a", "b"},
+		b:           []string{"c"},
+		wantOps:     "-a -b +c",
+	}, {
+		description: "insert only",
+		a:           nil,
+		b:           []string{"a", "b"},
+		wantOps:     "+a +b",
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			var ops []string
+			for _, op := range diffLines(tt.a, tt.b) {
+				ops = append(ops, string(op.kind)+op.line)
+			}
+			if diff := testutil.Diff(strings.Join(ops, " "), tt.wantOps); diff != "" {
+				t.Error(testutil.Callers(), diff)
+			}
+		})
+	}
+
+	// Diffing a large file must not use memory proportional to the square
+	// of its length.
+	t.Run("large", func(t *testing.T) {
+		a := make([]string, 5000)
+		b := make([]string, 5000)
+		for i := range a {
+			a[i] = strconv.Itoa(i)
+			b[i] = strconv.Itoa(i)
+			if i%2 == 0 {
+				b[i] += "'"
+			}
+		}
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		ops := diffLines(a, b)
+		runtime.ReadMemStats(&after)
+		if len(ops) != 7500 {
+			t.Errorf(testutil.Callers()+" expected 7500 ops, got %d", len(ops))
+		}
+		if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 64<<20 {
+			t.Errorf(testutil.Callers()+" expected less than 64MB to be allocated, got %dMB", allocated>>20)
+		}
+	})
+}
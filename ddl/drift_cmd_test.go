@@ -0,0 +1,112 @@
+package ddl
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bokwoon95/sqddl/internal/testutil"
+)
+
+func TestDriftCmd(t *testing.T) {
+	t.Parallel()
+	dirFS := fstest.MapFS{
+		"01_actor.sql": &fstest.MapFile{
+			Data: []byte("CREATE TABLE actor (actor_id INT PRIMARY KEY, first_name TEXT, last_name TEXT);"),
+		},
+		"02_film.sql": &fstest.MapFile{
+			Data: []byte("CREATE TABLE film (film_id INT PRIMARY KEY, title TEXT);\nCREATE INDEX film_title_idx ON film (title);"),
+		},
+	}
+	newDB := func(t *testing.T, statements ...string) *sql.DB {
+		db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=true")
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		db.SetMaxOpenConns(1)
+		t.Cleanup(func() { db.Close() })
+		for _, statement := range statements {
+			_, err = db.Exec(statement)
+			if err != nil {
+				t.Fatal(testutil.Callers(), err)
+			}
+		}
+		return db
+	}
+
+	t.Run("no drift", func(t *testing.T) {
+		t.Parallel()
+		db := newDB(t,
+			"CREATE TABLE actor (actor_id INT PRIMARY KEY, first_name TEXT, last_name TEXT)",
+			"CREATE TABLE film (film_id INT PRIMARY KEY, title TEXT)",
+			"CREATE INDEX film_title_idx ON film (title)",
+		)
+		buf := &bytes.Buffer{}
+		driftCmd := &DriftCmd{DB: db, Dialect: DialectSQLite, DirFS: dirFS, Stdout: buf}
+		err := driftCmd.Run()
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf(testutil.Callers()+" expected no output, got %q", buf.String())
+		}
+	})
+
+	t.Run("drift", func(t *testing.T) {
+		t.Parallel()
+		db := newDB(t,
+			"CREATE TABLE actor (actor_id INT PRIMARY KEY, first_name TEXT, last_name TEXT, nickname TEXT)",
+			"CREATE TABLE film (film_id INT PRIMARY KEY, title VARCHAR(255))",
+			"CREATE INDEX actor_last_name_idx ON actor (last_name)",
+		)
+		buf := &bytes.Buffer{}
+		driftCmd := &DriftCmd{DB: db, Dialect: DialectSQLite, DirFS: dirFS, Stdout: buf}
+		err := driftCmd.Run()
+		if !errors.Is(err, ErrDiffFound) {
+			t.Fatalf(testutil.Callers()+" expected ErrDiffFound, got %v", err)
+		}
+		wantOutput := "table actor\n" +
+			"  + column nickname\n" +
+			"  + index actor_last_name_idx\n" +
+			"table film\n" +
+			"  ~ column title: ColumnType 'TEXT' -> 'VARCHAR(255)'\n" +
+			"  - index film_title_idx\n"
+		if diff := testutil.Diff(buf.String(), wantOutput); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+	})
+
+	t.Run("scratch database reused", func(t *testing.T) {
+		t.Parallel()
+		db := newDB(t,
+			"CREATE TABLE actor (actor_id INT PRIMARY KEY, first_name TEXT, last_name TEXT)",
+			"CREATE TABLE film (film_id INT PRIMARY KEY, title TEXT)",
+			"CREATE INDEX film_title_idx ON film (title)",
+		)
+		scratchDB := newDB(t)
+		for i := 0; i < 2; i++ {
+			buf := &bytes.Buffer{}
+			driftCmd := &DriftCmd{DB: db, Dialect: DialectSQLite, DirFS: dirFS, ScratchDB: scratchDB, Stdout: buf}
+			err := driftCmd.Run()
+			if err != nil {
+				t.Fatal(testutil.Callers(), err)
+			}
+			if buf.Len() != 0 {
+				t.Errorf(testutil.Callers()+" expected no output, got %q", buf.String())
+			}
+		}
+	})
+
+	t.Run("scratch database not empty", func(t *testing.T) {
+		t.Parallel()
+		db := newDB(t)
+		scratchDB := newDB(t, "CREATE TABLE actor (actor_id INT)")
+		driftCmd := &DriftCmd{DB: db, Dialect: DialectSQLite, DirFS: dirFS, ScratchDB: scratchDB}
+		_, _, err := driftCmd.Results()
+		if err == nil {
+			t.Error(testutil.Callers(), "expected error but got nil")
+		}
+	})
+}
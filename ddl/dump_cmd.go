@@ -387,6 +387,17 @@ func (cmd *DumpCmd) dumpZip(name string) error {
 				return err
 			}
 		}
+		// sequences.sql
+		if cmd.hasSequenceValues() {
+			file, err := zipWriter.Create("sequences.sql")
+			if err != nil {
+				return err
+			}
+			err = cmd.dumpSequenceValues(file)
+			if err != nil {
+				return err
+			}
+		}
 	}
 	err = zipWriter.Close()
 	if err != nil {
@@ -504,6 +515,22 @@ func (cmd *DumpCmd) dumpTgz(name string) error {
 				return err
 			}
 		}
+		// sequences.sql
+		if cmd.hasSequenceValues() {
+			buf.Reset()
+			err = cmd.dumpSequenceValues(buf)
+			if err != nil {
+				return err
+			}
+			err = tarWriter.WriteHeader(&tar.Header{Name: "sequences.sql", Mode: 0644, Size: int64(buf.Len())})
+			if err != nil {
+				return err
+			}
+			_, err = buf.WriteTo(tarWriter)
+			if err != nil {
+				return err
+			}
+		}
 	}
 	err = tarWriter.Close()
 	if err != nil {
@@ -616,6 +643,23 @@ func (cmd *DumpCmd) dumpDir() error {
 		if err != nil {
 			return err
 		}
+		// sequences.sql
+		if cmd.hasSequenceValues() {
+			filename := filepath.Join(cmd.OutputDir, "sequences.sql")
+			file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			err = cmd.dumpSequenceValues(file)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.Stderr, filename)
+			err = file.Close()
+			if err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -701,6 +745,14 @@ func (cmd *DumpCmd) dumpSchema(w io.Writer) error {
 			}
 			buf.WriteString(";\n")
 		}
+
+		// CREATE SEQUENCE.
+		for j := range schema.Sequences {
+			if buf.Len() > 0 {
+				buf.WriteString("\n")
+			}
+			writeCreateSequence(cmd.Dialect, buf, cmd.catalog.CurrentSchema, &schema.Sequences[j], false)
+		}
 	}
 
 	for i := range cmd.catalog.Schemas {
@@ -714,7 +766,23 @@ func (cmd *DumpCmd) dumpSchema(w io.Writer) error {
 			if buf.Len() > 0 {
 				buf.WriteString("\n")
 			}
-			writeCreateTable(cmd.Dialect, buf, cmd.catalog.CurrentSchema, cmd.catalog.DefaultCollation, table, false)
+			writeCreateTable(cmd.Dialect, buf, cmd.catalog.CurrentSchema, cmd.catalog.DefaultCollation, table, false, false)
+		}
+	}
+
+	// ALTER SEQUENCE OWNED BY.
+	// The owning tables have to exist first, so this is done at the very end.
+	for i := range cmd.catalog.Schemas {
+		schema := &cmd.catalog.Schemas[i]
+		for j := range schema.Sequences {
+			sequence := &schema.Sequences[j]
+			if sequence.OwnedByTable == "" || sequence.OwnedByColumn == "" {
+				continue
+			}
+			if buf.Len() > 0 {
+				buf.WriteString("\n")
+			}
+			writeSequenceOwnedBy(buf, cmd.catalog.CurrentSchema, sequence)
 		}
 	}
 
@@ -749,7 +817,7 @@ func (cmd *DumpCmd) dumpIndexes(w io.Writer) error {
 			buf.WriteString(table.TableName + "\n")
 			for k := range table.Indexes {
 				index := &table.Indexes[k]
-				writeCreateIndex(cmd.Dialect, buf, cmd.catalog.CurrentSchema, index, false)
+				writeCreateIndex(cmd.Dialect, buf, cmd.catalog.CurrentSchema, index, false, false)
 			}
 		}
 	}
@@ -839,6 +907,61 @@ func (cmd *DumpCmd) dumpConstraints(w io.Writer) error {
 	return nil
 }
 
+// hasSequenceValues reports whether any sequence in the catalog has been used
+// and so needs to have its current value restored after the data is loaded.
+func (cmd *DumpCmd) hasSequenceValues() bool {
+	for _, schema := range cmd.catalog.Schemas {
+		for _, sequence := range schema.Sequences {
+			if sequence.LastValue != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dumpSequenceValues writes the statements that restore each sequence to its
+// current value.
+func (cmd *DumpCmd) dumpSequenceValues(w io.Writer) error {
+	buf, isBuffer := w.(*bytes.Buffer)
+	if !isBuffer {
+		buf = bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		defer bufpool.Put(buf)
+	}
+	for _, schema := range cmd.catalog.Schemas {
+		for _, sequence := range schema.Sequences {
+			if sequence.LastValue == 0 {
+				continue
+			}
+			sequenceName := QuoteIdentifier(cmd.Dialect, sequence.SequenceName)
+			if sequence.SequenceSchema != "" && sequence.SequenceSchema != cmd.catalog.CurrentSchema {
+				sequenceName = QuoteIdentifier(cmd.Dialect, sequence.SequenceSchema) + "." + sequenceName
+			}
+			incrementBy := sequence.IncrementBy
+			if incrementBy == 0 {
+				incrementBy = 1
+			}
+			switch cmd.Dialect {
+			case DialectPostgres:
+				buf.WriteString("SELECT setval('" + EscapeQuote(sequenceName, '\'') + "', " + strconv.FormatInt(sequence.LastValue, 10) + ");\n")
+			case DialectSQLServer:
+				buf.WriteString("ALTER SEQUENCE " + sequenceName + " RESTART WITH " + strconv.FormatInt(sequence.LastValue+incrementBy, 10) + ";\n")
+			case DialectOracle:
+				buf.WriteString("ALTER SEQUENCE " + sequenceName + " RESTART START WITH " + strconv.FormatInt(sequence.LastValue+incrementBy, 10) + ";\n")
+			}
+		}
+	}
+	if isBuffer {
+		return nil
+	}
+	_, err := buf.WriteTo(w)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (cmd *DumpCmd) dumpCSV(ctx context.Context, w io.Writer, table *Table, query string) error {
 	headers := make([]string, 0, len(table.Columns))
 	columnTypes := make([]string, 0, len(table.Columns))
@@ -1013,16 +1136,124 @@ func writeCreateSchema(dialect string, buf *bytes.Buffer, schemaName string) {
 	}
 }
 
-func writeCreateTable(dialect string, buf *bytes.Buffer, currentSchema, defaultCollation string, table *Table, includeConstraints bool) {
-	if table.SQL != "" {
-		buf.WriteString(table.SQL + "\n")
+// writeCreateSequence writes the CREATE SEQUENCE statement for a sequence. The
+// OWNED BY clause is written separately by writeSequenceOwnedBy because the
+// owning table may not exist yet. ifNotExists is only supported by Postgres.
+func writeCreateSequence(dialect string, buf *bytes.Buffer, currentSchema string, sequence *Sequence, ifNotExists bool) {
+	sequenceName := QuoteIdentifier(dialect, sequence.SequenceName)
+	if sequence.SequenceSchema != "" && sequence.SequenceSchema != currentSchema {
+		sequenceName = QuoteIdentifier(dialect, sequence.SequenceSchema) + "." + sequenceName
+	}
+	if ifNotExists && dialect == DialectPostgres {
+		buf.WriteString("CREATE SEQUENCE IF NOT EXISTS " + sequenceName)
+	} else {
+		buf.WriteString("CREATE SEQUENCE " + sequenceName)
+	}
+	writeSequenceOptions(dialect, buf, sequence)
+	buf.WriteString(";\n")
+}
+
+// writeSequenceOptions writes the options of a sequence (shared by CREATE
+// SEQUENCE and ALTER SEQUENCE). Zero values are omitted so that the database
+// defaults apply.
+func writeSequenceOptions(dialect string, buf *bytes.Buffer, sequence *Sequence) {
+	if sequence.DataType != "" && dialect != DialectOracle {
+		buf.WriteString(" AS " + sequence.DataType)
+	}
+	if sequence.StartValue != 0 {
+		buf.WriteString(" START WITH " + strconv.FormatInt(sequence.StartValue, 10))
+	}
+	if sequence.IncrementBy != 0 {
+		buf.WriteString(" INCREMENT BY " + strconv.FormatInt(sequence.IncrementBy, 10))
+	}
+	if sequence.MinValue != 0 {
+		buf.WriteString(" MINVALUE " + strconv.FormatInt(sequence.MinValue, 10))
+	}
+	if sequence.MaxValue != 0 {
+		buf.WriteString(" MAXVALUE " + strconv.FormatInt(sequence.MaxValue, 10))
+	}
+	if sequence.IsCycle {
+		buf.WriteString(" CYCLE")
+	}
+}
+
+// writeAlterSequence writes the ALTER SEQUENCE statement that changes the
+// given options of a sequence. Nothing is written if there are no options to
+// change.
+func writeAlterSequence(dialect string, buf *bytes.Buffer, currentSchema string, sequence *Sequence, options sequenceOptions) {
+	if options == (sequenceOptions{}) {
 		return
 	}
+	sequenceName := QuoteIdentifier(dialect, sequence.SequenceName)
+	if sequence.SequenceSchema != "" && sequence.SequenceSchema != currentSchema {
+		sequenceName = QuoteIdentifier(dialect, sequence.SequenceSchema) + "." + sequenceName
+	}
+	buf.WriteString("ALTER SEQUENCE " + sequenceName)
+	if options.dataType != "" {
+		buf.WriteString(" AS " + options.dataType)
+	}
+	if options.startValue != 0 {
+		buf.WriteString(" START WITH " + strconv.FormatInt(options.startValue, 10))
+	}
+	if options.incrementBy != 0 {
+		buf.WriteString(" INCREMENT BY " + strconv.FormatInt(options.incrementBy, 10))
+	}
+	if options.minValue != 0 {
+		buf.WriteString(" MINVALUE " + strconv.FormatInt(options.minValue, 10))
+	}
+	if options.maxValue != 0 {
+		buf.WriteString(" MAXVALUE " + strconv.FormatInt(options.maxValue, 10))
+	}
+	if options.cycle == "NO CYCLE" && dialect == DialectOracle {
+		buf.WriteString(" NOCYCLE")
+	} else if options.cycle != "" {
+		buf.WriteString(" " + options.cycle)
+	}
+	buf.WriteString(";\n")
+}
+
+// writeSequenceOwnedBy writes the ALTER SEQUENCE OWNED BY statement for a
+// sequence. Postgres only.
+func writeSequenceOwnedBy(buf *bytes.Buffer, currentSchema string, sequence *Sequence) {
+	const dialect = DialectPostgres
+	sequenceName := QuoteIdentifier(dialect, sequence.SequenceName)
+	tableName := QuoteIdentifier(dialect, sequence.OwnedByTable)
+	if sequence.SequenceSchema != "" && sequence.SequenceSchema != currentSchema {
+		sequenceName = QuoteIdentifier(dialect, sequence.SequenceSchema) + "." + sequenceName
+		tableName = QuoteIdentifier(dialect, sequence.SequenceSchema) + "." + tableName
+	}
+	if sequence.OwnedByTable == "" || sequence.OwnedByColumn == "" {
+		buf.WriteString("ALTER SEQUENCE " + sequenceName + " OWNED BY NONE;\n")
+		return
+	}
+	buf.WriteString("ALTER SEQUENCE " + sequenceName + " OWNED BY " + tableName + "." + QuoteIdentifier(dialect, sequence.OwnedByColumn) + ";\n")
+}
+
+func writeCreateTable(dialect string, buf *bytes.Buffer, currentSchema, defaultCollation string, table *Table, includeConstraints, ifNotExists bool) {
 	tableName := QuoteIdentifier(dialect, table.TableName)
 	if table.TableSchema != "" && table.TableSchema != currentSchema {
 		tableName = QuoteIdentifier(dialect, table.TableSchema) + "." + tableName
 	}
-	buf.WriteString("CREATE TABLE " + tableName + " (")
+	// IF NOT EXISTS is not supported by SQL Server or Oracle.
+	if dialect == DialectSQLServer || dialect == DialectOracle {
+		ifNotExists = false
+	}
+	if table.SQL != "" {
+		sql := table.SQL
+		if ifNotExists {
+			sql = strings.Replace(sql, "TABLE "+tableName, "TABLE IF NOT EXISTS "+tableName, 1)
+		}
+		buf.WriteString(sql + "\n")
+		return
+	}
+	if ifNotExists {
+		tableName = "IF NOT EXISTS " + tableName
+	}
+	if table.IsUnlogged && dialect == DialectPostgres {
+		buf.WriteString("CREATE UNLOGGED TABLE " + tableName + " (")
+	} else {
+		buf.WriteString("CREATE TABLE " + tableName + " (")
+	}
 	columnWritten := false
 	for i := range table.Columns {
 		column := &table.Columns[i]
@@ -1055,7 +1286,16 @@ func writeCreateTable(dialect string, buf *bytes.Buffer, currentSchema, defaultC
 			}
 			writeConstraintDefinition(dialect, buf, currentSchema, constraint)
 		}
-		buf.WriteString("\n);\n")
+		buf.WriteString("\n)")
+		// STRICT, WITHOUT ROWID
+		if table.IsStrict && table.IsWithoutRowid {
+			buf.WriteString(" STRICT, WITHOUT ROWID")
+		} else if table.IsStrict {
+			buf.WriteString(" STRICT")
+		} else if table.IsWithoutRowid {
+			buf.WriteString(" WITHOUT ROWID")
+		}
+		buf.WriteString(";\n")
 		return
 	}
 	if !includeConstraints {
@@ -1069,46 +1309,127 @@ func writeCreateTable(dialect string, buf *bytes.Buffer, currentSchema, defaultC
 				}
 			}
 		}
-		buf.WriteString("\n);\n")
-		return
+	} else {
+		newlineSeparatorWritten := false
+		for i := range table.Constraints {
+			constraint := &table.Constraints[i]
+			if constraint.Ignore {
+				continue
+			}
+			if constraint.ConstraintType == FOREIGN_KEY {
+				continue
+			}
+			if !newlineSeparatorWritten {
+				newlineSeparatorWritten = true
+				buf.WriteString("\n\n    ,")
+			} else {
+				buf.WriteString("\n    ,")
+			}
+			writeConstraintDefinition(dialect, buf, currentSchema, constraint)
+		}
 	}
-	newlineSeparatorWritten := false
-	for i := range table.Constraints {
-		constraint := &table.Constraints[i]
-		if constraint.Ignore {
-			continue
+	buf.WriteString("\n)")
+	// ENGINE, DEFAULT CHARSET
+	if dialect == DialectMySQL {
+		if table.Engine != "" {
+			buf.WriteString(" ENGINE=" + table.Engine)
 		}
-		if constraint.ConstraintType == FOREIGN_KEY {
+		if table.Charset != "" {
+			buf.WriteString(" DEFAULT CHARSET=" + table.Charset)
+		}
+	}
+	// COMMENT
+	if table.Comment != "" && dialect == DialectMySQL {
+		buf.WriteString(" COMMENT='" + EscapeQuote(table.Comment, '\'') + "'")
+	}
+	// PARTITION BY
+	if table.PartitionStrategy != "" && (dialect == DialectPostgres || dialect == DialectMySQL) {
+		buf.WriteString(" PARTITION BY " + table.PartitionStrategy + " (" + table.PartitionKey + ")")
+		if dialect == DialectMySQL {
+			writePartitionDefinitions(buf, table.Partitions)
+		}
+	}
+	// WITH
+	if len(table.StorageParams) > 0 && dialect == DialectPostgres {
+		buf.WriteString(" WITH (" + strings.Join(table.StorageParams, ", ") + ")")
+	}
+	buf.WriteString(";\n")
+	// For Postgres, each partition is a separate table.
+	if table.PartitionStrategy != "" && dialect == DialectPostgres {
+		for i := range table.Partitions {
+			partition := &table.Partitions[i]
+			if partition.Ignore {
+				continue
+			}
+			buf.WriteString("\n")
+			writeCreatePartition(buf, currentSchema, table, partition)
+		}
+	}
+}
+
+// writePartitionDefinitions writes the list of partition definitions of a
+// MySQL partitioned table.
+func writePartitionDefinitions(buf *bytes.Buffer, partitions []Partition) {
+	const dialect = DialectMySQL
+	written := false
+	for i := range partitions {
+		partition := &partitions[i]
+		if partition.Ignore {
 			continue
 		}
-		if !newlineSeparatorWritten {
-			newlineSeparatorWritten = true
-			buf.WriteString("\n\n    ,")
+		if !written {
+			written = true
+			buf.WriteString(" (\n    ")
 		} else {
 			buf.WriteString("\n    ,")
 		}
-		writeConstraintDefinition(dialect, buf, currentSchema, constraint)
+		buf.WriteString("PARTITION " + QuoteIdentifier(dialect, partition.PartitionName))
+		if partition.PartitionBound != "" {
+			buf.WriteString(" " + partition.PartitionBound)
+		}
 	}
-	buf.WriteString("\n);\n")
+	if written {
+		buf.WriteString("\n)")
+	}
+}
+
+// writeCreatePartition writes the CREATE TABLE ... PARTITION OF statement for
+// a partition of a Postgres partitioned table.
+func writeCreatePartition(buf *bytes.Buffer, currentSchema string, table *Table, partition *Partition) {
+	const dialect = DialectPostgres
+	tableName := QuoteIdentifier(dialect, table.TableName)
+	partitionName := QuoteIdentifier(dialect, partition.PartitionName)
+	if table.TableSchema != "" && table.TableSchema != currentSchema {
+		tableName = QuoteIdentifier(dialect, table.TableSchema) + "." + tableName
+		partitionName = QuoteIdentifier(dialect, table.TableSchema) + "." + partitionName
+	}
+	buf.WriteString("CREATE TABLE " + partitionName + " PARTITION OF " + tableName + " " + partition.PartitionBound + ";\n")
 }
 
-func writeCreateIndex(dialect string, buf *bytes.Buffer, currentSchema string, index *Index, createConcurrently bool) {
+func writeCreateIndex(dialect string, buf *bytes.Buffer, currentSchema string, index *Index, createConcurrently, ifNotExists bool) {
+	// IF NOT EXISTS is only supported by Postgres and SQLite.
+	if dialect != DialectPostgres && dialect != DialectSQLite {
+		ifNotExists = false
+	}
 	if index.SQL != "" {
 		sql := index.SQL
+		indexName := QuoteIdentifier(dialect, index.IndexName)
 		if createConcurrently && dialect == DialectPostgres {
-			indexName := QuoteIdentifier(dialect, index.IndexName)
 			sql = strings.Replace(sql, "CREATE INDEX "+indexName, "CREATE INDEX CONCURRENTLY "+indexName, 1)
 		}
+		if ifNotExists {
+			sql = strings.Replace(sql, " "+indexName, " IF NOT EXISTS "+indexName, 1)
+		}
 		buf.WriteString(sql + "\n")
 		return
 	}
 	// CREATE INDEX.
 	buf.WriteString("CREATE ")
-	writeIndexDefinition(dialect, buf, currentSchema, index, createConcurrently, false)
+	writeIndexDefinition(dialect, buf, currentSchema, index, createConcurrently, false, ifNotExists)
 	buf.WriteString(";\n")
 }
 
-func writeIndexDefinition(dialect string, buf *bytes.Buffer, currentSchema string, index *Index, createConcurrently, createInline bool) {
+func writeIndexDefinition(dialect string, buf *bytes.Buffer, currentSchema string, index *Index, createConcurrently, createInline, ifNotExists bool) {
 	var isFulltextOrSpatialIndex bool
 	if dialect == DialectMySQL {
 		if strings.EqualFold(index.IndexType, "FULLTEXT") || strings.EqualFold(index.IndexName, "SPATIAL") {
@@ -1125,6 +1446,9 @@ func writeIndexDefinition(dialect string, buf *bytes.Buffer, currentSchema strin
 	if createConcurrently && dialect == DialectPostgres {
 		buf.WriteString("CONCURRENTLY ")
 	}
+	if ifNotExists {
+		buf.WriteString("IF NOT EXISTS ")
+	}
 	buf.WriteString(indexName)
 	if index.IndexType != "" && dialect == DialectMySQL && !strings.EqualFold(index.IndexType, "BTREE") && !isFulltextOrSpatialIndex {
 		if !createInline {
@@ -1152,6 +1476,9 @@ func writeIndexDefinition(dialect string, buf *bytes.Buffer, currentSchema strin
 		} else {
 			buf.WriteString(QuoteIdentifier(dialect, column))
 		}
+		if dialect == DialectPostgres && i < len(index.Opclasses) && !isDefaultOpclass(index.Opclasses[i]) {
+			buf.WriteString(" " + index.Opclasses[i])
+		}
 		if i < len(index.Descending) && index.Descending[i] {
 			buf.WriteString(" DESC")
 		}
@@ -1167,6 +1494,27 @@ func writeIndexDefinition(dialect string, buf *bytes.Buffer, currentSchema strin
 	}
 }
 
+// identityDefinition returns the identity definition of a column together
+// with its START WITH and INCREMENT BY options (if any).
+func identityDefinition(dialect string, column *Column) string {
+	if column.IdentityStart == 0 && column.IdentityIncrement == 0 {
+		return column.ColumnIdentity
+	}
+	if dialect == DialectSQLServer {
+		// SQL Server requires both the seed and the increment.
+		start, increment := identityOptions(column)
+		return column.ColumnIdentity + "(" + strconv.FormatInt(start, 10) + ", " + strconv.FormatInt(increment, 10) + ")"
+	}
+	var options []string
+	if column.IdentityStart != 0 {
+		options = append(options, "START WITH "+strconv.FormatInt(column.IdentityStart, 10))
+	}
+	if column.IdentityIncrement != 0 {
+		options = append(options, "INCREMENT BY "+strconv.FormatInt(column.IdentityIncrement, 10))
+	}
+	return column.ColumnIdentity + " (" + strings.Join(options, " ") + ")"
+}
+
 func writeColumnDefinition(dialect string, buf *bytes.Buffer, defaultCollation string, column *Column, columnLevelConstraint bool) {
 	isSQLServerGeneratedColumn := dialect == DialectSQLServer && column.GeneratedExpr != ""
 	// ColumnName
@@ -1179,6 +1527,15 @@ func writeColumnDefinition(dialect string, buf *bytes.Buffer, defaultCollation s
 	} else if column.ColumnType != "" && !isSQLServerGeneratedColumn {
 		buf.WriteString(" " + strings.ToUpper(column.ColumnType))
 	}
+	// Oracle requires DEFAULT and IDENTITY to come before any column
+	// constraints (including NOT NULL).
+	if dialect == DialectOracle {
+		if column.ColumnIdentity != "" {
+			buf.WriteString(" " + identityDefinition(dialect, column))
+		} else if column.ColumnDefault != "" {
+			buf.WriteString(" DEFAULT " + column.ColumnDefault)
+		}
+	}
 	// PRIMARY KEY
 	if column.IsPrimaryKey && (columnLevelConstraint || dialect == DialectSQLite) {
 		buf.WriteString(" PRIMARY KEY")
@@ -1202,10 +1559,10 @@ func writeColumnDefinition(dialect string, buf *bytes.Buffer, defaultCollation s
 	}
 	// IDENTITY
 	if column.ColumnIdentity != "" && (dialect == DialectPostgres || dialect == DialectSQLServer) {
-		buf.WriteString(" " + column.ColumnIdentity)
+		buf.WriteString(" " + identityDefinition(dialect, column))
 	}
 	// DEFAULT
-	if column.ColumnDefault != "" {
+	if column.ColumnDefault != "" && dialect != DialectOracle {
 		buf.WriteString(" DEFAULT " + column.ColumnDefault)
 	}
 	// ON UPDATE CURRENT TIMESTAMP
@@ -1236,8 +1593,14 @@ func writeColumnDefinition(dialect string, buf *bytes.Buffer, defaultCollation s
 			if column.GeneratedExprStored {
 				buf.WriteString(" PERSISTED")
 			}
+		case DialectOracle:
+			buf.WriteString(" GENERATED ALWAYS AS " + generatedExpr + " VIRTUAL")
 		}
 	}
+	// COMMENT
+	if column.Comment != "" && dialect == DialectMySQL {
+		buf.WriteString(" COMMENT '" + EscapeQuote(column.Comment, '\'') + "'")
+	}
 	// REFERENCES
 	if columnLevelConstraint && column.ReferencesTable != "" && column.ReferencesColumn != "" {
 		buf.WriteString(" REFERENCES ")
@@ -1245,7 +1608,7 @@ func writeColumnDefinition(dialect string, buf *bytes.Buffer, defaultCollation s
 			buf.WriteString(QuoteIdentifier(dialect, column.ReferencesSchema) + ".")
 		}
 		buf.WriteString(QuoteIdentifier(dialect, column.ReferencesTable) + " (" + QuoteIdentifier(dialect, column.ReferencesColumn) + ")")
-		if column.UpdateRule != "" && column.UpdateRule != NO_ACTION {
+		if column.UpdateRule != "" && column.UpdateRule != NO_ACTION && dialect != DialectOracle {
 			buf.WriteString(" ON UPDATE " + column.UpdateRule)
 		}
 		if column.DeleteRule != "" && column.DeleteRule != NO_ACTION {
@@ -1315,7 +1678,7 @@ func writeConstraintDefinition(dialect string, buf *bytes.Buffer, currentSchema
 		buf.WriteString(" REFERENCES " + referencesTable + " (")
 		writeColumnNames(dialect, buf, constraint.ReferencesColumns)
 		buf.WriteString(")")
-		if constraint.UpdateRule != "" && constraint.UpdateRule != NO_ACTION {
+		if constraint.UpdateRule != "" && constraint.UpdateRule != NO_ACTION && dialect != DialectOracle {
 			buf.WriteString(" ON UPDATE " + constraint.UpdateRule)
 		}
 		if constraint.DeleteRule != "" && constraint.DeleteRule != NO_ACTION {
@@ -3,10 +3,12 @@ package ddl
 import (
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/bokwoon95/sqddl/internal/testutil"
 )
@@ -23,23 +25,36 @@ func TestGenerateCmd(t *testing.T) {
 		{dialect: "mysql", dir: "testdata/mysql_schema"},
 		{dialect: "mysql", dir: "testdata/mysql_table"},
 		{dialect: "mysql", dir: "testdata/mysql_ignore"},
+		{dialect: "mysql", dir: "testdata/mysql_comment"},
+		{dialect: "mysql", dir: "testdata/mysql_rename"},
+		{dialect: "oracle", dir: "testdata/oracle_add"},
+		{dialect: "oracle", dir: "testdata/oracle_alter"},
+		{dialect: "oracle", dir: "testdata/oracle_drop"},
+		{dialect: "oracle", dir: "testdata/oracle_table"},
+		{dialect: "oracle", dir: "testdata/oracle_comment"},
+		{dialect: "oracle", dir: "testdata/oracle_rename"},
 		{dialect: "postgres", dir: "testdata/postgres_add"},
 		{dialect: "postgres", dir: "testdata/postgres_alter"},
 		{dialect: "postgres", dir: "testdata/postgres_drop"},
 		{dialect: "postgres", dir: "testdata/postgres_schema"},
 		{dialect: "postgres", dir: "testdata/postgres_table"},
 		{dialect: "postgres", dir: "testdata/postgres_ignore"},
+		{dialect: "postgres", dir: "testdata/postgres_comment"},
+		{dialect: "postgres", dir: "testdata/postgres_rename"},
 		{dialect: "sqlite", dir: "testdata/sqlite_create_schema"},
 		{dialect: "sqlite", dir: "testdata/sqlite_drop_schema"},
 		{dialect: "sqlite", dir: "testdata/sqlite_empty"},
 		{dialect: "sqlite", dir: "testdata/sqlite_misc"},
 		{dialect: "sqlite", dir: "testdata/sqlite_ignore"},
+		{dialect: "sqlite", dir: "testdata/sqlite_rename"},
 		{dialect: "sqlserver", dir: "testdata/sqlserver_add"},
 		{dialect: "sqlserver", dir: "testdata/sqlserver_alter"},
 		{dialect: "sqlserver", dir: "testdata/sqlserver_drop"},
 		{dialect: "sqlserver", dir: "testdata/sqlserver_schema"},
 		{dialect: "sqlserver", dir: "testdata/sqlserver_table"},
 		{dialect: "sqlserver", dir: "testdata/sqlserver_ignore"},
+		{dialect: "sqlserver", dir: "testdata/sqlserver_comment"},
+		{dialect: "sqlserver", dir: "testdata/sqlserver_rename"},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -146,19 +161,38 @@ func Test_writeCatalog(t *testing.T) {
 		t.Fatal(testutil.Callers(), err)
 	}
 
+	// A directory of SQL files, loaded in lexical order.
+	schemaSQL, err := os.ReadFile("testdata/sqlite/schema.sql")
+	if err != nil {
+		t.Fatal(testutil.Callers(), err)
+	}
+	indexesSQL, err := os.ReadFile("testdata/sqlite/indexes.sql")
+	if err != nil {
+		t.Fatal(testutil.Callers(), err)
+	}
+	sqlFS := fstest.MapFS{
+		"schema/01_schema.sql":  &fstest.MapFile{Data: schemaSQL},
+		"schema/02_indexes.sql": &fstest.MapFile{Data: indexesSQL},
+	}
+
 	sources := []string{
 		"testdata/sqlite/schema.json",
 		"testdata/sqlite/tables.go.txt",
 		"testdata/sqlite/dump.zip",
 		"testdata/sqlite/dump.tgz",
 		"testdata/sqlite",
+		"schema",
 		dsn,
 	}
 	for _, source := range sources {
 		source := source
 		t.Run(source, func(t *testing.T) {
+			var fsys fs.FS = os.DirFS(".")
+			if source == "schema" {
+				fsys = sqlFS
+			}
 			gotCatalog := &Catalog{Dialect: "sqlite"}
-			err = writeCatalog(gotCatalog, os.DirFS("."), "sqddl_history", source)
+			err = writeCatalog(gotCatalog, fsys, "sqddl_history", "", source)
 			if err != nil {
 				t.Fatal(testutil.Callers(), err)
 			}
@@ -186,3 +220,135 @@ func Test_writeCatalog(t *testing.T) {
 		})
 	}
 }
+
+func Test_columnOrderReport(t *testing.T) {
+	newCatalog := func(columnNames ...string) *Catalog {
+		table := Table{TableSchema: "public", TableName: "actor"}
+		for _, columnName := range columnNames {
+			table.Columns = append(table.Columns, Column{TableSchema: "public", TableName: "actor", ColumnName: columnName, ColumnType: "INT"})
+		}
+		return &Catalog{
+			Dialect:       DialectPostgres,
+			CurrentSchema: "public",
+			Schemas:       []Schema{{SchemaName: "public", Tables: []Table{table}}},
+		}
+	}
+	// New and dropped columns do not count towards the column order.
+	srcCatalog := newCatalog("actor_id", "last_name", "first_name", "last_update")
+	destCatalog := newCatalog("actor_id", "first_name", "middle_name", "last_name")
+	gotReport := columnOrderReport(DialectPostgres, srcCatalog, destCatalog)
+	wantReport := []string{
+		"actor: the column order (actor_id, last_name, first_name) differs from the desired column order (actor_id, first_name, last_name), postgres cannot reorder existing columns without recreating the table",
+	}
+	if diff := testutil.Diff(gotReport, wantReport); diff != "" {
+		t.Error(testutil.Callers(), diff)
+	}
+
+	// The report is written to Stderr and does not block the migration.
+	stderr := &strings.Builder{}
+	generateCmd := &GenerateCmd{
+		SrcCatalog:  srcCatalog,
+		DestCatalog: destCatalog,
+		Dialect:     DialectPostgres,
+		OutputDir:   t.TempDir(),
+		ColumnOrder: true,
+		Stderr:      stderr,
+	}
+	err := generateCmd.Run()
+	if err != nil {
+		t.Fatal(testutil.Callers(), err)
+	}
+	if !strings.Contains(stderr.String(), wantReport[0]) {
+		t.Errorf(testutil.Callers()+" expected %q in stderr, got %q", wantReport[0], stderr.String())
+	}
+
+	destCatalog = newCatalog("actor_id", "middle_name", "last_name", "first_name")
+	gotReport = columnOrderReport(DialectPostgres, srcCatalog, destCatalog)
+	if len(gotReport) != 0 {
+		t.Errorf(testutil.Callers()+" expected no report, got %v", gotReport)
+	}
+}
+
+func TestGenerateCmdSplitBy(t *testing.T) {
+	type TT struct {
+		dialect       string
+		dir           string
+		splitBy       string
+		wantFilenames []string
+	}
+	tests := []TT{{
+		dialect: "sqlite",
+		dir:     "testdata/sqlite_misc",
+		splitBy: "table",
+		wantFilenames: []string{
+			"misc_01_drop_residence.sql", "misc_01_drop_residence.undo.sql",
+			"misc_02_create_country.sql", "misc_02_create_country.undo.sql",
+			"misc_03_create_city.sql", "misc_03_create_city.undo.sql",
+			"misc_04_create_address.sql", "misc_04_create_address.undo.sql",
+			"misc_05_alter_author.sql", "misc_05_alter_author.undo.sql",
+			"misc_06_alter_post.sql", "misc_06_alter_post.undo.sql",
+		},
+	}, {
+		dialect: "sqlite",
+		dir:     "testdata/sqlite_misc",
+		splitBy: "step",
+		wantFilenames: []string{
+			"misc_01_drop_tables.sql", "misc_01_drop_tables.undo.sql",
+			"misc_02_create_tables.sql", "misc_02_create_tables.undo.sql",
+			"misc_03_alter_tables.sql", "misc_03_alter_tables.undo.sql",
+		},
+	}, {
+		dialect: "postgres",
+		dir:     "testdata/postgres_table",
+		splitBy: "step",
+		wantFilenames: []string{
+			"table_01_drop_movie_award_movie_fkeys.tx.sql",
+			"table_02_drop_movie_award_actor_fkeys.tx.sql",
+			"table_03_drop_tables.sql", "table_03_drop_tables.undo.sql",
+			"table_04_create_tables.sql", "table_04_create_tables.undo.sql",
+			"table_05_add_movie_awards_movies_fkeys.tx.sql",
+			"table_06_add_movie_awards_actors_fkeys.tx.sql",
+		},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.dir+" "+tt.splitBy, func(t *testing.T) {
+			t.Parallel()
+			generateCmd, err := GenerateCommand(
+				"-src", filepath.Join(tt.dir, "src.go.txt"),
+				"-dest", filepath.Join(tt.dir, "dest.go.txt"),
+				"-prefix", strings.TrimPrefix(tt.dir, "testdata/"+tt.dialect+"_"),
+				"-drop-objects",
+				"-dialect", tt.dialect,
+				"-split-by", tt.splitBy,
+			)
+			if err != nil {
+				t.Fatal(testutil.Callers(), err)
+			}
+			files, _, err := generateCmd.Results()
+			if err != nil {
+				t.Fatal(testutil.Callers(), err)
+			}
+			var gotFilenames []string
+			for _, file := range files {
+				fileInfo, err := file.Stat()
+				if err != nil {
+					t.Fatal(testutil.Callers(), err)
+				}
+				gotFilenames = append(gotFilenames, fileInfo.Name())
+			}
+			if diff := testutil.Diff(gotFilenames, tt.wantFilenames); diff != "" {
+				t.Error(testutil.Callers(), diff)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		generateCmd := &GenerateCmd{Dialect: "sqlite", SplitBy: "schema"}
+		_, _, err := generateCmd.Results()
+		if err == nil {
+			t.Error(testutil.Callers(), "expected error, got nil")
+		}
+	})
+}
@@ -142,13 +142,13 @@ func TestMain(m *testing.M) {
 				if buf.Len() > 0 {
 					buf.WriteString("\n")
 				}
-				writeCreateTable(dialect, buf, "", "", table, true)
+				writeCreateTable(dialect, buf, "", "", table, true, false)
 				for k := range table.Indexes {
 					index := &table.Indexes[k]
 					if buf.Len() > 0 {
 						buf.WriteString("\n")
 					}
-					writeCreateIndex(dialect, buf, "", index, false)
+					writeCreateIndex(dialect, buf, "", index, false, false)
 				}
 			}
 		}
@@ -283,12 +283,12 @@ func TestMain(m *testing.M) {
 
 		for _, tc := range database.testcases {
 			srcCatalog := &Catalog{Dialect: database.dialect, CurrentSchema: database.currentSchema}
-			err = writeCatalog(srcCatalog, os.DirFS("."), "sqddl_history", filepath.Join(tc.dir, "src.go.txt"))
+			err = writeCatalog(srcCatalog, os.DirFS("."), "sqddl_history", "", filepath.Join(tc.dir, "src.go.txt"))
 			if err != nil {
 				l.Fatalf("%s: %s: %s", database.dialect, tc.dir, err)
 			}
 			destCatalog := &Catalog{Dialect: database.dialect, CurrentSchema: database.currentSchema}
-			err = writeCatalog(destCatalog, os.DirFS("."), "sqddl_history", filepath.Join(tc.dir, "dest.go.txt"))
+			err = writeCatalog(destCatalog, os.DirFS("."), "sqddl_history", "", filepath.Join(tc.dir, "dest.go.txt"))
 			if err != nil {
 				l.Fatalf("%s: %s: %s", database.dialect, tc.dir, err)
 			}
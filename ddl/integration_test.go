@@ -41,6 +41,7 @@ func TestSQLite(t *testing.T) {
 		"testdata/sqlite_empty",
 		"testdata/sqlite_misc",
 		"testdata/sqlite_ignore",
+		"testdata/sqlite_rename",
 	)
 	testLoadDump(t, dialect, sqliteDSN, nil)
 }
@@ -465,7 +466,7 @@ func rewriteCSV(filename string, transform func(record []string) []string) error
 
 func writeDatabaseSchemaIntoJSONFile(t *testing.T, dsn string, filename string) {
 	catalog := &Catalog{}
-	err := writeCatalog(catalog, os.DirFS("."), "sqddl_history", dsn)
+	err := writeCatalog(catalog, os.DirFS("."), "sqddl_history", "", dsn)
 	if err != nil {
 		t.Fatal(testutil.Callers(), err)
 	}
@@ -0,0 +1,29 @@
+SELECT
+    table_schema
+    ,table_name
+    ,partition_name
+    ,partition_method
+    ,COALESCE(partition_description, '') AS partition_description
+FROM
+    information_schema.partitions
+WHERE
+    partition_name IS NOT NULL -- exclude tables that are not partitioned
+    AND COALESCE(subpartition_ordinal_position, 1) = 1 -- list each partition only once
+    {{- if not .IncludeSystemCatalogs }}
+    AND table_schema NOT IN ('mysql', 'information_schema', 'performance_schema', 'sys')
+    {{- end }}
+    {{- if .Schemas }}
+    AND table_schema IN ({{ mklist .Schemas }})
+    {{- else if .ExcludeSchemas }}
+    AND table_schema NOT IN ({{ mklist .ExcludeSchemas }})
+    {{- end }}
+    {{- if .Tables }}
+    AND table_name IN ({{ mklist .Tables }})
+    {{- else if .ExcludeTables }}
+    AND table_name NOT IN ({{ mklist .ExcludeTables }})
+    {{- end }}
+ORDER BY
+    table_schema
+    ,table_name
+    ,partition_ordinal_position
+;
@@ -2,6 +2,29 @@ SELECT
     table_schema
     ,table_name
     ,COALESCE(table_comment, '') AS table_comment
+    ,COALESCE((
+        SELECT partition_method
+        FROM information_schema.partitions
+        WHERE partitions.table_schema = tables.table_schema
+            AND partitions.table_name = tables.table_name
+            AND partitions.partition_ordinal_position = 1
+            AND COALESCE(partitions.subpartition_ordinal_position, 1) = 1
+    ), '') AS partition_strategy
+    ,COALESCE((
+        SELECT partition_expression
+        FROM information_schema.partitions
+        WHERE partitions.table_schema = tables.table_schema
+            AND partitions.table_name = tables.table_name
+            AND partitions.partition_ordinal_position = 1
+            AND COALESCE(partitions.subpartition_ordinal_position, 1) = 1
+    ), '') AS partition_key
+    ,COALESCE(engine, '') AS engine
+    ,COALESCE((
+        SELECT character_set_name
+        FROM information_schema.collation_character_set_applicability AS ccsa
+        WHERE ccsa.collation_name = tables.table_collation
+        LIMIT 1
+    ), '') AS charset
 FROM
     information_schema.tables
 WHERE
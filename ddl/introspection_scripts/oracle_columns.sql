@@ -0,0 +1,55 @@
+SELECT
+    tables.owner AS table_schema
+    ,tables.table_name
+    ,tab_columns.column_name
+    ,tab_columns.data_type AS column_type
+    ,CASE
+        WHEN tab_columns.data_type IN ('VARCHAR2', 'NVARCHAR2', 'CHAR', 'NCHAR') THEN tab_columns.char_length
+        WHEN tab_columns.data_type = 'RAW' THEN tab_columns.data_length
+    END AS character_length
+    ,tab_columns.data_precision AS numeric_precision
+    ,tab_columns.data_scale AS numeric_scale
+    ,CASE tab_identity_cols.generation_type
+        WHEN 'ALWAYS' THEN 'GENERATED ALWAYS AS IDENTITY'
+        WHEN 'BY DEFAULT' THEN 'GENERATED BY DEFAULT AS IDENTITY'
+    END AS column_identity
+    -- identity_options looks like 'START WITH: 1, INCREMENT BY: 1, MAX_VALUE: ...'
+    ,TO_NUMBER(REGEXP_SUBSTR(tab_identity_cols.identity_options, 'START WITH: (-?[0-9]+)', 1, 1, NULL, 1)) AS identity_start
+    ,TO_NUMBER(REGEXP_SUBSTR(tab_identity_cols.identity_options, 'INCREMENT BY: (-?[0-9]+)', 1, 1, NULL, 1)) AS identity_increment
+    ,CASE WHEN tab_columns.nullable = 'N' THEN 1 ELSE 0 END AS is_notnull
+    ,CASE WHEN tab_columns.virtual_column = 'YES' THEN tab_columns.data_default_vc END AS generated_expr
+    ,CASE WHEN tab_columns.virtual_column = 'YES' OR tab_columns.identity_column = 'YES' THEN NULL ELSE tab_columns.data_default_vc END AS column_default
+    ,col_comments.comments AS column_comment
+FROM
+    all_tab_cols tab_columns
+    JOIN all_tables tables
+        ON tables.owner = tab_columns.owner
+        AND tables.table_name = tab_columns.table_name
+    LEFT JOIN all_tab_identity_cols tab_identity_cols
+        ON tab_identity_cols.owner = tab_columns.owner
+        AND tab_identity_cols.table_name = tab_columns.table_name
+        AND tab_identity_cols.column_name = tab_columns.column_name
+    LEFT JOIN all_col_comments col_comments
+        ON col_comments.owner = tab_columns.owner
+        AND col_comments.table_name = tab_columns.table_name
+        AND col_comments.column_name = tab_columns.column_name
+WHERE
+    tab_columns.hidden_column = 'NO'
+    AND tables.dropped = 'NO' AND tables.nested = 'NO' AND tables.secondary = 'N'
+    {{- if not .IncludeSystemCatalogs }}
+    AND tables.owner NOT IN (SELECT username FROM all_users WHERE oracle_maintained = 'Y')
+    {{- end }}
+    {{- if .Schemas }}
+    AND tables.owner IN ({{ mklist .Schemas }})
+    {{- else if .ExcludeSchemas }}
+    AND tables.owner NOT IN ({{ mklist .ExcludeSchemas }})
+    {{- end }}
+    {{- if .Tables }}
+    AND tables.table_name IN ({{ mklist .Tables }})
+    {{- else if .ExcludeTables }}
+    AND tables.table_name NOT IN ({{ mklist .ExcludeTables }})
+    {{- end }}
+ORDER BY
+    tables.owner
+    ,tables.table_name
+    ,tab_columns.column_id
@@ -0,0 +1,72 @@
+SELECT
+    constraints.owner AS table_schema
+    ,constraints.table_name
+    ,constraints.constraint_name
+    ,CASE constraints.constraint_type
+        WHEN 'P' THEN 'PRIMARY KEY'
+        WHEN 'U' THEN 'UNIQUE'
+        WHEN 'R' THEN 'FOREIGN KEY'
+        ELSE 'CHECK'
+    END AS constraint_type
+    ,(
+        SELECT LISTAGG(cons_columns.column_name, ',') WITHIN GROUP (ORDER BY cons_columns.position)
+        FROM all_cons_columns cons_columns
+        WHERE cons_columns.owner = constraints.owner
+        AND cons_columns.constraint_name = constraints.constraint_name
+    ) AS columns
+    ,ref_constraints.owner AS references_schema
+    ,ref_constraints.table_name AS references_table
+    ,(
+        SELECT LISTAGG(cons_columns.column_name, ',') WITHIN GROUP (ORDER BY cons_columns.position)
+        FROM all_cons_columns cons_columns
+        WHERE cons_columns.owner = ref_constraints.owner
+        AND cons_columns.constraint_name = ref_constraints.constraint_name
+    ) AS references_columns
+    ,CASE WHEN constraints.constraint_type = 'R' THEN constraints.delete_rule END AS delete_rule
+    ,constraints.search_condition_vc AS check_expr
+    ,CASE WHEN constraints.deferrable = 'DEFERRABLE' THEN 1 ELSE 0 END AS is_deferrable
+    ,CASE WHEN constraints.deferred = 'DEFERRED' THEN 1 ELSE 0 END AS is_initially_deferred
+    ,CASE WHEN constraints.validated = 'NOT VALIDATED' THEN 1 ELSE 0 END AS is_not_valid
+FROM
+    all_constraints constraints
+    JOIN all_tables tables
+        ON tables.owner = constraints.owner
+        AND tables.table_name = constraints.table_name
+    LEFT JOIN all_constraints ref_constraints
+        ON ref_constraints.owner = constraints.r_owner
+        AND ref_constraints.constraint_name = constraints.r_constraint_name
+WHERE
+    (
+        1 <> 1
+        {{- if .IncludeConstraintType "PRIMARY KEY" }}
+        OR constraints.constraint_type = 'P'
+        {{- end }}
+        {{- if .IncludeConstraintType "UNIQUE" }}
+        OR constraints.constraint_type = 'U'
+        {{- end }}
+        {{- if .IncludeConstraintType "FOREIGN KEY" }}
+        OR constraints.constraint_type = 'R'
+        {{- end }}
+        {{- if .IncludeConstraintType "CHECK" }}
+        -- exclude the NOT NULL checks that Oracle creates for NOT NULL columns
+        OR (constraints.constraint_type = 'C' AND NOT (constraints.generated = 'GENERATED NAME' AND constraints.search_condition_vc LIKE '% IS NOT NULL'))
+        {{- end }}
+    )
+    AND tables.dropped = 'NO' AND tables.nested = 'NO' AND tables.secondary = 'N'
+    {{- if not .IncludeSystemCatalogs }}
+    AND constraints.owner NOT IN (SELECT username FROM all_users WHERE oracle_maintained = 'Y')
+    {{- end }}
+    {{- if .Schemas }}
+    AND constraints.owner IN ({{ mklist .Schemas }})
+    {{- else if .ExcludeSchemas }}
+    AND constraints.owner NOT IN ({{ mklist .ExcludeSchemas }})
+    {{- end }}
+    {{- if .Tables }}
+    AND constraints.table_name IN ({{ mklist .Tables }})
+    {{- else if .ExcludeTables }}
+    AND constraints.table_name NOT IN ({{ mklist .ExcludeTables }})
+    {{- end }}
+ORDER BY
+    constraints.owner
+    ,constraints.table_name
+    ,constraints.constraint_name
@@ -0,0 +1,49 @@
+SELECT
+    indexes.table_owner AS table_schema
+    ,indexes.table_name
+    ,indexes.index_name
+    ,indexes.index_type
+    ,CASE WHEN indexes.table_type = 'VIEW' THEN 1 ELSE 0 END AS is_view_index
+    ,CASE WHEN indexes.uniqueness = 'UNIQUE' THEN 1 ELSE 0 END AS is_unique
+    ,LISTAGG(ind_columns.column_name, ',') WITHIN GROUP (ORDER BY ind_columns.column_position) AS columns
+    ,LISTAGG(CASE WHEN ind_columns.descend = 'DESC' THEN 1 ELSE 0 END, ',') WITHIN GROUP (ORDER BY ind_columns.column_position) AS descending
+FROM
+    all_indexes indexes
+    JOIN all_ind_columns ind_columns
+        ON ind_columns.index_owner = indexes.owner
+        AND ind_columns.index_name = indexes.index_name
+WHERE
+    indexes.index_type IN ('NORMAL', 'BITMAP')
+    AND indexes.generated = 'N'
+    AND NOT EXISTS (
+        -- exclude indexes created by constraints
+        SELECT 1
+        FROM all_constraints constraints
+        WHERE constraints.index_owner = indexes.owner
+        AND constraints.index_name = indexes.index_name
+        AND constraints.constraint_type IN ('P', 'U')
+    )
+    {{- if not .IncludeSystemCatalogs }}
+    AND indexes.table_owner NOT IN (SELECT username FROM all_users WHERE oracle_maintained = 'Y')
+    {{- end }}
+    {{- if .Schemas }}
+    AND indexes.table_owner IN ({{ mklist .Schemas }})
+    {{- else if .ExcludeSchemas }}
+    AND indexes.table_owner NOT IN ({{ mklist .ExcludeSchemas }})
+    {{- end }}
+    {{- if .Tables }}
+    AND indexes.table_name IN ({{ mklist .Tables }})
+    {{- else if .ExcludeTables }}
+    AND indexes.table_name NOT IN ({{ mklist .ExcludeTables }})
+    {{- end }}
+GROUP BY
+    indexes.table_owner
+    ,indexes.table_name
+    ,indexes.index_name
+    ,indexes.index_type
+    ,indexes.table_type
+    ,indexes.uniqueness
+ORDER BY
+    indexes.table_owner
+    ,indexes.table_name
+    ,indexes.index_name
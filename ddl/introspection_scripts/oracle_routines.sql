@@ -0,0 +1,25 @@
+SELECT
+    routines.owner AS routine_schema
+    ,routines.object_name AS routine_name
+    ,routines.object_type AS routine_type
+    ,DBMS_METADATA.GET_DDL(routines.object_type, routines.object_name, routines.owner) AS sql
+FROM
+    all_objects routines
+WHERE
+    routines.object_type IN ('FUNCTION', 'PROCEDURE')
+    {{- if not .IncludeSystemCatalogs }}
+    AND routines.owner NOT IN (SELECT username FROM all_users WHERE oracle_maintained = 'Y')
+    {{- end }}
+    {{- if .Schemas }}
+    AND routines.owner IN ({{ mklist .Schemas }})
+    {{- else if .ExcludeSchemas }}
+    AND routines.owner NOT IN ({{ mklist .ExcludeSchemas }})
+    {{- end }}
+    {{- if .Routines }}
+    AND routines.object_name IN ({{ mklist .Routines }})
+    {{- else if .ExcludeRoutines }}
+    AND routines.object_name NOT IN ({{ mklist .ExcludeRoutines }})
+    {{- end }}
+ORDER BY
+    routines.owner
+    ,routines.object_name
@@ -0,0 +1,33 @@
+SELECT
+    sequences.sequence_owner AS sequence_schema
+    ,sequences.sequence_name
+    ,sequences.increment_by
+    ,GREATEST(sequences.min_value, -9223372036854775808) AS min_value
+    ,LEAST(sequences.max_value, 9223372036854775807) AS max_value
+    ,sequences.cycle_flag
+    -- last_number is the next number that will be written to disk, so the
+    -- last value returned by the sequence is at most one increment behind it.
+    ,CASE
+        WHEN sequences.last_number - sequences.increment_by < sequences.min_value THEN 0
+        ELSE LEAST(sequences.last_number - sequences.increment_by, 9223372036854775807)
+    END AS last_value
+FROM
+    all_sequences sequences
+WHERE
+    sequences.sequence_name NOT LIKE 'ISEQ$$%' -- exclude the sequences backing identity columns
+    {{- if not .IncludeSystemCatalogs }}
+    AND sequences.sequence_owner NOT IN (SELECT username FROM all_users WHERE oracle_maintained = 'Y')
+    {{- end }}
+    {{- if .Schemas }}
+    AND sequences.sequence_owner IN ({{ mklist .Schemas }})
+    {{- else if .ExcludeSchemas }}
+    AND sequences.sequence_owner NOT IN ({{ mklist .ExcludeSchemas }})
+    {{- end }}
+    {{- if .Sequences }}
+    AND sequences.sequence_name IN ({{ mklist .Sequences }})
+    {{- else if .ExcludeSequences }}
+    AND sequences.sequence_name NOT IN ({{ mklist .ExcludeSequences }})
+    {{- end }}
+ORDER BY
+    sequences.sequence_owner
+    ,sequences.sequence_name
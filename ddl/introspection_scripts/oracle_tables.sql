@@ -0,0 +1,27 @@
+SELECT
+    tables.owner AS table_schema
+    ,tables.table_name
+    ,tab_comments.comments AS table_comment
+FROM
+    all_tables tables
+    LEFT JOIN all_tab_comments tab_comments
+        ON tab_comments.owner = tables.owner
+        AND tab_comments.table_name = tables.table_name
+WHERE
+    tables.dropped = 'NO' AND tables.nested = 'NO' AND tables.secondary = 'N'
+    {{- if not .IncludeSystemCatalogs }}
+    AND tables.owner NOT IN (SELECT username FROM all_users WHERE oracle_maintained = 'Y')
+    {{- end }}
+    {{- if .Schemas }}
+    AND tables.owner IN ({{ mklist .Schemas }})
+    {{- else if .ExcludeSchemas }}
+    AND tables.owner NOT IN ({{ mklist .ExcludeSchemas }})
+    {{- end }}
+    {{- if .Tables }}
+    AND tables.table_name IN ({{ mklist .Tables }})
+    {{- else if .ExcludeTables }}
+    AND tables.table_name NOT IN ({{ mklist .ExcludeTables }})
+    {{- end }}
+ORDER BY
+    tables.owner
+    ,tables.table_name
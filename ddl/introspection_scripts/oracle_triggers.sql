@@ -0,0 +1,32 @@
+SELECT
+    triggers.table_owner AS table_schema
+    ,triggers.table_name
+    ,triggers.trigger_name
+    ,CASE WHEN triggers.base_object_type = 'VIEW' THEN 1 ELSE 0 END AS is_view_trigger
+    ,DBMS_METADATA.GET_DDL('TRIGGER', triggers.trigger_name, triggers.owner) AS sql
+FROM
+    all_triggers triggers
+WHERE
+    triggers.base_object_type IN ('TABLE', 'VIEW')
+    {{- if not .IncludeSystemCatalogs }}
+    AND triggers.table_owner NOT IN (SELECT username FROM all_users WHERE oracle_maintained = 'Y')
+    {{- end }}
+    {{- if .Schemas }}
+    AND triggers.table_owner IN ({{ mklist .Schemas }})
+    {{- else if .ExcludeSchemas }}
+    AND triggers.table_owner NOT IN ({{ mklist .ExcludeSchemas }})
+    {{- end }}
+    {{- if .Tables }}
+    AND triggers.table_name IN ({{ mklist .Tables }})
+    {{- else if .ExcludeTables }}
+    AND triggers.table_name NOT IN ({{ mklist .ExcludeTables }})
+    {{- end }}
+    {{- if .Views }}
+    AND triggers.table_name IN ({{ mklist .Views }})
+    {{- else if .ExcludeViews }}
+    AND triggers.table_name NOT IN ({{ mklist .ExcludeViews }})
+    {{- end }}
+ORDER BY
+    triggers.table_owner
+    ,triggers.table_name
+    ,triggers.trigger_name
@@ -0,0 +1,37 @@
+SELECT
+    views.owner AS view_schema
+    ,views.view_name
+    ,DBMS_METADATA.GET_DDL('VIEW', views.view_name, views.owner) AS sql
+    ,view_columns.names AS column_names
+    ,view_columns.types AS column_types
+FROM
+    all_views views
+    JOIN (
+        SELECT
+            owner
+            ,table_name
+            ,LISTAGG(column_name, '|') WITHIN GROUP (ORDER BY column_id) AS names
+            ,LISTAGG(data_type, '|') WITHIN GROUP (ORDER BY column_id) AS types
+        FROM all_tab_columns
+        GROUP BY owner, table_name
+    ) view_columns
+        ON view_columns.owner = views.owner
+        AND view_columns.table_name = views.view_name
+WHERE
+    1 = 1
+    {{- if not .IncludeSystemCatalogs }}
+    AND views.owner NOT IN (SELECT username FROM all_users WHERE oracle_maintained = 'Y')
+    {{- end }}
+    {{- if .Schemas }}
+    AND views.owner IN ({{ mklist .Schemas }})
+    {{- else if .ExcludeSchemas }}
+    AND views.owner NOT IN ({{ mklist .ExcludeSchemas }})
+    {{- end }}
+    {{- if .Views }}
+    AND views.view_name IN ({{ mklist .Views }})
+    {{- else if .ExcludeViews }}
+    AND views.view_name NOT IN ({{ mklist .ExcludeViews }})
+    {{- end }}
+ORDER BY
+    views.owner
+    ,views.view_name
@@ -24,6 +24,8 @@ SELECT
         WHEN 'a' THEN 'GENERATED ALWAYS AS IDENTITY'
         ELSE ''
     END AS column_identity
+    ,COALESCE(identity_sequence.seqstart, 0) AS identity_start
+    ,COALESCE(identity_sequence.seqincrement, 0) AS identity_increment
     ,columns.attnotnull AS is_notnull
     ,CASE columns.attgenerated
         WHEN 's' THEN COALESCE(pg_get_expr(pg_attrdef.adbin, pg_attrdef.adrelid, TRUE), '')
@@ -41,10 +43,14 @@ SELECT
     ,COALESCE(col_description(columns.attrelid, columns.attnum), '') AS comment
 FROM
     pg_attribute AS columns
-    JOIN pg_class AS tables ON tables.relkind = 'r' AND tables.oid = columns.attrelid
+    JOIN pg_class AS tables ON tables.relkind IN ('r', 'p') AND NOT tables.relispartition AND tables.oid = columns.attrelid
     JOIN pg_namespace AS schemas ON schemas.oid = tables.relnamespace
     LEFT JOIN pg_attrdef ON pg_attrdef.adrelid = tables.oid AND pg_attrdef.adnum = columns.attnum
     LEFT JOIN pg_collation ON pg_collation.oid = columns.attcollation
+    LEFT JOIN (
+        pg_depend AS identity_depend
+        JOIN pg_sequence AS identity_sequence ON identity_sequence.seqrelid = identity_depend.objid
+    ) ON identity_depend.refobjid = tables.oid AND identity_depend.refobjsubid = columns.attnum AND identity_depend.deptype = 'i'
     LEFT JOIN pg_type AS enum ON enum.typtype = 'e' AND enum.oid = columns.atttypid
     LEFT JOIN pg_type AS domain_type ON domain_type.oid = columns.atttypid AND domain_type.typtype = 'd'
     LEFT JOIN pg_type AS domain_base_type ON domain_base_type.oid = domain_type.typbasetype
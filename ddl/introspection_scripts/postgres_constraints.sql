@@ -57,6 +57,7 @@ FROM (
         JOIN pg_attribute AS columns ON columns.attrelid = pg_constraint.conrelid AND columns.attnum = c.oid
     WHERE
         pg_constraint.contype = 'p'
+        AND NOT tables.relispartition -- partitions are introspected separately
         {{- if not .IncludeSystemCatalogs }}
         AND schemas.nspname <> 'information_schema' AND schemas.nspname NOT LIKE 'pg_%'
         {{- end }}
@@ -116,6 +117,7 @@ FROM (
         JOIN pg_attribute AS columns ON columns.attrelid = pg_constraint.conrelid AND columns.attnum = c.oid
     WHERE
         pg_constraint.contype = 'u'
+        AND NOT tables.relispartition -- partitions are introspected separately
         {{- if not .IncludeSystemCatalogs }}
         AND schemas.nspname <> 'information_schema' AND schemas.nspname NOT LIKE 'pg_%'
         {{- end }}
@@ -202,6 +204,8 @@ FROM (
         JOIN pg_attribute AS columns2 ON columns2.attrelid = pg_constraint.confrelid AND columns2.attnum = c2.oid
     WHERE
         pg_constraint.contype = 'f'
+        AND NOT tables1.relispartition -- partitions are introspected separately
+        AND pg_constraint.conparentid = 0 -- exclude foreign keys cloned onto partitions
         {{- if not .IncludeSystemCatalogs }}
         AND schemas1.nspname <> 'information_schema' AND schemas1.nspname NOT LIKE 'pg_%'
         {{- end }}
@@ -256,6 +260,7 @@ FROM
     JOIN pg_namespace AS schemas ON schemas.oid = tables.relnamespace
 WHERE
     pg_constraint.contype = 'c'
+    AND NOT tables.relispartition -- partitions are introspected separately
     {{- if not .IncludeSystemCatalogs }}
     AND schemas.nspname <> 'information_schema' AND schemas.nspname NOT LIKE 'pg_%'
     {{- end }}
@@ -317,6 +322,7 @@ FROM (
         JOIN pg_operator ON pg_operator.oid = o.oid
     WHERE
         pg_constraint.contype = 'x'
+        AND NOT tables.relispartition -- partitions are introspected separately
         {{- if not .IncludeSystemCatalogs }}
         AND schemas.nspname <> 'information_schema' AND schemas.nspname NOT LIKE 'pg_%'
         {{- end }}
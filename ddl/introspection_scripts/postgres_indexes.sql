@@ -8,6 +8,7 @@ SELECT
     ,num_key_columns
     ,json_agg(column_name ORDER BY seq) AS columns
     ,json_agg(opclass ORDER BY seq) AS opclasses
+    ,json_agg(is_descending ORDER BY seq) AS descending
     ,COALESCE(pg_get_expr(predicate_oid, table_oid, TRUE), '') AS predicate
     ,pg_get_indexdef(index_oid, 0, TRUE) || ';' AS sql
 FROM (
@@ -21,6 +22,7 @@ FROM (
         ,pg_index.indnkeyatts AS num_key_columns
         ,pg_get_indexdef(indexes.oid, c.seq::INT, TRUE) AS column_name
         ,pg_opclass.opcname AS opclass
+        ,COALESCE((pg_index.indoption[c.seq::INT - 1]::INT & 1) = 1, FALSE) AS is_descending
         ,pg_index.indexrelid AS index_oid
         ,pg_index.indrelid AS table_oid
         ,pg_index.indpred AS predicate_oid
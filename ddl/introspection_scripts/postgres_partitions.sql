@@ -0,0 +1,32 @@
+SELECT
+    schemas.nspname AS table_schema
+    ,tables.relname AS table_name
+    ,partitions.relname AS partition_name
+    ,COALESCE(pg_get_expr(partitions.relpartbound, partitions.oid, TRUE), '') AS partition_bound
+FROM
+    pg_inherits
+    JOIN pg_class AS tables ON tables.oid = pg_inherits.inhparent
+    JOIN pg_class AS partitions ON partitions.oid = pg_inherits.inhrelid
+    JOIN pg_namespace AS schemas ON schemas.oid = tables.relnamespace
+WHERE
+    tables.relkind = 'p'
+    AND NOT tables.relispartition -- exclude sub-partitions
+    AND partitions.relispartition
+    {{- if not .IncludeSystemCatalogs }}
+    AND schemas.nspname <> 'information_schema' AND schemas.nspname NOT LIKE 'pg_%'
+    {{- end }}
+    {{- if .Schemas }}
+    AND schemas.nspname IN ({{ mklist .Schemas }})
+    {{- else if .ExcludeSchemas }}
+    AND schemas.nspname NOT IN ({{ mklist .ExcludeSchemas }})
+    {{- end }}
+    {{- if .Tables }}
+    AND tables.relname IN ({{ mklist .Tables }})
+    {{- else if .ExcludeTables }}
+    AND tables.relname NOT IN ({{ mklist .ExcludeTables }})
+    {{- end }}
+ORDER BY
+    schemas.nspname
+    ,tables.relname
+    ,partitions.relname
+;
@@ -0,0 +1,50 @@
+SELECT
+    schemas.nspname AS sequence_schema
+    ,sequences.relname AS sequence_name
+    ,format_type(pg_sequence.seqtypid, NULL) AS data_type
+    ,pg_sequence.seqstart AS start_value
+    ,pg_sequence.seqincrement AS increment_by
+    ,pg_sequence.seqmin AS min_value
+    ,pg_sequence.seqmax AS max_value
+    ,pg_sequence.seqcycle AS is_cycle
+    ,COALESCE(tables.relname, '') AS owned_by_table
+    ,COALESCE(columns.attname, '') AS owned_by_column
+    ,COALESCE(pg_sequences.last_value, 0) AS last_value
+    ,COALESCE(obj_description(sequences.oid, 'pg_class'), '') AS sequence_comment
+FROM
+    pg_class AS sequences
+    JOIN pg_namespace AS schemas ON schemas.oid = sequences.relnamespace
+    JOIN pg_sequence ON pg_sequence.seqrelid = sequences.oid
+    LEFT JOIN pg_sequences ON pg_sequences.schemaname = schemas.nspname AND pg_sequences.sequencename = sequences.relname
+    LEFT JOIN pg_depend
+        ON pg_depend.classid = 'pg_class'::regclass
+        AND pg_depend.objid = sequences.oid
+        AND pg_depend.refclassid = 'pg_class'::regclass
+        AND pg_depend.deptype = 'a'
+    LEFT JOIN pg_class AS tables ON tables.oid = pg_depend.refobjid
+    LEFT JOIN pg_attribute AS columns ON columns.attrelid = pg_depend.refobjid AND columns.attnum = pg_depend.refobjsubid
+WHERE
+    sequences.relkind = 'S'
+    -- exclude the sequences backing identity columns
+    AND NOT EXISTS (
+        SELECT 1
+        FROM pg_depend
+        WHERE pg_depend.classid = 'pg_class'::regclass AND pg_depend.objid = sequences.oid AND pg_depend.deptype = 'i'
+    )
+    {{- if not .IncludeSystemCatalogs }}
+    AND schemas.nspname <> 'information_schema' AND schemas.nspname NOT LIKE 'pg_%'
+    {{- end }}
+    {{- if .Schemas }}
+    AND schemas.nspname IN ({{ mklist .Schemas }})
+    {{- else if .ExcludeSchemas }}
+    AND schemas.nspname NOT IN ({{ mklist .ExcludeSchemas }})
+    {{- end }}
+    {{- if .Sequences }}
+    AND sequences.relname IN ({{ mklist .Sequences }})
+    {{- else if .ExcludeSequences }}
+    AND sequences.relname NOT IN ({{ mklist .ExcludeSequences }})
+    {{- end }}
+ORDER BY
+    schemas.nspname
+    ,sequences.relname
+;
@@ -2,12 +2,19 @@ SELECT
     schemas.nspname AS table_schema
     ,tables.relname AS table_name
     ,COALESCE(pg_description.description, '') AS table_comment
+    ,CASE tables.relkind WHEN 'p' THEN pg_get_partkeydef(tables.oid) ELSE '' END AS partition_key
+    ,tables.relpersistence = 'u' AS is_unlogged
+    ,COALESCE(array_to_string(tables.reloptions, ','), '') AS storage_params
 FROM
     pg_class AS tables
     JOIN pg_namespace AS schemas ON schemas.oid = tables.relnamespace
-    LEFT JOIN pg_description ON pg_description.objoid = tables.oid
+    LEFT JOIN pg_description
+        ON pg_description.objoid = tables.oid
+        AND pg_description.classoid = 'pg_class'::regclass
+        AND pg_description.objsubid = 0
 WHERE
-    tables.relkind = 'r'
+    tables.relkind IN ('r', 'p') -- 'r' = ordinary table, 'p' = partitioned table
+    AND NOT tables.relispartition -- partitions are introspected separately
     {{- if not .IncludeSystemCatalogs }}
     AND schemas.nspname <> 'information_schema' AND schemas.nspname NOT LIKE 'pg_%'
     {{- end }}
@@ -12,14 +12,9 @@ SELECT
         WHEN columns.system_type_id IN (40, 41, 42, 43, 58, 61) THEN ''
         ELSE COALESCE(ODBCSCALE(columns.system_type_id, columns.scale), '')
     END AS numeric_scale
-    ,CASE
-        WHEN columns.is_identity = 1
-            THEN CASE
-                WHEN identity_columns.seed_value = 1 AND identity_columns.increment_value = 1 THEN 'IDENTITY'
-                ELSE CONCAT('IDENTITY(', CAST(identity_columns.seed_value AS VARCHAR(255)), ', ', CAST(identity_columns.increment_value AS VARCHAR(255)), ')')
-            END
-        ELSE ''
-    END AS column_identity
+    ,CASE WHEN columns.is_identity = 1 THEN 'IDENTITY' ELSE '' END AS column_identity
+    ,COALESCE(CAST(identity_columns.seed_value AS BIGINT), 0) AS identity_start
+    ,COALESCE(CAST(identity_columns.increment_value AS BIGINT), 0) AS identity_increment
     ,CASE WHEN columns.is_nullable = 1 THEN 0 ELSE 1 END AS is_notnull
     ,COALESCE(computed_columns.definition, '') AS generated_expr
     ,COALESCE(computed_columns.is_persisted, 0) AS generated_expr_stored
@@ -28,6 +23,7 @@ SELECT
         ELSE COALESCE(columns.collation_name, '')
     END AS collation_name
     ,COALESCE(OBJECT_DEFINITION(columns.default_object_id), '') AS column_default
+    ,COALESCE(CAST(extended_properties.value AS NVARCHAR(MAX)), '') AS comment
 FROM
     sys.columns
     JOIN sys.tables ON tables.object_id = columns.object_id
@@ -39,6 +35,11 @@ FROM
     LEFT JOIN sys.computed_columns
         ON computed_columns.object_id = columns.object_id
         AND computed_columns.column_id = columns.column_id
+    LEFT JOIN sys.extended_properties
+        ON extended_properties.class = 1
+        AND extended_properties.major_id = columns.object_id
+        AND extended_properties.minor_id = columns.column_id
+        AND extended_properties.name = 'MS_Description'
 WHERE
     tables.type = 'U' -- User-defined table (https://stackoverflow.com/a/2907204)
     {{- if not .IncludeSystemCatalogs }}
@@ -0,0 +1,39 @@
+SELECT
+    schemas.name AS sequence_schema
+    ,sequences.name AS sequence_name
+    ,TYPE_NAME(sequences.system_type_id) AS data_type
+    ,CAST(sequences.start_value AS BIGINT) AS start_value
+    ,CAST(sequences.increment AS BIGINT) AS increment_by
+    ,CAST(sequences.minimum_value AS BIGINT) AS min_value
+    ,CAST(sequences.maximum_value AS BIGINT) AS max_value
+    ,sequences.is_cycling AS is_cycle
+    {{- if .VersionNums.LowerThan 14 }}
+    ,CAST(0 AS BIGINT) AS last_value
+    {{- else }}
+    ,COALESCE(CAST(sequences.last_used_value AS BIGINT), 0) AS last_value
+    {{- end }}
+    ,COALESCE(CAST(extended_properties.value AS NVARCHAR(MAX)), '') AS sequence_comment
+FROM
+    sys.sequences
+    JOIN sys.schemas ON schemas.schema_id = sequences.schema_id
+    LEFT JOIN sys.extended_properties
+        ON extended_properties.class = 1
+        AND extended_properties.major_id = sequences.object_id
+        AND extended_properties.minor_id = 0
+        AND extended_properties.name = 'MS_Description'
+WHERE
+    1 = 1
+    {{- if .Schemas }}
+    AND schemas.name IN ({{ mklist .Schemas }})
+    {{- else if .ExcludeSchemas }}
+    AND schemas.name NOT IN ({{ mklist .ExcludeSchemas }})
+    {{- end }}
+    {{- if .Sequences }}
+    AND sequences.name IN ({{ mklist .Sequences }})
+    {{- else if .ExcludeSequences }}
+    AND sequences.name NOT IN ({{ mklist .ExcludeSequences }})
+    {{- end }}
+ORDER BY
+    schemas.name
+    ,sequences.name
+;
@@ -1,9 +1,15 @@
 SELECT
     schemas.name AS table_schema
     ,tables.name AS table_name
+    ,COALESCE(CAST(extended_properties.value AS NVARCHAR(MAX)), '') AS table_comment
 FROM
     sys.objects AS tables
     JOIN sys.schemas ON schemas.schema_id = tables.schema_id
+    LEFT JOIN sys.extended_properties
+        ON extended_properties.class = 1
+        AND extended_properties.major_id = tables.object_id
+        AND extended_properties.minor_id = 0
+        AND extended_properties.name = 'MS_Description'
 WHERE
     tables.type = 'U' -- User-defined table (https://stackoverflow.com/a/2907204)
     {{- if not .IncludeSystemCatalogs }}
@@ -77,6 +77,12 @@ type LoadCmd struct {
 	// TIMESTAMP, DATETIME and DATE columns.
 	TimestampAsInteger bool
 
+	// Validate checks schema.sql files with an SQLParser before they are
+	// loaded, so that a schema.sql file with syntax errors or references to
+	// missing tables or columns is rejected before any of its statements are
+	// run against the database.
+	Validate bool
+
 	// Ctx is the command's context.
 	Ctx context.Context
 
@@ -111,6 +117,7 @@ func LoadCommand(args ...string) (*LoadCmd, error) {
 	flagset.BoolVar(&cmd.NoBinaryprefix, "no-binaryprefix", false, "Do not recognize any binaryprefix when loading CSV files (you will be unable to insert BLOB values).")
 	flagset.BoolVar(&cmd.Verbose, "verbose", false, "Log start and end timestamps for each file loaded.")
 	flagset.BoolVar(&cmd.TimestampAsInteger, "timestamp-as-integer", false, "(SQLite only) Load timestamp strings as unix timestamp integers for TIMESTAMP, DATETIME and DATE columns.")
+	flagset.BoolVar(&cmd.Validate, "validate", false, "Check schema.sql files for errors before loading them.")
 	flagset.Usage = func() {
 		fmt.Fprint(flagset.Output(), `Usage:
   sqddl load -db <DATABASE_URL> [FLAGS] [FILENAMES...]
@@ -287,6 +294,12 @@ func (cmd *LoadCmd) loadSQL(conn *sql.Conn, filename string, r io.Reader) error
 	if buf.Len() == 0 {
 		return nil
 	}
+	if cmd.Validate && filepath.Base(filename) == "schema.sql" {
+		err = validateSchema(cmd.Dialect, buf.Bytes())
+		if err != nil {
+			return err
+		}
+	}
 	if cmd.Verbose {
 		fmt.Fprintln(cmd.Stderr, timestamp()+"[START] "+filename)
 	}
@@ -322,6 +335,21 @@ func (cmd *LoadCmd) loadSQL(conn *sql.Conn, filename string, r io.Reader) error
 	return nil
 }
 
+// validateSchema parses the contents of a schema.sql file and reports any
+// errors found.
+func validateSchema(dialect string, src []byte) error {
+	catalog := &Catalog{Dialect: dialect}
+	switch dialect {
+	case DialectPostgres:
+		catalog.CurrentSchema = "public"
+	case DialectSQLServer:
+		catalog.CurrentSchema = "dbo"
+	}
+	p := NewSQLParser(nil)
+	p.addFile("", src)
+	return p.WriteCatalog(catalog)
+}
+
 // startsWithNumber is used to trim leading digits from a csv filename (in
 // order to determine the name of the table).
 var startsWithNumber = regexp.MustCompile(`^\d*_`)
@@ -540,7 +568,8 @@ func (cmd *LoadCmd) loadCSV(ctx context.Context, tx *sql.Tx, filename string, r
 }
 
 // loadZip loads a .zip file into the database. The order of files loaded goes
-// like this: schema.sql -> CSV files -> indexes.sql -> constraints.sql.
+// like this: schema.sql -> CSV files -> sequences.sql -> indexes.sql ->
+// constraints.sql.
 func (cmd *LoadCmd) loadZip(conn *sql.Conn, zipName string) error {
 	// Sanity check in case someone tries to load a zip file from an embed.FS.
 	// This is potentially a common mistake, so we specially flag it out with a
@@ -591,9 +620,9 @@ func (cmd *LoadCmd) loadZip(conn *sql.Conn, zipName string) error {
 	// dump.zip/dump/schema.sql as top level files.
 	name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(zipName), "./"), ".zip")
 
-	// Locate schema.sql, indexes.sql and constraints.sql.
-	var schemaFilename, indexesFilename, constraintsFilename string
-	var schemaFile, indexesFile, constraintsFile *zip.File
+	// Locate schema.sql, sequences.sql, indexes.sql and constraints.sql.
+	var schemaFilename, sequencesFilename, indexesFilename, constraintsFilename string
+	var schemaFile, sequencesFile, indexesFile, constraintsFile *zip.File
 	for _, f := range zipReader.File {
 		if strings.HasPrefix(f.Name, "__MACOSX/") {
 			// https://superuser.com/questions/104500/what-is-macosx-folder
@@ -603,6 +632,9 @@ func (cmd *LoadCmd) loadZip(conn *sql.Conn, zipName string) error {
 		case "schema.sql", name + "/schema.sql":
 			schemaFilename = f.Name
 			schemaFile = f
+		case "sequences.sql", name + "/sequences.sql":
+			sequencesFilename = f.Name
+			sequencesFile = f
 		case "indexes.sql", name + "/indexes.sql":
 			indexesFilename = f.Name
 			indexesFile = f
@@ -610,7 +642,7 @@ func (cmd *LoadCmd) loadZip(conn *sql.Conn, zipName string) error {
 			constraintsFilename = f.Name
 			constraintsFile = f
 		}
-		if schemaFile != nil && indexesFile != nil && constraintsFile != nil {
+		if schemaFile != nil && sequencesFile != nil && indexesFile != nil && constraintsFile != nil {
 			break
 		}
 	}
@@ -688,6 +720,19 @@ func (cmd *LoadCmd) loadZip(conn *sql.Conn, zipName string) error {
 		}
 	}
 
+	// Run sequences.sql if it exists.
+	if sequencesFile != nil {
+		contents, err := sequencesFile.Open()
+		if err != nil {
+			return err
+		}
+		filename := filepath.ToSlash(filepath.Join(zipName, sequencesFilename))
+		err = cmd.loadSQL(conn, filename, contents)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+	}
+
 	// Run indexes.sql if it exists.
 	if indexesFile != nil {
 		contents, err := indexesFile.Open()
@@ -717,14 +762,19 @@ func (cmd *LoadCmd) loadZip(conn *sql.Conn, zipName string) error {
 }
 
 // loadTgz loads a .tgz or .tar.gz file into the database. The order of files
-// loaded goes like this: schema.sql -> CSV files -> indexes.sql ->
-// constraints.sql.
+// loaded goes like this: schema.sql -> CSV files -> sequences.sql ->
+// indexes.sql -> constraints.sql.
 func (cmd *LoadCmd) loadTgz(conn *sql.Conn, tgzName string) error {
 	// schemaBuf
 	var schemaFilename string
 	schemaBuf := bufpool.Get().(*bytes.Buffer)
 	schemaBuf.Reset()
 	defer bufpool.Put(schemaBuf)
+	// sequencesBuf
+	var sequencesFilename string
+	sequencesBuf := bufpool.Get().(*bytes.Buffer)
+	sequencesBuf.Reset()
+	defer bufpool.Put(sequencesBuf)
 	// indexesBuf
 	var indexesFilename string
 	indexesBuf := bufpool.Get().(*bytes.Buffer)
@@ -777,8 +827,8 @@ func (cmd *LoadCmd) loadTgz(conn *sql.Conn, tgzName string) error {
 	}
 
 	// Open the tgz file. This first pass is solely dedicated to locating and
-	// storing the contents of schema.sql, indexes.sql and constraints.sql into
-	// memory. This is to get around the limitation that tgz files can only be
+	// storing the contents of schema.sql, sequences.sql, indexes.sql and
+	// constraints.sql into memory. This is to get around the limitation that tgz files can only be
 	// streamed (random access is not supported).
 	for {
 		hdr, err := tarReader.Next()
@@ -795,6 +845,12 @@ func (cmd *LoadCmd) loadTgz(conn *sql.Conn, tgzName string) error {
 			if err != nil {
 				return err
 			}
+		case "sequences.sql", name + "/sequences.sql":
+			sequencesFilename = hdr.Name
+			_, err = sequencesBuf.ReadFrom(tarReader)
+			if err != nil {
+				return err
+			}
 		case "indexes.sql", name + "/indexes.sql":
 			indexesFilename = hdr.Name
 			_, err = indexesBuf.ReadFrom(tarReader)
@@ -808,7 +864,7 @@ func (cmd *LoadCmd) loadTgz(conn *sql.Conn, tgzName string) error {
 				return err
 			}
 		}
-		if schemaBuf.Len() > 0 && indexesBuf.Len() > 0 && constraintsBuf.Len() > 0 {
+		if schemaBuf.Len() > 0 && sequencesBuf.Len() > 0 && indexesBuf.Len() > 0 && constraintsBuf.Len() > 0 {
 			break
 		}
 	}
@@ -865,6 +921,15 @@ func (cmd *LoadCmd) loadTgz(conn *sql.Conn, tgzName string) error {
 		}
 	}
 
+	// Run sequences.sql if it exists.
+	if sequencesBuf.Len() > 0 {
+		filename := filepath.ToSlash(filepath.Join(tgzName, sequencesFilename))
+		err = cmd.loadSQL(conn, filename, sequencesBuf)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+	}
+
 	// Run indexes.sql if it exists.
 	if indexesBuf.Len() > 0 {
 		filename := filepath.ToSlash(filepath.Join(tgzName, indexesFilename))
@@ -886,7 +951,8 @@ func (cmd *LoadCmd) loadTgz(conn *sql.Conn, tgzName string) error {
 }
 
 // loadDir loads a directory into the database. The order of files loaded goes
-// like this: schema.sql -> csv files -> indexes.sql -> constraints.sql.
+// like this: schema.sql -> csv files -> sequences.sql -> indexes.sql ->
+// constraints.sql.
 func (cmd *LoadCmd) loadDir(conn *sql.Conn, dirname string) error {
 	// Run schema.sql if it exists.
 	schemaFilename := filepath.ToSlash(filepath.Join(dirname, "schema.sql"))
@@ -966,6 +1032,19 @@ func (cmd *LoadCmd) loadDir(conn *sql.Conn, dirname string) error {
 		}
 	}
 
+	// Run sequences.sql if it exists.
+	sequencesFilename := filepath.ToSlash(filepath.Join(dirname, "sequences.sql"))
+	sequencesFile, err := cmd.DirFS.Open(sequencesFilename)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		err = cmd.loadSQL(conn, sequencesFilename, sequencesFile)
+		if err != nil {
+			return fmt.Errorf("%s: %w", sequencesFilename, err)
+		}
+	}
+
 	// Run indexes.sql if it exists.
 	indexesFilename := filepath.ToSlash(filepath.Join(dirname, "indexes.sql"))
 	indexesFile, err := cmd.DirFS.Open(indexesFilename)
@@ -6,7 +6,9 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/bokwoon95/sqddl/internal/testutil"
 )
@@ -137,6 +139,49 @@ func TestLoadCmd(t *testing.T) {
 		}
 	})
 
+	t.Run("schema validation", func(t *testing.T) {
+		t.Parallel()
+		db, err := sql.Open("sqlite3", "file:/"+t.Name()+".db?vfs=memdb&_foreign_keys=true")
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		defer db.Close()
+		fsys := fstest.MapFS{
+			"schema.sql": {Data: []byte("CREATE TABLE film (film_id INT PRIMARY KEY);\n" +
+				"CREATE TABLE film_actor (film_id INT REFERENCES film (film_id), actor_id INT REFERENCES actor (actor_id));"),
+			},
+		}
+		loadCmd := &LoadCmd{
+			DB:        db,
+			Dialect:   "sqlite",
+			DirFS:     fsys,
+			Filenames: []string{"schema.sql"},
+			Validate:  true,
+			Stderr:    io.Discard,
+		}
+		err = loadCmd.Run()
+		if err == nil {
+			t.Fatal(testutil.Callers(), "expected validation error, got nil")
+		}
+		if !strings.Contains(err.Error(), "table actor does not exist") {
+			t.Errorf(testutil.Callers()+" unexpected error: %v", err)
+		}
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM sqlite_schema WHERE type = 'table' AND name = 'film'").Scan(&count)
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		if count != 0 {
+			t.Error(testutil.Callers(), "schema.sql was loaded despite failing validation")
+		}
+		// Validation is opt-in.
+		loadCmd.Validate = false
+		err = loadCmd.Run()
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+	})
+
 	assertOrderedCSV := func(t *testing.T, db *sql.DB) {
 		wantA := []int{1, 2, 3}
 		var gotA []int
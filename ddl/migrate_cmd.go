@@ -216,8 +216,24 @@ func (cmd *MigrateCmd) Run() error {
 		if err != nil {
 			return err
 		}
+		hasBatch := false
+		for _, filename := range cmd.Filenames {
+			if strings.HasSuffix(filename, ".batch.sql") {
+				hasBatch = true
+				break
+			}
+		}
+		if hasBatch {
+			err = ensureHistoryTableBatchKey(cmd.Dialect, cmd.DB, cmd.HistoryTable)
+			if err != nil {
+				return err
+			}
+		}
 		var b strings.Builder
 		b.WriteString("SELECT filename, checksum, started_at, time_taken_ns, success")
+		if hasBatch {
+			b.WriteString(", batch_key")
+		}
 		b.WriteString(" FROM " + QuoteIdentifier(cmd.Dialect, cmd.HistoryTable))
 		b.WriteString(" WHERE filename IN (")
 		for i, filename := range cmd.Filenames {
@@ -237,7 +253,12 @@ func (cmd *MigrateCmd) Run() error {
 			var startedAt sql.NullTime
 			var timeTakenNs sql.NullInt64
 			var success sql.NullBool
-			err := rows.Scan(&filename, &checksum, &startedAt, &timeTakenNs, &success)
+			var batchKey sql.NullString
+			dest := []any{&filename, &checksum, &startedAt, &timeTakenNs, &success}
+			if hasBatch {
+				dest = append(dest, &batchKey)
+			}
+			err := rows.Scan(dest...)
 			if err != nil {
 				return err
 			}
@@ -249,6 +270,7 @@ func (cmd *MigrateCmd) Run() error {
 					startedAt:   startedAt,
 					timeTakenNs: timeTakenNs,
 					success:     success.Bool,
+					batchKey:    batchKey,
 				}
 			}
 		}
@@ -307,8 +329,10 @@ func (cmd *MigrateCmd) Run() error {
 		if cmd.Dialect == DialectMySQL ||
 			strings.HasSuffix(queue[0].filename, ".tx.sql") ||
 			strings.HasSuffix(queue[0].filename, ".txoff.sql") ||
+			strings.HasSuffix(queue[0].filename, ".batch.sql") ||
 			strings.HasSuffix(m.filename, ".tx.sql") ||
-			strings.HasSuffix(m.filename, ".txoff.sql") {
+			strings.HasSuffix(m.filename, ".txoff.sql") ||
+			strings.HasSuffix(m.filename, ".batch.sql") {
 			err = cmd.runWithRetry(conn, queue)
 			if err != nil {
 				return err
@@ -329,12 +353,13 @@ func (cmd *MigrateCmd) Run() error {
 }
 
 type migration struct {
-	valid       bool          // Indicates whether the migration is in the history table.
-	filename    string        // Migration filename.
-	checksum    string        // Migration file checksum.
-	startedAt   sql.NullTime  // When the migration started at.
-	timeTakenNs sql.NullInt64 // How long the migration took (in nanoseconds).
-	success     bool          // Whether the migration was successful.
+	valid       bool           // Indicates whether the migration is in the history table.
+	filename    string         // Migration filename.
+	checksum    string         // Migration file checksum.
+	startedAt   sql.NullTime   // When the migration started at.
+	timeTakenNs sql.NullInt64  // How long the migration took (in nanoseconds).
+	success     bool           // Whether the migration was successful.
+	batchKey    sql.NullString // Last key processed by a batch migration.
 }
 
 // https://www.reddit.com/r/golang/comments/ntyi7i/what_is_the_reason_go_chose_to_use_a_constant_as/h0w0tu7/
@@ -362,6 +387,9 @@ func (migrationErr *MigrationError) Unwrap() error {
 }
 
 func (cmd *MigrateCmd) runWithRetry(conn *sql.Conn, queue []migration) error {
+	if strings.HasSuffix(queue[0].filename, ".batch.sql") {
+		return cmd.runBatch(conn, queue[0])
+	}
 	isTx := len(queue) > 1 || (!strings.HasSuffix(queue[0].filename, ".txoff.sql") && cmd.Dialect != DialectMySQL) || strings.HasSuffix(queue[0].filename, ".tx.sql")
 	isStmt := false
 	if !isTx && len(queue) == 1 {
@@ -393,13 +421,7 @@ func (cmd *MigrateCmd) runWithRetry(conn *sql.Conn, queue []migration) error {
 				fmt.Fprintf(cmd.Stderr, queue[stoppedAt].filename+": attempt %d/%d timed out\n", attempts, cmd.MaxAttempts)
 				return fmt.Errorf("%s: %w", queue[stoppedAt].filename, migrationErr)
 			}
-			multiplier := int(math.Exp2(float64(attempts)))
-			delay := time.Duration(rng.Intn(int(cmd.BaseDelay*time.Duration(multiplier)/time.Second))) * time.Second
-			if delay < cmd.BaseDelay {
-				delay = cmd.BaseDelay
-			} else if delay > cmd.MaxDelay {
-				delay = cmd.MaxDelay
-			}
+			delay := cmd.retryDelay(attempts)
 			fmt.Fprintf(cmd.Stderr, queue[stoppedAt].filename+": attempt %d/%d timed out, retrying in %s\n", attempts, cmd.MaxAttempts, delay.String())
 			time.Sleep(delay)
 			continue
@@ -446,6 +468,20 @@ func (cmd *MigrateCmd) runWithRetry(conn *sql.Conn, queue []migration) error {
 	}
 }
 
+// retryDelay returns how long to wait before retrying a migration that timed
+// out on its nth attempt (exponential backoff with jitter, bounded by
+// BaseDelay and MaxDelay).
+func (cmd *MigrateCmd) retryDelay(attempts int) time.Duration {
+	multiplier := int(math.Exp2(float64(attempts)))
+	delay := time.Duration(rng.Intn(int(cmd.BaseDelay*time.Duration(multiplier)/time.Second))) * time.Second
+	if delay < cmd.BaseDelay {
+		delay = cmd.BaseDelay
+	} else if delay > cmd.MaxDelay {
+		delay = cmd.MaxDelay
+	}
+	return delay
+}
+
 func (cmd *MigrateCmd) run(conn *sql.Conn, migrations []migration) (stoppedAt int, err error) {
 	var tx *sql.Tx
 	if len(migrations) > 1 || (!strings.HasSuffix(migrations[0].filename, ".txoff.sql") && cmd.Dialect != DialectMySQL) || strings.HasSuffix(migrations[0].filename, ".tx.sql") {
@@ -603,3 +639,311 @@ func (cmd *MigrateCmd) undo(conn *sql.Conn, m migration, originalErr error) erro
 	}
 	return originalErr
 }
+
+// batchMigration is a *.batch.sql migration. It is made up of a header that
+// declares which table and key column to batch on, followed by a single
+// statement that is run once for every chunk of rows. E.g.
+//
+//	-- sqddl:batch table=film key=film_id size=1000 sleep=100ms
+//	UPDATE film SET title_search = LOWER(title) WHERE film_id BETWEEN {start_key} AND {end_key};
+//
+// {start_key} and {end_key} are replaced by the first and last key of each
+// chunk.
+type batchMigration struct {
+	tableName string        // Table to batch on.
+	keyColumn string        // Column to batch on, it must be unique.
+	size      int           // Number of rows per chunk.
+	sleep     time.Duration // How long to sleep between chunks.
+	query     string        // Statement with the placeholders filled in.
+	keyArgs   []bool        // For each placeholder, whether it is the end key.
+}
+
+// parseBatchMigration parses the contents of a *.batch.sql migration.
+func parseBatchMigration(dialect, contents string) (*batchMigration, error) {
+	contents = strings.TrimSpace(strings.ReplaceAll(contents, "\r\n", "\n"))
+	header, query, _ := strings.Cut(contents, "\n")
+	if !strings.HasPrefix(header, "-- sqddl:batch ") {
+		return nil, fmt.Errorf("batch migration does not start with a \"-- sqddl:batch\" header")
+	}
+	batch := &batchMigration{size: 1000}
+	modifiers, err := NewModifiers(strings.TrimPrefix(header, "-- sqddl:batch "))
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range modifiers {
+		switch m.Name {
+		case "table":
+			batch.tableName = m.RawValue
+		case "key":
+			batch.keyColumn = m.RawValue
+		case "size":
+			batch.size, err = strconv.Atoi(m.RawValue)
+			if err != nil || batch.size <= 0 {
+				return nil, fmt.Errorf("size: %q is not a positive integer", m.RawValue)
+			}
+		case "sleep":
+			batch.sleep, err = time.ParseDuration(m.RawValue)
+			if err != nil {
+				return nil, fmt.Errorf("sleep: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("unknown modifier %q", m.Name)
+		}
+	}
+	if batch.tableName == "" {
+		return nil, fmt.Errorf("table empty or not provided")
+	}
+	if batch.keyColumn == "" {
+		return nil, fmt.Errorf("key empty or not provided")
+	}
+	// Oracle rejects statements with a trailing semicolon.
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	if query == "" {
+		return nil, fmt.Errorf("batch migration has no statement")
+	}
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(query, '{')
+		if i < 0 {
+			b.WriteString(query)
+			break
+		}
+		b.WriteString(query[:i])
+		query = query[i:]
+		switch {
+		case strings.HasPrefix(query, "{start_key}"):
+			batch.keyArgs = append(batch.keyArgs, false)
+			query = query[len("{start_key}"):]
+		case strings.HasPrefix(query, "{end_key}"):
+			batch.keyArgs = append(batch.keyArgs, true)
+			query = query[len("{end_key}"):]
+		default:
+			b.WriteByte('{')
+			query = query[1:]
+			continue
+		}
+		b.WriteString(batchPlaceholder(dialect, len(batch.keyArgs)))
+	}
+	if len(batch.keyArgs) == 0 {
+		return nil, fmt.Errorf("batch migration statement does not use {start_key} or {end_key}")
+	}
+	batch.query = b.String()
+	return batch, nil
+}
+
+// boundsQuery returns the query that fetches the first and last key of the
+// next chunk of rows. If afterKey is true the chunk starts after the key
+// passed in as the first argument, otherwise it starts from the beginning of
+// the table.
+func (batch *batchMigration) boundsQuery(dialect string, afterKey bool) string {
+	where := ""
+	if afterKey {
+		where = " WHERE " + batch.keyColumn + " > " + batchPlaceholder(dialect, 1)
+	}
+	size := strconv.Itoa(batch.size)
+	switch dialect {
+	case DialectSQLServer:
+		return "SELECT MIN(" + batch.keyColumn + "), MAX(" + batch.keyColumn + ") FROM (SELECT TOP (" + size + ") " + batch.keyColumn + " FROM " + batch.tableName + where + " ORDER BY " + batch.keyColumn + ") AS batch"
+	case DialectOracle:
+		return "SELECT MIN(" + batch.keyColumn + "), MAX(" + batch.keyColumn + ") FROM (SELECT " + batch.keyColumn + " FROM " + batch.tableName + where + " ORDER BY " + batch.keyColumn + " FETCH FIRST " + size + " ROWS ONLY) batch"
+	default:
+		return "SELECT MIN(" + batch.keyColumn + "), MAX(" + batch.keyColumn + ") FROM (SELECT " + batch.keyColumn + " FROM " + batch.tableName + where + " ORDER BY " + batch.keyColumn + " LIMIT " + size + ") AS batch"
+	}
+}
+
+// batchPlaceholder returns the nth (1-based) query placeholder for a dialect.
+func batchPlaceholder(dialect string, n int) string {
+	switch dialect {
+	case DialectPostgres:
+		return "$" + strconv.Itoa(n)
+	case DialectSQLServer:
+		return "@p" + strconv.Itoa(n)
+	case DialectOracle:
+		return ":" + strconv.Itoa(n)
+	default:
+		return "?"
+	}
+}
+
+// runBatch runs a *.batch.sql migration one chunk of rows at a time. Each
+// chunk is committed in its own transaction together with its last key in the
+// history table, so that an interrupted batch migration resumes from the
+// chunk after the last committed one when it is run again.
+func (cmd *MigrateCmd) runBatch(conn *sql.Conn, m migration) error {
+	file, err := cmd.DirFS.Open(m.filename)
+	if err != nil {
+		return err
+	}
+	cmd.buf.Reset()
+	_, err = cmd.buf.ReadFrom(file)
+	file.Close()
+	if err != nil {
+		return err
+	}
+	contents := cmd.buf.String()
+	batch, err := parseBatchMigration(cmd.Dialect, contents)
+	if err != nil {
+		return fmt.Errorf("%s: %w", m.filename, err)
+	}
+	if m.batchKey.Valid {
+		fmt.Fprintln(cmd.Stderr, m.filename+": resuming after key "+m.batchKey.String)
+	}
+	m.startedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	if cmd.Verbose {
+		fmt.Fprintln(cmd.Stderr, timestamp()+"[START] "+m.filename)
+	}
+	for {
+		var args []any
+		if m.batchKey.Valid {
+			args = append(args, m.batchKey.String)
+		}
+		var startKey, endKey sql.NullString
+		chunkStartedAt := time.Now()
+		// Each chunk is retried on lock timeout the same way as every
+		// other migration.
+		var err error
+		for attempts := 1; ; attempts++ {
+			err = conn.QueryRowContext(cmd.Ctx, batch.boundsQuery(cmd.Dialect, m.batchKey.Valid), args...).Scan(&startKey, &endKey)
+			if err == nil && endKey.Valid {
+				err = cmd.runBatchChunk(conn, &m, batch, startKey.String, endKey.String)
+			}
+			if err == nil || cmd.driver.IsLockTimeout == nil || !cmd.driver.IsLockTimeout(err) {
+				break
+			}
+			if attempts >= cmd.MaxAttempts {
+				fmt.Fprintf(cmd.Stderr, m.filename+": attempt %d/%d timed out\n", attempts, cmd.MaxAttempts)
+				break
+			}
+			delay := cmd.retryDelay(attempts)
+			fmt.Fprintf(cmd.Stderr, m.filename+": attempt %d/%d timed out, retrying in %s\n", attempts, cmd.MaxAttempts, delay.String())
+			select {
+			case <-cmd.Ctx.Done():
+				return cmd.Ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+		if err != nil {
+			timeTaken := time.Since(m.startedAt.Time)
+			migrationErr := &MigrationError{
+				Err:       err,
+				Filename:  m.filename,
+				Contents:  contents,
+				StartedAt: m.startedAt.Time,
+				TimeTaken: timeTaken,
+			}
+			if cmd.driver.AnnotateError != nil {
+				migrationErr.Err = cmd.driver.AnnotateError(migrationErr.Err, contents)
+			}
+			if cmd.Verbose {
+				fmt.Fprintln(cmd.Stderr, timestamp()+"[FAIL]  "+m.filename+" ("+timeTaken.String()+")")
+			} else {
+				fmt.Fprintln(cmd.Stderr, "[FAIL] "+m.filename+" ("+timeTaken.String()+")")
+			}
+			if !cmd.SkipHistoryTable {
+				m.timeTakenNs = sql.NullInt64{Int64: int64(timeTaken), Valid: true}
+				m.success = false
+				err := cmd.writeBatchHistory(conn, m)
+				if err != nil {
+					fmt.Fprintln(cmd.Stderr, err.Error())
+				}
+			}
+			return fmt.Errorf("%s: %w", m.filename, migrationErr)
+		}
+		if !endKey.Valid {
+			break
+		}
+		if cmd.Verbose {
+			fmt.Fprintln(cmd.Stderr, timestamp()+"[BATCH] "+m.filename+" "+startKey.String+".."+endKey.String+" ("+time.Since(chunkStartedAt).String()+")")
+		} else {
+			fmt.Fprintln(cmd.Stderr, "[BATCH] "+m.filename+" "+startKey.String+".."+endKey.String+" ("+time.Since(chunkStartedAt).String()+")")
+		}
+		if batch.sleep > 0 {
+			select {
+			case <-cmd.Ctx.Done():
+				return cmd.Ctx.Err()
+			case <-time.After(batch.sleep):
+			}
+		}
+	}
+	timeTaken := time.Since(m.startedAt.Time)
+	m.timeTakenNs = sql.NullInt64{Int64: int64(timeTaken), Valid: true}
+	m.success = true
+	if cmd.Verbose {
+		fmt.Fprintln(cmd.Stderr, timestamp()+"[OK]    "+m.filename+" ("+timeTaken.String()+")")
+	} else {
+		fmt.Fprintln(cmd.Stderr, "[OK] "+m.filename+" ("+timeTaken.String()+")")
+	}
+	if cmd.SkipHistoryTable {
+		return nil
+	}
+	return cmd.writeBatchHistory(conn, m)
+}
+
+// runBatchChunk runs the statement of a batch migration for the chunk of rows
+// between startKey and endKey (inclusive) and records endKey in the history
+// table, all within one transaction. The batch key of m is only advanced once
+// the transaction commits, so that a chunk that fails can be retried.
+func (cmd *MigrateCmd) runBatchChunk(conn *sql.Conn, m *migration, batch *batchMigration, startKey, endKey string) error {
+	tx, err := conn.BeginTx(cmd.Ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	args := make([]any, len(batch.keyArgs))
+	for i, isEnd := range batch.keyArgs {
+		if isEnd {
+			args[i] = endKey
+		} else {
+			args[i] = startKey
+		}
+	}
+	_, err = tx.ExecContext(cmd.Ctx, batch.query, args...)
+	if err != nil {
+		return err
+	}
+	batchKey := sql.NullString{String: endKey, Valid: true}
+	if !cmd.SkipHistoryTable {
+		history := *m
+		history.batchKey = batchKey
+		history.timeTakenNs = sql.NullInt64{Int64: int64(time.Since(m.startedAt.Time)), Valid: true}
+		err = cmd.writeBatchHistory(tx, history)
+		if err != nil {
+			return err
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	m.batchKey = batchKey
+	return nil
+}
+
+// writeBatchHistory upserts the status of a batch migration (including the
+// last key it processed) in the history table.
+func (cmd *MigrateCmd) writeBatchHistory(db DB, m migration) error {
+	bi := BatchInsert{
+		Dialect:    cmd.Dialect,
+		TableName:  cmd.HistoryTable,
+		Columns:    []string{"filename", "checksum", "started_at", "time_taken_ns", "success", "batch_key"},
+		KeyColumns: []string{"filename"},
+	}
+	i := 0
+	_, err := bi.ExecContext(cmd.Ctx, db, func(row []any) error {
+		if i > 0 {
+			return io.EOF
+		}
+		row[0] = m.filename
+		row[1] = m.checksum
+		row[2] = m.startedAt
+		if cmd.Dialect == DialectSQLite && m.startedAt.Valid {
+			row[2] = m.startedAt.Time.UTC().Format("2006-01-02 15:04:05")
+		}
+		row[3] = m.timeTakenNs
+		row[4] = m.success
+		row[5] = m.batchKey
+		i++
+		return nil
+	})
+	return err
+}
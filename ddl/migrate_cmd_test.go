@@ -230,4 +230,135 @@ func TestMigrateCmd(t *testing.T) {
 			"table1",
 		})
 	})
+
+	t.Run("batch migration", func(t *testing.T) {
+		t.Parallel()
+		db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=true")
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		migrateCmd := &MigrateCmd{
+			Dialect: "sqlite",
+			DB:      db,
+			DirFS: fstest.MapFS{
+				"01_table1.sql": &fstest.MapFile{
+					Data: []byte("CREATE TABLE table1 ( id INT PRIMARY KEY, n INT, n2 INT );" +
+						" WITH RECURSIVE ids (id) AS (SELECT 1 UNION ALL SELECT id + 1 FROM ids WHERE id < 25)" +
+						" INSERT INTO table1 (id, n) SELECT id, id FROM ids;"),
+				},
+				"02_backfill.batch.sql": &fstest.MapFile{
+					Data: []byte("-- sqddl:batch table=table1 key=id size=10\n" +
+						"UPDATE table1 SET n2 = n * 2 WHERE id >= {start_key} AND id <= {end_key};\n"),
+				},
+			},
+			Stderr: io.Discard,
+		}
+		err = migrateCmd.Run()
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		assertHistoryTable(t, db, []historyTableEntry{
+			{"01_table1.sql", true},
+			{"02_backfill.batch.sql", true},
+		})
+		var count int
+		err = db.QueryRow("SELECT COUNT(*) FROM table1 WHERE n2 = n * 2").Scan(&count)
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		if count != 25 {
+			t.Errorf(testutil.Callers()+" expected 25 rows to be backfilled, got %d", count)
+		}
+		var batchKey string
+		err = db.QueryRow("SELECT batch_key FROM sqddl_history WHERE filename = '02_backfill.batch.sql'").Scan(&batchKey)
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		if diff := testutil.Diff(batchKey, "25"); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+	})
+
+	t.Run("batch migration (resume)", func(t *testing.T) {
+		t.Parallel()
+		db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=true")
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		// Simulate a batch migration that was interrupted after the chunk
+		// ending with id 20 was committed.
+		_, err = db.Exec("CREATE TABLE table1 ( id INT PRIMARY KEY, n INT, n2 INT );" +
+			" WITH RECURSIVE ids (id) AS (SELECT 1 UNION ALL SELECT id + 1 FROM ids WHERE id < 25)" +
+			" INSERT INTO table1 (id, n) SELECT id, id FROM ids;")
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		err = ensureHistoryTableExists("sqlite", db, "sqddl_history")
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		_, err = db.Exec("INSERT INTO sqddl_history (filename, success, batch_key) VALUES ('01_backfill.batch.sql', FALSE, '20')")
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		migrateCmd := &MigrateCmd{
+			Dialect: "sqlite",
+			DB:      db,
+			DirFS: fstest.MapFS{
+				"01_backfill.batch.sql": &fstest.MapFile{
+					Data: []byte("-- sqddl:batch table=table1 key=id size=10\n" +
+						"UPDATE table1 SET n2 = n * 2 WHERE id BETWEEN {start_key} AND {end_key};\n"),
+				},
+			},
+			Stderr: io.Discard,
+		}
+		err = migrateCmd.Run()
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		assertHistoryTable(t, db, []historyTableEntry{
+			{"01_backfill.batch.sql", true},
+		})
+		// Only the rows after id 20 should have been backfilled.
+		var minID, maxID int
+		err = db.QueryRow("SELECT MIN(id), MAX(id) FROM table1 WHERE n2 IS NOT NULL").Scan(&minID, &maxID)
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		if diff := testutil.Diff([2]int{minID, maxID}, [2]int{21, 25}); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+	})
+
+	t.Run("batch migration (history table without batch_key)", func(t *testing.T) {
+		t.Parallel()
+		db, err := sql.Open("sqlite3", ":memory:?_foreign_keys=true")
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		// A history table created before batch migrations were supported.
+		_, err = db.Exec("CREATE TABLE sqddl_history (" +
+			" filename VARCHAR(255) NOT NULL PRIMARY KEY" +
+			" ,checksum VARCHAR(64)" +
+			" ,started_at DATETIME" +
+			" ,time_taken_ns BIGINT" +
+			" ,success BOOLEAN" +
+			" )")
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		for i := 0; i < 2; i++ {
+			err = ensureHistoryTableBatchKey("sqlite", db, "sqddl_history")
+			if err != nil {
+				t.Fatal(testutil.Callers(), err)
+			}
+			exists, err := historyTableHasBatchKey("sqlite", db, "sqddl_history")
+			if err != nil {
+				t.Fatal(testutil.Callers(), err)
+			}
+			if !exists {
+				t.Fatal(testutil.Callers(), "batch_key column was not added")
+			}
+		}
+	})
 }
@@ -11,6 +11,8 @@ type mysqlMigration struct {
 	versionNums      VersionNums
 	currentSchema    string
 	defaultCollation string
+	warnings         []string
+	renames          []renameOperation
 	dropFkeys        []*Constraint
 	dropSchemas      []string
 	createSchemas    []string
@@ -18,6 +20,16 @@ type mysqlMigration struct {
 	createTables     []*Table
 	alterTables      []mysqlAlterTable
 	addFkeys         []*Constraint
+	expandColumns    []expandContractColumn
+
+	// columnOrder adds new columns with FIRST or AFTER so that they end up
+	// in the same position as in the dest table, instead of always being
+	// appended as the last column.
+	columnOrder bool
+
+	// splitBy splits the tables file into one file per table ("table") or
+	// into drop_tables and create_tables files ("step").
+	splitBy string
 }
 
 type mysqlAlterTable struct {
@@ -30,6 +42,24 @@ type mysqlAlterTable struct {
 	alterColumns    [][2]*Column
 	createIndexes   []*Index
 	addConstraints  []*Constraint
+	commentTable    [2]*Table
+	engineTable     [2]*Table
+	charsetTable    [2]*Table
+
+	// srcTable is the table being altered. It is used to decide which
+	// ALGORITHM each change qualifies for.
+	srcTable *Table
+
+	// destTable is what the table is being altered into. It is used to
+	// position new columns when columnOrder is enabled.
+	destTable *Table
+
+	// Partitioning changes are written as separate ALTER TABLE statements
+	// because MySQL only allows one partitioning option per statement.
+	partitionTable  [2]*Table
+	dropPartitions  []*Partition
+	addPartitions   []*Partition
+	alterPartitions [][2]*Partition
 }
 
 func newMySQLMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) mysqlMigration {
@@ -39,6 +69,7 @@ func newMySQLMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) mysql
 		currentSchema:    srcCatalog.CurrentSchema,
 		defaultCollation: srcCatalog.DefaultCollation,
 	}
+	srcCatalog, m.renames, m.warnings = resolveRenames(dialect, srcCatalog, destCatalog, dropObjects)
 	srcCache, destCache := NewCatalogCache(srcCatalog), NewCatalogCache(destCatalog)
 	if dropObjects {
 		for i := range srcCatalog.Schemas {
@@ -123,6 +154,22 @@ func newMySQLMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) mysql
 			alterTable := mysqlAlterTable{
 				tableSchema: destTable.TableSchema,
 				tableName:   destTable.TableName,
+				srcTable:    srcTable,
+				destTable:   destTable,
+			}
+			if srcTable.Comment != destTable.Comment {
+				// COMMENT.
+				alterTable.commentTable = [2]*Table{srcTable, destTable}
+			}
+			// The engine and charset are only changed if the dest table
+			// specifies them, otherwise the existing ones are kept.
+			if destTable.Engine != "" && !strings.EqualFold(srcTable.Engine, destTable.Engine) {
+				// ENGINE.
+				alterTable.engineTable = [2]*Table{srcTable, destTable}
+			}
+			if destTable.Charset != "" && !strings.EqualFold(srcTable.Charset, destTable.Charset) {
+				// DEFAULT CHARSET.
+				alterTable.charsetTable = [2]*Table{srcTable, destTable}
 			}
 			if dropObjects {
 				for k := range srcTable.Constraints {
@@ -133,8 +180,8 @@ func newMySQLMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) mysql
 					destConstraint := destCache.GetConstraint(destTable, srcConstraint.ConstraintName)
 					if destConstraint == nil {
 						switch srcConstraint.ConstraintType {
-						case PRIMARY_KEY, UNIQUE:
-							// DROP PRIMARY KEY, DROP UNIQUE.
+						case PRIMARY_KEY, UNIQUE, CHECK:
+							// DROP PRIMARY KEY, DROP UNIQUE, DROP CHECK.
 							alterTable.dropConstraints = append(alterTable.dropConstraints, srcConstraint)
 						case FOREIGN_KEY:
 							// DROP FOREIGN KEY.
@@ -205,6 +252,9 @@ func newMySQLMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) mysql
 					if srcCollation != destCollation {
 						return true
 					}
+					if srcColumn.Comment != destColumn.Comment {
+						return true
+					}
 					return false
 				}()
 				if columnsAreDifferent {
@@ -221,6 +271,10 @@ func newMySQLMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) mysql
 				if srcIndex == nil {
 					// CREATE INDEX.
 					alterTable.createIndexes = append(alterTable.createIndexes, destIndex)
+				} else if indexesAreDifferent(dialect, srcIndex, destIndex) {
+					// DROP INDEX + CREATE INDEX.
+					alterTable.dropIndexes = append(alterTable.dropIndexes, srcIndex)
+					alterTable.createIndexes = append(alterTable.createIndexes, destIndex)
 				}
 			}
 			for k := range destTable.Constraints {
@@ -231,8 +285,8 @@ func newMySQLMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) mysql
 				srcConstraint := srcCache.GetConstraint(srcTable, destConstraint.ConstraintName)
 				if srcConstraint == nil {
 					switch destConstraint.ConstraintType {
-					case PRIMARY_KEY, UNIQUE:
-						// ADD PRIMARY KEY | ADD UNIQUE.
+					case PRIMARY_KEY, UNIQUE, CHECK:
+						// ADD PRIMARY KEY | ADD UNIQUE | ADD CHECK.
 						alterTable.addConstraints = append(alterTable.addConstraints, destConstraint)
 					case FOREIGN_KEY:
 						// ADD FOREIGN KEY.
@@ -254,12 +308,43 @@ func newMySQLMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) mysql
 					}
 				}
 			}
-			if len(alterTable.dropConstraints) > 0 ||
-				len(alterTable.dropIndexes) > 0 ||
-				len(alterTable.addColumns) > 0 ||
-				len(alterTable.alterColumns) > 0 ||
-				len(alterTable.createIndexes) > 0 ||
-				len(alterTable.addConstraints) > 0 {
+			if !strings.EqualFold(srcTable.PartitionStrategy, destTable.PartitionStrategy) || normalizeIndexExpr(srcTable.PartitionKey) != normalizeIndexExpr(destTable.PartitionKey) {
+				// PARTITION BY | REMOVE PARTITIONING.
+				alterTable.partitionTable = [2]*Table{srcTable, destTable}
+			} else if destTable.PartitionStrategy != "" {
+				srcPartitions := make(map[string]*Partition)
+				for k := range srcTable.Partitions {
+					if !srcTable.Partitions[k].Ignore {
+						srcPartitions[srcTable.Partitions[k].PartitionName] = &srcTable.Partitions[k]
+					}
+				}
+				destPartitions := make(map[string]*Partition)
+				for k := range destTable.Partitions {
+					destPartition := &destTable.Partitions[k]
+					if destPartition.Ignore {
+						continue
+					}
+					destPartitions[destPartition.PartitionName] = destPartition
+					srcPartition := srcPartitions[destPartition.PartitionName]
+					if srcPartition == nil {
+						// ADD PARTITION.
+						alterTable.addPartitions = append(alterTable.addPartitions, destPartition)
+					} else if normalizeIndexExpr(srcPartition.PartitionBound) != normalizeIndexExpr(destPartition.PartitionBound) {
+						// REORGANIZE PARTITION.
+						alterTable.alterPartitions = append(alterTable.alterPartitions, [2]*Partition{srcPartition, destPartition})
+					}
+				}
+				if dropObjects {
+					for k := range srcTable.Partitions {
+						srcPartition := &srcTable.Partitions[k]
+						if !srcPartition.Ignore && destPartitions[srcPartition.PartitionName] == nil {
+							// DROP PARTITION.
+							alterTable.dropPartitions = append(alterTable.dropPartitions, srcPartition)
+						}
+					}
+				}
+			}
+			if !alterTable.isEmpty() {
 				m.alterTables = append(m.alterTables, alterTable)
 			}
 		}
@@ -267,10 +352,154 @@ func newMySQLMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) mysql
 	return m
 }
 
+// mysqlColumnPosition returns the FIRST or AFTER clause that places a new
+// column in the same position as it has in the destTable. It returns an empty
+// string if appending the column already puts it in the right position i.e.
+// every column that comes after it in the destTable is also new.
+func mysqlColumnPosition(srcTable, destTable *Table, columnName string) string {
+	const dialect = DialectMySQL
+	srcColumns := make(map[string]bool)
+	for i := range srcTable.Columns {
+		if !srcTable.Columns[i].Ignore {
+			srcColumns[srcTable.Columns[i].ColumnName] = true
+		}
+	}
+	var prevColumn string
+	for i := range destTable.Columns {
+		destColumn := &destTable.Columns[i]
+		if destColumn.Ignore {
+			continue
+		}
+		if destColumn.ColumnName != columnName {
+			prevColumn = destColumn.ColumnName
+			continue
+		}
+		for _, nextColumn := range destTable.Columns[i+1:] {
+			if nextColumn.Ignore || !srcColumns[nextColumn.ColumnName] {
+				continue
+			}
+			if prevColumn == "" {
+				return "FIRST"
+			}
+			return "AFTER " + QuoteIdentifier(dialect, prevColumn)
+		}
+		return ""
+	}
+	return ""
+}
+
+// isEmpty reports whether the ALTER TABLE has no changes to make.
+func (alterTable *mysqlAlterTable) isEmpty() bool {
+	return len(alterTable.dropConstraints) == 0 &&
+		len(alterTable.dropIndexes) == 0 &&
+		len(alterTable.addColumns) == 0 &&
+		len(alterTable.alterColumns) == 0 &&
+		len(alterTable.createIndexes) == 0 &&
+		len(alterTable.addConstraints) == 0 &&
+		alterTable.commentTable[1] == nil &&
+		alterTable.engineTable[1] == nil &&
+		alterTable.charsetTable[1] == nil &&
+		alterTable.partitionTable[1] == nil &&
+		len(alterTable.dropPartitions) == 0 &&
+		len(alterTable.addPartitions) == 0 &&
+		len(alterTable.alterPartitions) == 0
+}
+
+// planExpandContract moves every column type change that cannot be done
+// in-place out of its ALTER TABLE and into an expand/contract plan (see
+// expandContractColumn). The old columns are only dropped if dropObjects is
+// true.
+func (m *mysqlMigration) planExpandContract(dropObjects bool) {
+	const dialect = DialectMySQL
+	alterTables := m.alterTables[:0]
+	for _, alterTable := range m.alterTables {
+		tableName := QuoteIdentifier(dialect, alterTable.tableName)
+		if alterTable.tableSchema != "" && alterTable.tableSchema != m.currentSchema {
+			tableName = QuoteIdentifier(dialect, alterTable.tableSchema) + "." + tableName
+		}
+		alterColumns := alterTable.alterColumns[:0]
+		for _, columns := range alterTable.alterColumns {
+			srcColumn, destColumn := columns[0], columns[1]
+			srcType, srcArg1, srcArg2 := normalizeColumnType(dialect, srcColumn.ColumnType)
+			destType, destArg1, destArg2 := normalizeColumnType(dialect, destColumn.ColumnType)
+			if [3]string{srcType, srcArg1, srcArg2} == [3]string{destType, destArg1, destArg2} || m.alterTypeWarning(tableName, srcColumn, destColumn) == "" {
+				alterColumns = append(alterColumns, columns)
+				continue
+			}
+			// AUTO_INCREMENT and primary key columns cannot be swapped out
+			// from under their referencing foreign keys, and generated
+			// columns cannot be written to by a trigger.
+			if srcColumn.IsAutoincrement || destColumn.IsAutoincrement || srcColumn.IsPrimaryKey || destColumn.IsPrimaryKey || srcColumn.GeneratedExpr != "" || destColumn.GeneratedExpr != "" {
+				m.warnings = append(m.warnings, fmt.Sprintf("%s: column %q cannot be changed with zero downtime (AUTO_INCREMENT columns, primary key columns and generated columns are not supported), it will be altered in place instead", tableName, destColumn.ColumnName))
+				alterColumns = append(alterColumns, columns)
+				continue
+			}
+			m.warnings = append(m.warnings, fmt.Sprintf("%s: column %q is replaced by a new column, the indexes, constraints and views that depend on it have to be recreated manually", tableName, destColumn.ColumnName))
+			m.expandColumns = append(m.expandColumns, expandContractColumn{
+				tableSchema: alterTable.tableSchema,
+				tableName:   alterTable.tableName,
+				srcColumn:   srcColumn,
+				destColumn:  destColumn,
+				contract:    dropObjects,
+			})
+		}
+		alterTable.alterColumns = alterColumns
+		if !alterTable.isEmpty() {
+			alterTables = append(alterTables, alterTable)
+		}
+	}
+	m.alterTables = alterTables
+}
+
 func (m *mysqlMigration) sql(prefix string) (filenames []string, bufs []*bytes.Buffer, warnings []string) {
 	const dialect = DialectMySQL
+	warnings = m.warnings
 	n := 0
 
+	// RENAME.
+	if len(m.renames) > 0 {
+		n++
+		// ${prefix}_${n}_renames.sql
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_renames.sql")
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		for _, rename := range m.renames {
+			m.writeRename(buf, rename.objectType, rename.tableSchema, rename.tableName, rename.oldName, rename.newName, rename.column)
+		}
+		// ${prefix}_${n}_renames.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		for i := len(m.renames) - 1; i >= 0; i-- {
+			rename := m.renames[i]
+			tableName := rename.tableName
+			if rename.objectType == "TABLE" {
+				tableName = rename.newName
+			}
+			var column *Column
+			if rename.column != nil {
+				c := *rename.column
+				c.ColumnName = rename.oldName
+				column = &c
+			}
+			stmt := bufpool.Get().(*bytes.Buffer)
+			stmt.Reset()
+			m.writeRename(stmt, rename.objectType, rename.tableSchema, tableName, rename.newName, rename.oldName, column)
+			var exists string
+			switch rename.objectType {
+			case "TABLE":
+				exists = m.exists("tables", rename.tableSchema, rename.newName, "", "")
+			case "COLUMN":
+				exists = m.exists("columns", rename.tableSchema, tableName, "column_name", rename.newName)
+			case "INDEX", "CONSTRAINT":
+				exists = m.exists("statistics", rename.tableSchema, tableName, "index_name", rename.newName)
+			}
+			m.writeIf(undobuf, exists, stmt.String())
+			bufpool.Put(stmt)
+		}
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+	}
+
 	// DROP FOREIGN KEY.
 	for _, fkey := range m.dropFkeys {
 		n++
@@ -286,6 +515,16 @@ func (m *mysqlMigration) sql(prefix string) (filenames []string, bufs []*bytes.B
 		}
 		constraintName := QuoteIdentifier(dialect, fkey.ConstraintName)
 		buf.WriteString("ALTER TABLE " + tableName + " DROP CONSTRAINT " + constraintName + ";\n")
+		// ${prefix}_${n}_drop_${constraint}.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		stmt := bufpool.Get().(*bytes.Buffer)
+		stmt.Reset()
+		stmt.WriteString("ALTER TABLE " + tableName + " ADD ")
+		writeConstraintDefinition(dialect, stmt, m.currentSchema, fkey)
+		m.writeIf(undobuf, "NOT "+m.exists("table_constraints", fkey.TableSchema, fkey.TableName, "constraint_name", fkey.ConstraintName), stmt.String())
+		bufpool.Put(stmt)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
 	}
 
 	// DROP SCHEMA + CREATE SCHEMA.
@@ -302,34 +541,39 @@ func (m *mysqlMigration) sql(prefix string) (filenames []string, bufs []*bytes.B
 			}
 			buf.WriteString("DROP SCHEMA IF EXISTS " + QuoteIdentifier(dialect, schemaName) + ";\n")
 		}
-		if len(m.createSchemas) > 0 {
-			// ${prefix}_${n}_schemas.undo.sql
-			filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_schemas.undo.sql")
-			undobuf := bufpool.Get().(*bytes.Buffer)
-			undobuf.Reset()
-			bufs = append(bufs, undobuf)
-			for _, schemaName := range m.createSchemas {
-				if buf.Len() > 0 {
-					buf.WriteString("\n")
-				}
-				buf.WriteString("CREATE SCHEMA IF NOT EXISTS " + QuoteIdentifier(dialect, schemaName) + ";\n")
-				if undobuf.Len() > 0 {
-					undobuf.WriteString("\n")
-				}
-				undobuf.WriteString("DROP SCHEMA IF EXISTS " + QuoteIdentifier(dialect, schemaName) + ";\n")
+		for _, schemaName := range m.createSchemas {
+			if buf.Len() > 0 {
+				buf.WriteString("\n")
+			}
+			buf.WriteString("CREATE SCHEMA IF NOT EXISTS " + QuoteIdentifier(dialect, schemaName) + ";\n")
+		}
+		// ${prefix}_${n}_schemas.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		for i := len(m.createSchemas) - 1; i >= 0; i-- {
+			if undobuf.Len() > 0 {
+				undobuf.WriteString("\n")
+			}
+			undobuf.WriteString("DROP SCHEMA IF EXISTS " + QuoteIdentifier(dialect, m.createSchemas[i]) + ";\n")
+		}
+		for _, schemaName := range m.dropSchemas {
+			if undobuf.Len() > 0 {
+				undobuf.WriteString("\n")
 			}
+			undobuf.WriteString("CREATE SCHEMA IF NOT EXISTS " + QuoteIdentifier(dialect, schemaName) + ";\n")
 		}
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
 	}
 
 	// DROP TABLE + CREATE TABLE.
-	if len(m.dropTables) > 0 || len(m.createTables) > 0 {
+	for _, group := range groupTables(m.splitBy, m.currentSchema, m.dropTables, m.createTables) {
 		n++
-		// ${prefix}_${n}_tables.sql
-		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_tables.sql")
+		// ${prefix}_${n}_${group}.sql
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_"+group.name+".sql")
 		buf := bufpool.Get().(*bytes.Buffer)
 		buf.Reset()
 		bufs = append(bufs, buf)
-		for _, table := range m.dropTables {
+		for _, table := range group.dropTables {
 			if buf.Len() > 0 {
 				buf.WriteString("\n")
 			}
@@ -339,33 +583,27 @@ func (m *mysqlMigration) sql(prefix string) (filenames []string, bufs []*bytes.B
 			}
 			buf.WriteString("DROP TABLE IF EXISTS " + tableName + ";\n")
 		}
-		if len(m.createTables) > 0 {
-			// ${prefix}_${n}_tables.undo.sql
-			filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_tables.undo.sql")
-			undobuf := bufpool.Get().(*bytes.Buffer)
-			undobuf.Reset()
-			bufs = append(bufs, undobuf)
-			for _, table := range m.createTables {
-				if buf.Len() > 0 {
-					buf.WriteString("\n")
-				}
-				writeCreateTable(dialect, buf, m.currentSchema, m.defaultCollation, table, true)
-				for _, index := range table.Indexes {
-					if buf.Len() > 0 {
-						buf.WriteString("\n")
-					}
-					writeCreateIndex(dialect, buf, m.currentSchema, &index, false)
-				}
-				if undobuf.Len() > 0 {
-					undobuf.WriteString("\n")
-				}
-				tableName := QuoteIdentifier(dialect, table.TableName)
-				if table.TableSchema != "" && table.TableSchema != m.currentSchema {
-					tableName = QuoteIdentifier(dialect, table.TableSchema) + "." + tableName
-				}
-				undobuf.WriteString("DROP TABLE IF EXISTS " + tableName + ";\n")
+		for _, table := range group.createTables {
+			m.writeTable(buf, table, false)
+		}
+		// ${prefix}_${n}_${group}.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		for i := len(group.createTables) - 1; i >= 0; i-- {
+			table := group.createTables[i]
+			if undobuf.Len() > 0 {
+				undobuf.WriteString("\n")
+			}
+			tableName := QuoteIdentifier(dialect, table.TableName)
+			if table.TableSchema != "" && table.TableSchema != m.currentSchema {
+				tableName = QuoteIdentifier(dialect, table.TableSchema) + "." + tableName
 			}
+			undobuf.WriteString("DROP TABLE IF EXISTS " + tableName + ";\n")
 		}
+		for _, table := range group.dropTables {
+			m.writeTable(undobuf, table, true)
+		}
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
 	}
 
 	// ALTER TABLE.
@@ -382,92 +620,181 @@ func (m *mysqlMigration) sql(prefix string) (filenames []string, bufs []*bytes.B
 		buf := bufpool.Get().(*bytes.Buffer)
 		buf.Reset()
 		bufs = append(bufs, buf)
-		buf.WriteString("ALTER TABLE " + tableName)
-		written := false
+		var clauses []mysqlAlterClause
+		replacePrimaryKey := false
 		for _, constraint := range alterTable.dropConstraints {
-			buf.WriteString("\n    ")
-			if written {
-				buf.WriteString(",")
-			}
-			written = true
 			constraintName := QuoteIdentifier(dialect, constraint.ConstraintName)
-			buf.WriteString("DROP CONSTRAINT " + constraintName)
+			clause := mysqlAlterClause{sql: "DROP CONSTRAINT " + constraintName, algorithm: mysqlAlgorithmInplace, lock: "NONE"}
+			if constraint.ConstraintType == PRIMARY_KEY {
+				for _, c := range alterTable.addConstraints {
+					if c.ConstraintType == PRIMARY_KEY {
+						replacePrimaryKey = true
+					}
+				}
+				if !replacePrimaryKey {
+					clause.algorithm, clause.lock = mysqlAlgorithmCopy, ""
+					clause.copyDesc = "dropping the primary key"
+				}
+			}
+			clauses = append(clauses, clause)
 		}
 		for _, index := range alterTable.dropIndexes {
-			buf.WriteString("\n    ")
-			if written {
-				buf.WriteString(",")
-			}
-			written = true
 			indexName := QuoteIdentifier(dialect, index.IndexName)
-			buf.WriteString("DROP INDEX " + indexName)
+			clauses = append(clauses, mysqlAlterClause{sql: "DROP INDEX " + indexName, algorithm: mysqlAlgorithmInplace, lock: "NONE"})
 		}
 		for _, column := range alterTable.dropColumns {
-			buf.WriteString("\n    ")
-			if written {
-				buf.WriteString(",")
-			}
-			written = true
 			columnName := QuoteIdentifier(dialect, column.ColumnName)
-			buf.WriteString("DROP COLUMN " + columnName)
+			clause := mysqlAlterClause{sql: "DROP COLUMN " + columnName, algorithm: mysqlAlgorithmInplace, lock: "NONE"}
+			// Dropping a column instantly is only supported from MySQL 8.0.29
+			// onwards, and not if the column is indexed (the index has to be
+			// rebuilt).
+			if m.versionNums.GreaterOrEqualTo(8, 0, 29) && !mysqlHasFulltextIndex(alterTable.srcTable) && !mysqlIsIndexed(alterTable.srcTable, column.ColumnName) {
+				clause.algorithm, clause.lock = mysqlAlgorithmInstant, ""
+			}
+			clauses = append(clauses, clause)
 		}
 		for _, column := range alterTable.addColumns {
-			buf.WriteString("\n    ")
-			if written {
-				buf.WriteString(",")
+			sqlbuf := bufpool.Get().(*bytes.Buffer)
+			sqlbuf.Reset()
+			sqlbuf.WriteString("ADD COLUMN ")
+			writeColumnDefinition(dialect, sqlbuf, m.defaultCollation, column, false)
+			var position string
+			if m.columnOrder {
+				position = mysqlColumnPosition(alterTable.srcTable, alterTable.destTable, column.ColumnName)
+				if position != "" {
+					sqlbuf.WriteString(" " + position)
+				}
 			}
-			written = true
-			buf.WriteString("ADD COLUMN ")
-			writeColumnDefinition(dialect, buf, m.defaultCollation, column, false)
+			clause := mysqlAlterClause{sql: sqlbuf.String(), algorithm: mysqlAlgorithmInplace, lock: "NONE"}
+			bufpool.Put(sqlbuf)
+			switch {
+			case column.GeneratedExpr != "" && column.GeneratedExprStored:
+				clause.algorithm, clause.lock = mysqlAlgorithmCopy, ""
+				clause.copyDesc = fmt.Sprintf("adding stored generated column %q", column.ColumnName)
+			case column.IsAutoincrement:
+				clause.lock = "SHARED"
+			case position != "" && m.versionNums.LowerThan(8, 0, 29):
+				// ALGORITHM=INSTANT only supports adding a column as the
+				// last column before MySQL 8.0.29.
+			case m.versionNums.GreaterOrEqualTo(8, 0, 12) && !mysqlHasFulltextIndex(alterTable.srcTable):
+				clause.algorithm, clause.lock = mysqlAlgorithmInstant, ""
+			}
+			clauses = append(clauses, clause)
 		}
 		for _, columns := range alterTable.alterColumns {
 			srcColumn, destColumn := columns[0], columns[1]
-			columnName := destColumn.ColumnName
 			srcType, srcArg1, srcArg2 := normalizeColumnType(dialect, srcColumn.ColumnType)
 			destType, destArg1, destArg2 := normalizeColumnType(dialect, destColumn.ColumnType)
 			if [3]string{srcType, srcArg1, srcArg2} != [3]string{destType, destArg1, destArg2} {
-				switch [2]string{srcType, destType} {
-				case [2]string{"VARCHAR", "VARCHAR"}:
-					srcLimit, _ := strconv.Atoi(srcArg1)
-					destLimit, _ := strconv.Atoi(destArg1)
-					if srcLimit > 0 && destLimit > 0 {
-						if srcLimit <= 255 && destLimit > 255 {
-							warnings = append(warnings, fmt.Sprintf("%s: column %q changing type from %q to %q is unsafe (cannot increase limit from less than or equal to 255 to greater than 255)", tableName, columnName, srcColumn.ColumnType, destColumn.ColumnType))
-						} else if srcLimit > 255 && destLimit <= 255 {
-							warnings = append(warnings, fmt.Sprintf("%s: column %q changing type from %q to %q is unsafe (cannot decrease limit from greater than 255 to less than or equal to 255)", tableName, columnName, srcColumn.ColumnType, destColumn.ColumnType))
-						}
-					}
-				default:
-					warnings = append(warnings, fmt.Sprintf("%s: column %q changing type from %q to %q may be unsafe", tableName, columnName, srcColumn.ColumnType, destColumn.ColumnType))
+				if warning := m.alterTypeWarning(tableName, srcColumn, destColumn); warning != "" {
+					warnings = append(warnings, warning)
 				}
 			}
-			buf.WriteString("\n    ")
-			if written {
-				buf.WriteString(",")
-			}
-			written = true
-			buf.WriteString("MODIFY COLUMN ")
-			writeColumnDefinition(dialect, buf, m.defaultCollation, destColumn, false)
+			sqlbuf := bufpool.Get().(*bytes.Buffer)
+			sqlbuf.Reset()
+			sqlbuf.WriteString("MODIFY COLUMN ")
+			writeColumnDefinition(dialect, sqlbuf, m.defaultCollation, destColumn, false)
+			clause := m.alterColumnClause(tableName, srcColumn, destColumn)
+			clause.sql = sqlbuf.String()
+			bufpool.Put(sqlbuf)
+			clauses = append(clauses, clause)
 		}
 		for _, index := range alterTable.createIndexes {
-			buf.WriteString("\n    ")
-			if written {
-				buf.WriteString(",")
+			sqlbuf := bufpool.Get().(*bytes.Buffer)
+			sqlbuf.Reset()
+			sqlbuf.WriteString("ADD ")
+			writeIndexDefinition(dialect, sqlbuf, m.currentSchema, index, false, true, false)
+			clause := mysqlAlterClause{sql: sqlbuf.String(), algorithm: mysqlAlgorithmInplace, lock: "NONE"}
+			bufpool.Put(sqlbuf)
+			// FULLTEXT and SPATIAL indexes block writes while they are built.
+			if strings.EqualFold(index.IndexType, "FULLTEXT") || strings.EqualFold(index.IndexType, "SPATIAL") {
+				clause.lock = "SHARED"
 			}
-			written = true
-			buf.WriteString("ADD ")
-			writeIndexDefinition(dialect, buf, m.currentSchema, index, false, true)
+			clauses = append(clauses, clause)
 		}
 		for _, constraint := range alterTable.addConstraints {
-			buf.WriteString("\n    ")
-			if written {
-				buf.WriteString(",")
+			sqlbuf := bufpool.Get().(*bytes.Buffer)
+			sqlbuf.Reset()
+			sqlbuf.WriteString("ADD ")
+			writeConstraintDefinition(dialect, sqlbuf, m.currentSchema, constraint)
+			clause := mysqlAlterClause{sql: sqlbuf.String(), algorithm: mysqlAlgorithmInplace, lock: "NONE"}
+			bufpool.Put(sqlbuf)
+			if constraint.ConstraintType == CHECK {
+				clause.algorithm, clause.lock = mysqlAlgorithmCopy, ""
+				clause.copyDesc = fmt.Sprintf("adding CHECK constraint %q", constraint.ConstraintName)
 			}
-			written = true
-			buf.WriteString("ADD ")
-			writeConstraintDefinition(dialect, buf, m.currentSchema, constraint)
+			clauses = append(clauses, clause)
 		}
-		buf.WriteString("\n;\n")
+		if destTable := alterTable.commentTable[1]; destTable != nil {
+			clauses = append(clauses, mysqlAlterClause{
+				sql:       "COMMENT = '" + EscapeQuote(destTable.Comment, '\'') + "'",
+				algorithm: mysqlAlgorithmInplace,
+				lock:      "NONE",
+			})
+		}
+		if destTable := alterTable.engineTable[1]; destTable != nil {
+			clauses = append(clauses, mysqlAlterClause{
+				sql:       "ENGINE = " + destTable.Engine,
+				algorithm: mysqlAlgorithmCopy,
+				copyDesc:  "changing the storage engine",
+			})
+		}
+		if destTable := alterTable.charsetTable[1]; destTable != nil {
+			// Changing the default charset only affects columns added later,
+			// the existing columns keep their charset.
+			clauses = append(clauses, mysqlAlterClause{
+				sql:       "DEFAULT CHARSET = " + destTable.Charset,
+				algorithm: mysqlAlgorithmInplace,
+				lock:      "NONE",
+			})
+		}
+		// Dropping the primary key can only be done in place if a new primary
+		// key is added in the same statement.
+		warnings = append(warnings, m.writeAlterClauses(buf, tableName, clauses, replacePrimaryKey)...)
+		// PARTITION BY | REMOVE PARTITIONING.
+		if srcTable, destTable := alterTable.partitionTable[0], alterTable.partitionTable[1]; destTable != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: changing the partitioning of a table rebuilds the table and blocks writes while the rows are copied", tableName))
+			m.writePartitionTable(buf, tableName, srcTable, destTable)
+		}
+		// DROP PARTITION.
+		if len(alterTable.dropPartitions) > 0 {
+			if buf.Len() > 0 {
+				buf.WriteString("\n")
+			}
+			buf.WriteString("ALTER TABLE " + tableName + " DROP PARTITION ")
+			for i, partition := range alterTable.dropPartitions {
+				if i > 0 {
+					buf.WriteString(", ")
+				}
+				buf.WriteString(QuoteIdentifier(dialect, partition.PartitionName))
+			}
+			buf.WriteString(";\n")
+		}
+		// ADD PARTITION.
+		if len(alterTable.addPartitions) > 0 {
+			if buf.Len() > 0 {
+				buf.WriteString("\n")
+			}
+			buf.WriteString("ALTER TABLE " + tableName + " ADD PARTITION")
+			partitions := make([]Partition, 0, len(alterTable.addPartitions))
+			for _, partition := range alterTable.addPartitions {
+				partitions = append(partitions, *partition)
+			}
+			writePartitionDefinitions(buf, partitions)
+			buf.WriteString(";\n")
+		}
+		// REORGANIZE PARTITION.
+		for _, partitions := range alterTable.alterPartitions {
+			if buf.Len() > 0 {
+				buf.WriteString("\n")
+			}
+			m.writeReorganizePartition(buf, tableName, partitions[1])
+		}
+		// ${prefix}_${n}_alter_${table}.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		m.writeUndoAlterTable(undobuf, tableName, alterTable)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
 	}
 
 	// ADD FOREIGN KEY.
@@ -486,7 +813,656 @@ func (m *mysqlMigration) sql(prefix string) (filenames []string, bufs []*bytes.B
 		buf.WriteString("ALTER TABLE " + tableName + " ADD ")
 		writeConstraintDefinition(dialect, buf, m.currentSchema, fkey)
 		buf.WriteString(";\n")
+		// ${prefix}_${n}_add_${constraint}.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		m.writeIf(undobuf, m.exists("table_constraints", fkey.TableSchema, fkey.TableName, "constraint_name", fkey.ConstraintName), "ALTER TABLE "+tableName+" DROP CONSTRAINT "+QuoteIdentifier(dialect, fkey.ConstraintName))
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+	}
+
+	// EXPAND + SYNC + BACKFILL + SWAP + CONTRACT.
+	for _, column := range m.expandColumns {
+		srcColumn, destColumn := column.srcColumn, column.destColumn
+		name := column.name(m.currentSchema)
+		tableName := QuoteIdentifier(dialect, column.tableName)
+		if column.tableSchema != "" && column.tableSchema != m.currentSchema {
+			tableName = QuoteIdentifier(dialect, column.tableSchema) + "." + tableName
+		}
+		columnName := QuoteIdentifier(dialect, destColumn.ColumnName)
+		shadowName := QuoteIdentifier(dialect, column.shadowName())
+		oldName := QuoteIdentifier(dialect, column.oldName())
+		// The shadow column is added without its DEFAULT and NOT NULL, those
+		// are only set when the columns are swapped.
+		shadowColumn := &Column{
+			TableSchema:   destColumn.TableSchema,
+			TableName:     destColumn.TableName,
+			ColumnName:    column.shadowName(),
+			ColumnType:    destColumn.ColumnType,
+			IsEnum:        destColumn.IsEnum,
+			CollationName: destColumn.CollationName,
+		}
+		// The old column must not reject the rows inserted after the swap,
+		// which no longer write to it.
+		oldColumn := *srcColumn
+		oldColumn.ColumnName = column.oldName()
+		oldColumn.IsNotNull = false
+
+		// ${prefix}_${n}_expand_${table}_${column}.sql
+		n++
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_expand_"+name+".sql")
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		buf.WriteString("ALTER TABLE " + tableName + " ADD COLUMN ")
+		writeColumnDefinition(dialect, buf, m.defaultCollation, shadowColumn, false)
+		buf.WriteString(";\n")
+		// ${prefix}_${n}_expand_${table}_${column}.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		m.writeIf(undobuf, m.exists("columns", column.tableSchema, column.tableName, "column_name", column.shadowName()), "ALTER TABLE "+tableName+" DROP COLUMN "+shadowName)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+
+		// ${prefix}_${n}_sync_${table}_${column}.sql
+		n++
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_sync_"+name+".sql")
+		buf = bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		m.writeSyncTriggers(buf, tableName, column)
+		// ${prefix}_${n}_sync_${table}_${column}.undo.sql
+		undobuf = bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		m.writeDropSyncTriggers(undobuf, column)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+
+		// ${prefix}_${n}_backfill_${table}_${column}.sql
+		n++
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_backfill_"+name+".sql")
+		buf = bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		// Each UPDATE is committed on its own (autocommit), so the rows are
+		// only ever locked one batch at a time.
+		procedureName := QuoteIdentifier(dialect, column.tableName+"_"+destColumn.ColumnName+"_backfill")
+		if column.tableSchema != "" && column.tableSchema != m.currentSchema {
+			procedureName = QuoteIdentifier(dialect, column.tableSchema) + "." + procedureName
+		}
+		backfillExpr := columnName
+		backfillWhere := shadowName + " IS NULL AND " + columnName + " IS NOT NULL"
+		if nullDefault := column.nullDefault(dialect); nullDefault != "" {
+			backfillExpr = "COALESCE(" + columnName + ", " + nullDefault + ")"
+			backfillWhere = shadowName + " IS NULL"
+		}
+		buf.WriteString("CREATE PROCEDURE " + procedureName + "()" +
+			"\nBEGIN" +
+			"\n    REPEAT" +
+			"\n        UPDATE " + tableName + " SET " + shadowName + " = " + backfillExpr + " WHERE " + backfillWhere + " LIMIT " + strconv.Itoa(backfillBatchSize) + ";" +
+			"\n    UNTIL ROW_COUNT() = 0 END REPEAT;" +
+			"\nEND;\n")
+		buf.WriteString("\nCALL " + procedureName + "();\n")
+		buf.WriteString("\nDROP PROCEDURE " + procedureName + ";\n")
+
+		// ${prefix}_${n}_swap_${table}_${column}.sql
+		n++
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_swap_"+name+".sql")
+		buf = bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		// The columns are redefined while the triggers are still keeping
+		// them in sync, so that the rename that follows only has to change
+		// the metadata. The triggers replace NULLs with the DEFAULT, so
+		// making the shadow column NOT NULL does not reject the rows that
+		// the old column still accepts.
+		swapColumn := *destColumn
+		swapColumn.ColumnName = column.shadowName()
+		redefineShadow := destColumn.IsNotNull || destColumn.ColumnDefault != "" || destColumn.Comment != ""
+		if redefineShadow || srcColumn.IsNotNull {
+			buf.WriteString("ALTER TABLE " + tableName)
+			written := false
+			if srcColumn.IsNotNull {
+				buf.WriteString("\n    MODIFY COLUMN ")
+				written = true
+				nullableColumn := *srcColumn
+				nullableColumn.IsNotNull = false
+				writeColumnDefinition(dialect, buf, m.defaultCollation, &nullableColumn, false)
+			}
+			if redefineShadow {
+				buf.WriteString("\n    ")
+				if written {
+					buf.WriteString(",")
+				}
+				buf.WriteString("MODIFY COLUMN ")
+				writeColumnDefinition(dialect, buf, m.defaultCollation, &swapColumn, false)
+			}
+			buf.WriteString("\n;\n\n")
+		}
+		if column.hasNulls(dialect) {
+			warnings = append(warnings, fmt.Sprintf("%s: column %q becomes NOT NULL but has no DEFAULT to replace its NULLs with, update the rows where it is NULL (and stop writing NULLs) before running the swap migration or MODIFY COLUMN will fail", tableName, destColumn.ColumnName))
+		}
+		if destColumn.IsNotNull {
+			warnings = append(warnings, fmt.Sprintf("%s: setting NOT NULL on column %q rebuilds the table (although writes are not blocked while it is rebuilt)", tableName, column.shadowName()))
+		}
+		m.writeDropSyncTriggers(buf, column)
+		// CHANGE COLUMN renames both columns in one statement, so there is
+		// never a moment where the column does not exist.
+		buf.WriteString("\nALTER TABLE " + tableName)
+		buf.WriteString("\n    CHANGE COLUMN " + columnName + " ")
+		writeColumnDefinition(dialect, buf, m.defaultCollation, &oldColumn, false)
+		buf.WriteString("\n    ,CHANGE COLUMN " + shadowName + " ")
+		writeColumnDefinition(dialect, buf, m.defaultCollation, destColumn, false)
+		buf.WriteString("\n;\n")
+		// ${prefix}_${n}_swap_${table}_${column}.undo.sql
+		undobuf = bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		// The columns are only swapped back if the old column exists, i.e.
+		// the swap got as far as renaming the columns.
+		stmt := bufpool.Get().(*bytes.Buffer)
+		stmt.Reset()
+		stmt.WriteString("ALTER TABLE " + tableName)
+		stmt.WriteString("\n    CHANGE COLUMN " + columnName + " ")
+		writeColumnDefinition(dialect, stmt, m.defaultCollation, &swapColumn, false)
+		stmt.WriteString("\n    ,CHANGE COLUMN " + oldName + " ")
+		nullableColumn := *srcColumn
+		nullableColumn.IsNotNull = false
+		writeColumnDefinition(dialect, stmt, m.defaultCollation, &nullableColumn, false)
+		m.writeIf(undobuf, m.exists("columns", column.tableSchema, column.tableName, "column_name", column.oldName()), stmt.String())
+		bufpool.Put(stmt)
+		undobuf.WriteString("\n")
+		m.writeDropSyncTriggers(undobuf, column)
+		undobuf.WriteString("\n")
+		m.writeSyncTriggers(undobuf, tableName, column)
+		if redefineShadow || srcColumn.IsNotNull {
+			undobuf.WriteString("\nALTER TABLE " + tableName)
+			written := false
+			if redefineShadow {
+				undobuf.WriteString("\n    MODIFY COLUMN ")
+				written = true
+				writeColumnDefinition(dialect, undobuf, m.defaultCollation, shadowColumn, false)
+			}
+			if srcColumn.IsNotNull {
+				undobuf.WriteString("\n    ")
+				if written {
+					undobuf.WriteString(",")
+				}
+				undobuf.WriteString("MODIFY COLUMN ")
+				writeColumnDefinition(dialect, undobuf, m.defaultCollation, srcColumn, false)
+			}
+			undobuf.WriteString("\n;\n")
+		}
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+
+		// ${prefix}_${n}_contract_${table}_${column}.sql
+		if !column.contract {
+			warnings = append(warnings, fmt.Sprintf("%s: the old column %q is kept after the swap, run generate with -drop-objects to drop it once nothing uses it anymore", tableName, column.oldName()))
+			continue
+		}
+		n++
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_contract_"+name+".sql")
+		buf = bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		buf.WriteString("ALTER TABLE " + tableName + " DROP COLUMN " + oldName + ";\n")
+		// ${prefix}_${n}_contract_${table}_${column}.undo.sql
+		undobuf = bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		stmt = bufpool.Get().(*bytes.Buffer)
+		stmt.Reset()
+		stmt.WriteString("ALTER TABLE " + tableName + " ADD COLUMN ")
+		writeColumnDefinition(dialect, stmt, m.defaultCollation, &oldColumn, false)
+		m.writeIf(undobuf, "NOT "+m.exists("columns", column.tableSchema, column.tableName, "column_name", column.oldName()), stmt.String())
+		bufpool.Put(stmt)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
 	}
 
 	return filenames, bufs, warnings
 }
+
+// writeTable writes the CREATE TABLE statement for a table, followed by its
+// CREATE INDEX statements. If ifNotExists is true the statements are skipped
+// for a table or index that already exists (used by undo migrations).
+func (m *mysqlMigration) writeTable(buf *bytes.Buffer, table *Table, ifNotExists bool) {
+	const dialect = DialectMySQL
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	writeCreateTable(dialect, buf, m.currentSchema, m.defaultCollation, table, true, ifNotExists)
+	for _, index := range table.Indexes {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		if !ifNotExists {
+			writeCreateIndex(dialect, buf, m.currentSchema, &index, false, false)
+			continue
+		}
+		stmt := bufpool.Get().(*bytes.Buffer)
+		stmt.Reset()
+		writeCreateIndex(dialect, stmt, m.currentSchema, &index, false, false)
+		m.writeIf(buf, "NOT "+m.exists("statistics", table.TableSchema, table.TableName, "index_name", index.IndexName), stmt.String())
+		bufpool.Put(stmt)
+	}
+}
+
+// exists returns a condition that checks if the table exists (if nameColumn
+// is empty) or if the table has an object whose nameColumn is name, using the
+// given information_schema view.
+func (m *mysqlMigration) exists(view, tableSchema, tableName, nameColumn, name string) string {
+	schema := "DATABASE()"
+	if tableSchema != "" && tableSchema != m.currentSchema {
+		schema = "'" + strings.ReplaceAll(tableSchema, "'", "''") + "'"
+	}
+	cond := "EXISTS (SELECT 1 FROM information_schema." + view + " WHERE table_schema = " + schema + " AND table_name = '" + strings.ReplaceAll(tableName, "'", "''") + "'"
+	if nameColumn != "" {
+		cond += " AND " + nameColumn + " = '" + strings.ReplaceAll(name, "'", "''") + "'"
+	}
+	return cond + ")"
+}
+
+// writeIf writes a statement that is only executed if cond is true. MySQL
+// has no IF [NOT] EXISTS for most of ALTER TABLE, so undo migrations use this
+// to skip the changes that a partially applied migration never made.
+func (m *mysqlMigration) writeIf(buf *bytes.Buffer, cond, stmt string) {
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	stmt = strings.TrimSuffix(strings.TrimSpace(stmt), ";")
+	stmt = strings.ReplaceAll(strings.ReplaceAll(stmt, `\`, `\\`), "'", "''")
+	buf.WriteString("SET @stmt = IF(" + cond + ", '" + stmt + "', 'DO 0');\n")
+	buf.WriteString("PREPARE stmt FROM @stmt;\n")
+	buf.WriteString("EXECUTE stmt;\n")
+	buf.WriteString("DEALLOCATE PREPARE stmt;\n")
+}
+
+// writeUndoAlterTable writes the ALTER TABLE statements that revert the
+// changes made by an ALTER TABLE migration. Every change that adds or removes
+// an object is only reverted if it was actually made, so that the undo
+// migration also works for a partially applied migration.
+func (m *mysqlMigration) writeUndoAlterTable(buf *bytes.Buffer, tableName string, alterTable mysqlAlterTable) {
+	const dialect = DialectMySQL
+	tableSchema, rawTableName := alterTable.tableSchema, alterTable.tableName
+	stmt := bufpool.Get().(*bytes.Buffer)
+	defer bufpool.Put(stmt)
+	for i := len(alterTable.addConstraints) - 1; i >= 0; i-- {
+		constraint := alterTable.addConstraints[i]
+		exists := m.exists("table_constraints", tableSchema, rawTableName, "constraint_name", constraint.ConstraintName)
+		m.writeIf(buf, exists, "ALTER TABLE "+tableName+" DROP CONSTRAINT "+QuoteIdentifier(dialect, constraint.ConstraintName))
+	}
+	for i := len(alterTable.createIndexes) - 1; i >= 0; i-- {
+		index := alterTable.createIndexes[i]
+		exists := m.exists("statistics", tableSchema, rawTableName, "index_name", index.IndexName)
+		m.writeIf(buf, exists, "ALTER TABLE "+tableName+" DROP INDEX "+QuoteIdentifier(dialect, index.IndexName))
+	}
+	// MODIFY COLUMN and the table options can be applied whether or not the
+	// migration changed them, so they are written as one ALTER TABLE.
+	stmt.Reset()
+	stmt.WriteString("ALTER TABLE " + tableName)
+	written := false
+	for i := len(alterTable.alterColumns) - 1; i >= 0; i-- {
+		stmt.WriteString("\n    ")
+		if written {
+			stmt.WriteString(",")
+		}
+		written = true
+		stmt.WriteString("MODIFY COLUMN ")
+		writeColumnDefinition(dialect, stmt, m.defaultCollation, alterTable.alterColumns[i][0], false)
+	}
+	if srcTable := alterTable.commentTable[0]; srcTable != nil {
+		stmt.WriteString("\n    ")
+		if written {
+			stmt.WriteString(",")
+		}
+		written = true
+		stmt.WriteString("COMMENT = '" + EscapeQuote(srcTable.Comment, '\'') + "'")
+	}
+	if srcTable := alterTable.engineTable[0]; srcTable != nil && srcTable.Engine != "" {
+		stmt.WriteString("\n    ")
+		if written {
+			stmt.WriteString(",")
+		}
+		written = true
+		stmt.WriteString("ENGINE = " + srcTable.Engine)
+	}
+	if srcTable := alterTable.charsetTable[0]; srcTable != nil && srcTable.Charset != "" {
+		stmt.WriteString("\n    ")
+		if written {
+			stmt.WriteString(",")
+		}
+		written = true
+		stmt.WriteString("DEFAULT CHARSET = " + srcTable.Charset)
+	}
+	if written {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		buf.Write(stmt.Bytes())
+		buf.WriteString("\n;\n")
+	}
+	for i := len(alterTable.addColumns) - 1; i >= 0; i-- {
+		column := alterTable.addColumns[i]
+		exists := m.exists("columns", tableSchema, rawTableName, "column_name", column.ColumnName)
+		m.writeIf(buf, exists, "ALTER TABLE "+tableName+" DROP COLUMN "+QuoteIdentifier(dialect, column.ColumnName))
+	}
+	for _, column := range alterTable.dropColumns {
+		stmt.Reset()
+		stmt.WriteString("ALTER TABLE " + tableName + " ADD COLUMN ")
+		writeColumnDefinition(dialect, stmt, m.defaultCollation, column, false)
+		m.writeIf(buf, "NOT "+m.exists("columns", tableSchema, rawTableName, "column_name", column.ColumnName), stmt.String())
+	}
+	for _, index := range alterTable.dropIndexes {
+		stmt.Reset()
+		stmt.WriteString("ALTER TABLE " + tableName + " ADD ")
+		writeIndexDefinition(dialect, stmt, m.currentSchema, index, false, true, false)
+		m.writeIf(buf, "NOT "+m.exists("statistics", tableSchema, rawTableName, "index_name", index.IndexName), stmt.String())
+	}
+	for _, constraint := range alterTable.dropConstraints {
+		stmt.Reset()
+		stmt.WriteString("ALTER TABLE " + tableName + " ADD ")
+		writeConstraintDefinition(dialect, stmt, m.currentSchema, constraint)
+		m.writeIf(buf, "NOT "+m.exists("table_constraints", tableSchema, rawTableName, "constraint_name", constraint.ConstraintName), stmt.String())
+	}
+	// REORGANIZE PARTITION.
+	for i := len(alterTable.alterPartitions) - 1; i >= 0; i-- {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		m.writeReorganizePartition(buf, tableName, alterTable.alterPartitions[i][0])
+	}
+	// ADD PARTITION.
+	for i := len(alterTable.addPartitions) - 1; i >= 0; i-- {
+		partition := alterTable.addPartitions[i]
+		exists := m.exists("partitions", tableSchema, rawTableName, "partition_name", partition.PartitionName)
+		m.writeIf(buf, exists, "ALTER TABLE "+tableName+" DROP PARTITION "+QuoteIdentifier(dialect, partition.PartitionName))
+	}
+	// DROP PARTITION.
+	for _, partition := range alterTable.dropPartitions {
+		stmt.Reset()
+		stmt.WriteString("ALTER TABLE " + tableName + " ADD PARTITION")
+		writePartitionDefinitions(stmt, []Partition{*partition})
+		m.writeIf(buf, "NOT "+m.exists("partitions", tableSchema, rawTableName, "partition_name", partition.PartitionName), stmt.String())
+	}
+	// PARTITION BY | REMOVE PARTITIONING.
+	if srcTable, destTable := alterTable.partitionTable[0], alterTable.partitionTable[1]; destTable != nil {
+		m.writePartitionTable(buf, tableName, destTable, srcTable)
+	}
+}
+
+// writePartitionTable writes the ALTER TABLE statement that changes the
+// partitioning of a table from srcTable's to destTable's.
+func (m *mysqlMigration) writePartitionTable(buf *bytes.Buffer, tableName string, srcTable, destTable *Table) {
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	if destTable.PartitionStrategy == "" {
+		buf.WriteString("ALTER TABLE " + tableName + " REMOVE PARTITIONING;\n")
+		return
+	}
+	buf.WriteString("ALTER TABLE " + tableName + " PARTITION BY " + destTable.PartitionStrategy + " (" + destTable.PartitionKey + ")")
+	writePartitionDefinitions(buf, destTable.Partitions)
+	buf.WriteString(";\n")
+}
+
+// writeReorganizePartition writes the ALTER TABLE statement that changes the
+// bound of a partition.
+func (m *mysqlMigration) writeReorganizePartition(buf *bytes.Buffer, tableName string, partition *Partition) {
+	const dialect = DialectMySQL
+	buf.WriteString("ALTER TABLE " + tableName + " REORGANIZE PARTITION " + QuoteIdentifier(dialect, partition.PartitionName) + " INTO")
+	writePartitionDefinitions(buf, []Partition{*partition})
+	buf.WriteString(";\n")
+}
+
+// alterTypeWarning returns a warning if changing the type of a column from
+// srcColumn's type to destColumn's type cannot be done in-place (i.e. MySQL
+// has to copy the whole table). It returns an empty string if the change is
+// safe.
+func (m *mysqlMigration) alterTypeWarning(tableName string, srcColumn, destColumn *Column) string {
+	const dialect = DialectMySQL
+	columnName := destColumn.ColumnName
+	srcType, srcArg1, _ := normalizeColumnType(dialect, srcColumn.ColumnType)
+	destType, destArg1, _ := normalizeColumnType(dialect, destColumn.ColumnType)
+	switch [2]string{srcType, destType} {
+	case [2]string{"VARCHAR", "VARCHAR"}:
+		srcLimit, _ := strconv.Atoi(srcArg1)
+		destLimit, _ := strconv.Atoi(destArg1)
+		if srcLimit > 0 && destLimit > 0 {
+			if srcLimit <= 255 && destLimit > 255 {
+				return fmt.Sprintf("%s: column %q changing type from %q to %q is unsafe (cannot increase limit from less than or equal to 255 to greater than 255)", tableName, columnName, srcColumn.ColumnType, destColumn.ColumnType)
+			} else if srcLimit > 255 && destLimit <= 255 {
+				return fmt.Sprintf("%s: column %q changing type from %q to %q is unsafe (cannot decrease limit from greater than 255 to less than or equal to 255)", tableName, columnName, srcColumn.ColumnType, destColumn.ColumnType)
+			}
+		}
+	default:
+		return fmt.Sprintf("%s: column %q changing type from %q to %q may be unsafe", tableName, columnName, srcColumn.ColumnType, destColumn.ColumnType)
+	}
+	return ""
+}
+
+// Online DDL algorithms, from fastest to slowest.
+const (
+	mysqlAlgorithmInstant = iota
+	mysqlAlgorithmInplace
+	mysqlAlgorithmCopy
+)
+
+// mysqlAlterClause is a single change in an ALTER TABLE statement together
+// with the fastest online DDL algorithm (and the lock) it qualifies for.
+type mysqlAlterClause struct {
+	sql       string
+	algorithm int
+	lock      string // NONE or SHARED. Empty for ALGORITHM=INSTANT and COPY.
+
+	// copyDesc describes the change for the warning issued when it needs
+	// ALGORITHM=COPY. If empty, no warning is issued (because the change is
+	// already warned about elsewhere).
+	copyDesc string
+}
+
+// alterColumnClause returns the algorithm and lock that changing a column
+// from srcColumn to destColumn qualifies for.
+func (m *mysqlMigration) alterColumnClause(tableName string, srcColumn, destColumn *Column) mysqlAlterClause {
+	const dialect = DialectMySQL
+	srcCollation, destCollation := srcColumn.CollationName, destColumn.CollationName
+	if srcCollation == "" {
+		srcCollation = m.defaultCollation
+	}
+	if destCollation == "" {
+		destCollation = m.defaultCollation
+	}
+	if srcCollation != destCollation {
+		return mysqlAlterClause{algorithm: mysqlAlgorithmCopy, copyDesc: fmt.Sprintf("changing the collation of column %q", destColumn.ColumnName)}
+	}
+	if srcColumn.IsAutoincrement != destColumn.IsAutoincrement || (srcColumn.ColumnIdentity == "") != (destColumn.ColumnIdentity == "") {
+		return mysqlAlterClause{algorithm: mysqlAlgorithmCopy, copyDesc: fmt.Sprintf("changing AUTO_INCREMENT on column %q", destColumn.ColumnName)}
+	}
+	srcType, srcArg1, srcArg2 := normalizeColumnType(dialect, srcColumn.ColumnType)
+	destType, destArg1, destArg2 := normalizeColumnType(dialect, destColumn.ColumnType)
+	if [3]string{srcType, srcArg1, srcArg2} != [3]string{destType, destArg1, destArg2} {
+		// Only extending a VARCHAR within the same length byte (i.e. without
+		// crossing 255) is done in place.
+		if srcType == "VARCHAR" && destType == "VARCHAR" {
+			srcLimit, _ := strconv.Atoi(srcArg1)
+			destLimit, _ := strconv.Atoi(destArg1)
+			if srcLimit > 0 && destLimit > srcLimit && (srcLimit <= 255) == (destLimit <= 255) {
+				return mysqlAlterClause{algorithm: mysqlAlgorithmInplace, lock: "NONE"}
+			}
+		}
+		// Type changes flagged by alterTypeWarning are already warned about.
+		if m.alterTypeWarning(tableName, srcColumn, destColumn) != "" {
+			return mysqlAlterClause{algorithm: mysqlAlgorithmCopy}
+		}
+		return mysqlAlterClause{algorithm: mysqlAlgorithmCopy, copyDesc: fmt.Sprintf("changing the type of column %q from %q to %q", destColumn.ColumnName, srcColumn.ColumnType, destColumn.ColumnType)}
+	}
+	if srcColumn.IsNotNull != destColumn.IsNotNull || srcColumn.Comment != destColumn.Comment {
+		return mysqlAlterClause{algorithm: mysqlAlgorithmInplace, lock: "NONE"}
+	}
+	// Only the DEFAULT has changed, which is a metadata-only change.
+	if m.versionNums.GreaterOrEqualTo(8, 0, 12) {
+		return mysqlAlterClause{algorithm: mysqlAlgorithmInstant}
+	}
+	return mysqlAlterClause{algorithm: mysqlAlgorithmInplace, lock: "NONE"}
+}
+
+// writeAlterClauses writes the clauses of an ALTER TABLE as one or more ALTER
+// TABLE statements, each annotated with the ALGORITHM and LOCK its clauses
+// qualify for. That way MySQL fails the migration instead of silently
+// falling back to copying the table. Consecutive clauses that qualify for the
+// same algorithm are kept in the same statement, clauses that need a
+// different algorithm start a new statement. If merge is true, all clauses
+// are written as a single statement annotated with the slowest algorithm
+// among them. Online DDL is only available from MySQL 5.6 onwards, before
+// that the clauses are written as a single statement without annotations.
+func (m *mysqlMigration) writeAlterClauses(buf *bytes.Buffer, tableName string, clauses []mysqlAlterClause, merge bool) (warnings []string) {
+	if len(clauses) == 0 {
+		return nil
+	}
+	onlineDDL := m.versionNums.GreaterOrEqualTo(5, 6)
+	var statements [][]mysqlAlterClause
+	for i, clause := range clauses {
+		if clause.copyDesc != "" && onlineDDL {
+			warnings = append(warnings, fmt.Sprintf("%s: %s cannot be done in place (ALGORITHM=COPY), writes to the table are blocked while it is copied", tableName, clause.copyDesc))
+		}
+		if i == 0 || merge || !onlineDDL {
+			if i == 0 {
+				statements = append(statements, nil)
+			}
+			statements[len(statements)-1] = append(statements[len(statements)-1], clause)
+			continue
+		}
+		prev := clauses[i-1]
+		if clause.algorithm != prev.algorithm || clause.lock != prev.lock {
+			statements = append(statements, nil)
+		}
+		statements[len(statements)-1] = append(statements[len(statements)-1], clause)
+	}
+	for _, statement := range statements {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("ALTER TABLE " + tableName)
+		algorithm, lock := mysqlAlgorithmInstant, ""
+		for i, clause := range statement {
+			buf.WriteString("\n    ")
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString(clause.sql)
+			if clause.algorithm > algorithm {
+				algorithm = clause.algorithm
+			}
+			if clause.lock == "SHARED" || (lock == "" && clause.lock != "") {
+				lock = clause.lock
+			}
+		}
+		if onlineDDL {
+			switch algorithm {
+			case mysqlAlgorithmInstant:
+				buf.WriteString("\n    ,ALGORITHM=INSTANT")
+			case mysqlAlgorithmInplace:
+				buf.WriteString("\n    ,ALGORITHM=INPLACE")
+				if lock != "" {
+					buf.WriteString("\n    ,LOCK=" + lock)
+				}
+			}
+		}
+		buf.WriteString("\n;\n")
+	}
+	return warnings
+}
+
+// mysqlHasFulltextIndex reports if the table has a FULLTEXT index. Columns
+// cannot be added to or dropped from such a table with ALGORITHM=INSTANT.
+func mysqlHasFulltextIndex(table *Table) bool {
+	if table == nil {
+		return false
+	}
+	for _, index := range table.Indexes {
+		if strings.EqualFold(index.IndexType, "FULLTEXT") {
+			return true
+		}
+	}
+	return false
+}
+
+// mysqlIsIndexed reports if the column is part of any index of the table.
+func mysqlIsIndexed(table *Table, columnName string) bool {
+	if table == nil {
+		return false
+	}
+	for _, index := range table.Indexes {
+		for _, name := range index.Columns {
+			if name == columnName {
+				return true
+			}
+		}
+	}
+	for _, constraint := range table.Constraints {
+		for _, name := range constraint.Columns {
+			if name == columnName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeSyncTriggers writes the triggers that copy every write to the old
+// column into the shadow column of an expand/contract plan. MySQL triggers
+// can only fire on a single event, so one trigger is needed for INSERT and
+// another for UPDATE.
+func (m *mysqlMigration) writeSyncTriggers(buf *bytes.Buffer, tableName string, column expandContractColumn) {
+	const dialect = DialectMySQL
+	schemaPrefix := ""
+	if column.tableSchema != "" && column.tableSchema != m.currentSchema {
+		schemaPrefix = QuoteIdentifier(dialect, column.tableSchema) + "."
+	}
+	value := "NEW." + QuoteIdentifier(dialect, column.destColumn.ColumnName)
+	if nullDefault := column.nullDefault(dialect); nullDefault != "" {
+		value = "COALESCE(" + value + ", " + nullDefault + ")"
+	}
+	set := "SET NEW." + QuoteIdentifier(dialect, column.shadowName()) + " = " + value
+	buf.WriteString("CREATE TRIGGER " + schemaPrefix + QuoteIdentifier(dialect, column.syncName()+"_insert") + " BEFORE INSERT ON " + tableName + " FOR EACH ROW " + set + ";\n")
+	buf.WriteString("\nCREATE TRIGGER " + schemaPrefix + QuoteIdentifier(dialect, column.syncName()+"_update") + " BEFORE UPDATE ON " + tableName + " FOR EACH ROW " + set + ";\n")
+}
+
+// writeDropSyncTriggers writes the statements that drop the triggers written
+// by writeSyncTriggers.
+func (m *mysqlMigration) writeDropSyncTriggers(buf *bytes.Buffer, column expandContractColumn) {
+	const dialect = DialectMySQL
+	schemaPrefix := ""
+	if column.tableSchema != "" && column.tableSchema != m.currentSchema {
+		schemaPrefix = QuoteIdentifier(dialect, column.tableSchema) + "."
+	}
+	buf.WriteString("DROP TRIGGER IF EXISTS " + schemaPrefix + QuoteIdentifier(dialect, column.syncName()+"_insert") + ";\n")
+	buf.WriteString("\nDROP TRIGGER IF EXISTS " + schemaPrefix + QuoteIdentifier(dialect, column.syncName()+"_update") + ";\n")
+}
+
+// writeRename writes the statement that renames a table, column or index
+// from oldName to newName. MySQL constraints (other than UNIQUE constraints,
+// which are renamed as indexes) cannot be renamed.
+func (m *mysqlMigration) writeRename(buf *bytes.Buffer, objectType, tableSchema, tableName, oldName, newName string, column *Column) {
+	const dialect = DialectMySQL
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	schemaPrefix := ""
+	if tableSchema != "" && tableSchema != m.currentSchema {
+		schemaPrefix = QuoteIdentifier(dialect, tableSchema) + "."
+	}
+	switch objectType {
+	case "TABLE":
+		buf.WriteString("RENAME TABLE " + schemaPrefix + QuoteIdentifier(dialect, oldName) + " TO " + schemaPrefix + QuoteIdentifier(dialect, newName) + ";\n")
+	case "COLUMN":
+		tableName = schemaPrefix + QuoteIdentifier(dialect, tableName)
+		// RENAME COLUMN is only available from MySQL 8.0 onwards, before that
+		// we have to use CHANGE COLUMN together with the full column
+		// definition.
+		if m.versionNums.LowerThan(8) && column != nil {
+			buf.WriteString("ALTER TABLE " + tableName + " CHANGE COLUMN " + QuoteIdentifier(dialect, oldName) + " ")
+			writeColumnDefinition(dialect, buf, m.defaultCollation, column, false)
+			buf.WriteString(";\n")
+		} else {
+			buf.WriteString("ALTER TABLE " + tableName + " RENAME COLUMN " + QuoteIdentifier(dialect, oldName) + " TO " + QuoteIdentifier(dialect, newName) + ";\n")
+		}
+	case "INDEX", "CONSTRAINT":
+		tableName = schemaPrefix + QuoteIdentifier(dialect, tableName)
+		buf.WriteString("ALTER TABLE " + tableName + " RENAME INDEX " + QuoteIdentifier(dialect, oldName) + " TO " + QuoteIdentifier(dialect, newName) + ";\n")
+	}
+}
@@ -13,16 +13,24 @@ import (
 
 func Test_mysqlMigration(t *testing.T) {
 	type TT struct {
-		dir         string
-		dropObjects bool
+		dir          string
+		dropObjects  bool
+		zeroDowntime bool
 	}
 	tests := []TT{
-		{"testdata/mysql_schema", true},
-		{"testdata/mysql_table", true},
-		{"testdata/mysql_drop", true},
-		{"testdata/mysql_add", false},
-		{"testdata/mysql_alter", false},
-		{"testdata/mysql_ignore", true},
+		{"testdata/mysql_schema", true, false},
+		{"testdata/mysql_table", true, false},
+		{"testdata/mysql_drop", true, false},
+		{"testdata/mysql_add", false, false},
+		{"testdata/mysql_alter", false, false},
+		{"testdata/mysql_ignore", true, false},
+		{"testdata/mysql_comment", false, false},
+		{"testdata/mysql_rename", true, false},
+		{"testdata/mysql_check", true, false},
+		{"testdata/mysql_partition", true, false},
+		{"testdata/mysql_index", false, false},
+		{"testdata/mysql_options", true, false},
+		{"testdata/mysql_zero_downtime", true, true},
 	}
 	newCatalog := func(t *testing.T, filename string) *Catalog {
 		file, err := os.Open(filename)
@@ -49,6 +57,9 @@ func Test_mysqlMigration(t *testing.T) {
 			srcCatalog := newCatalog(t, tt.dir+"/src.go.txt")
 			destCatalog := newCatalog(t, tt.dir+"/dest.go.txt")
 			m := newMySQLMigration(srcCatalog, destCatalog, tt.dropObjects)
+			if tt.zeroDowntime {
+				m.planExpandContract(tt.dropObjects)
+			}
 			filenames, bufs, warnings := m.sql(strings.TrimPrefix(filepath.Base(tt.dir), "mysql_"))
 			for i, filename := range filenames {
 				b, err := os.ReadFile(tt.dir + "/" + filename)
@@ -78,3 +89,168 @@ func Test_mysqlMigration(t *testing.T) {
 		})
 	}
 }
+
+func Test_mysqlMigration_algorithm(t *testing.T) {
+	type TT struct {
+		description string
+		versionNums VersionNums
+		want        string
+	}
+	srcCatalog := &Catalog{
+		Dialect:       "mysql",
+		CurrentSchema: "sakila",
+		Schemas: []Schema{{
+			SchemaName: "sakila",
+			Tables: []Table{{
+				TableSchema: "sakila",
+				TableName:   "actor",
+				Columns: []Column{
+					{TableSchema: "sakila", TableName: "actor", ColumnName: "actor_id", ColumnType: "INT"},
+					{TableSchema: "sakila", TableName: "actor", ColumnName: "name", ColumnType: "VARCHAR(100)"},
+				},
+			}},
+		}},
+	}
+	destCatalog := &Catalog{
+		Dialect:       "mysql",
+		CurrentSchema: "sakila",
+		Schemas: []Schema{{
+			SchemaName: "sakila",
+			Tables: []Table{{
+				TableSchema: "sakila",
+				TableName:   "actor",
+				Columns: []Column{
+					{TableSchema: "sakila", TableName: "actor", ColumnName: "actor_id", ColumnType: "INT"},
+					{TableSchema: "sakila", TableName: "actor", ColumnName: "name", ColumnType: "VARCHAR(200)"},
+					{TableSchema: "sakila", TableName: "actor", ColumnName: "age", ColumnType: "INT"},
+				},
+			}},
+		}},
+	}
+	tests := []TT{{
+		description: "MySQL 5.5",
+		versionNums: VersionNums{5, 5},
+		want: "ALTER TABLE actor" +
+			"\n    ADD COLUMN age INT" +
+			"\n    ,MODIFY COLUMN name VARCHAR(200)" +
+			"\n;\n",
+	}, {
+		description: "MySQL 5.7",
+		versionNums: VersionNums{5, 7, 40},
+		want: "ALTER TABLE actor" +
+			"\n    ADD COLUMN age INT" +
+			"\n    ,MODIFY COLUMN name VARCHAR(200)" +
+			"\n    ,ALGORITHM=INPLACE" +
+			"\n    ,LOCK=NONE" +
+			"\n;\n",
+	}, {
+		description: "MySQL 8.0",
+		versionNums: VersionNums{8, 0, 32},
+		want: "ALTER TABLE actor" +
+			"\n    ADD COLUMN age INT" +
+			"\n    ,ALGORITHM=INSTANT" +
+			"\n;\n" +
+			"\nALTER TABLE actor" +
+			"\n    MODIFY COLUMN name VARCHAR(200)" +
+			"\n    ,ALGORITHM=INPLACE" +
+			"\n    ,LOCK=NONE" +
+			"\n;\n",
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			src := *srcCatalog
+			src.VersionNums = tt.versionNums
+			m := newMySQLMigration(&src, destCatalog, false)
+			_, bufs, _ := m.sql("algorithm")
+			if len(bufs) == 0 {
+				t.Fatal(testutil.Callers(), "no migrations generated")
+			}
+			if diff := testutil.Diff(bufs[0].String(), tt.want); diff != "" {
+				t.Error(testutil.Callers(), diff)
+			}
+		})
+	}
+}
+
+func Test_mysqlMigration_columnOrder(t *testing.T) {
+	type TT struct {
+		description string
+		versionNums VersionNums
+		want        string
+	}
+	srcCatalog := &Catalog{
+		Dialect:       "mysql",
+		CurrentSchema: "sakila",
+		Schemas: []Schema{{
+			SchemaName: "sakila",
+			Tables: []Table{{
+				TableSchema: "sakila",
+				TableName:   "actor",
+				Columns: []Column{
+					{TableSchema: "sakila", TableName: "actor", ColumnName: "actor_id", ColumnType: "INT"},
+					{TableSchema: "sakila", TableName: "actor", ColumnName: "last_name", ColumnType: "VARCHAR(45)"},
+				},
+			}},
+		}},
+	}
+	destCatalog := &Catalog{
+		Dialect:       "mysql",
+		CurrentSchema: "sakila",
+		Schemas: []Schema{{
+			SchemaName: "sakila",
+			Tables: []Table{{
+				TableSchema: "sakila",
+				TableName:   "actor",
+				Columns: []Column{
+					{TableSchema: "sakila", TableName: "actor", ColumnName: "nickname", ColumnType: "VARCHAR(45)"},
+					{TableSchema: "sakila", TableName: "actor", ColumnName: "actor_id", ColumnType: "INT"},
+					{TableSchema: "sakila", TableName: "actor", ColumnName: "first_name", ColumnType: "VARCHAR(45)"},
+					{TableSchema: "sakila", TableName: "actor", ColumnName: "last_name", ColumnType: "VARCHAR(45)"},
+					{TableSchema: "sakila", TableName: "actor", ColumnName: "age", ColumnType: "INT"},
+				},
+			}},
+		}},
+	}
+	tests := []TT{{
+		description: "MySQL 8.0.20",
+		versionNums: VersionNums{8, 0, 20},
+		want: "ALTER TABLE actor" +
+			"\n    ADD COLUMN nickname VARCHAR(45) FIRST" +
+			"\n    ,ADD COLUMN first_name VARCHAR(45) AFTER actor_id" +
+			"\n    ,ALGORITHM=INPLACE" +
+			"\n    ,LOCK=NONE" +
+			"\n;\n" +
+			"\nALTER TABLE actor" +
+			"\n    ADD COLUMN age INT" +
+			"\n    ,ALGORITHM=INSTANT" +
+			"\n;\n",
+	}, {
+		description: "MySQL 8.0.32",
+		versionNums: VersionNums{8, 0, 32},
+		want: "ALTER TABLE actor" +
+			"\n    ADD COLUMN nickname VARCHAR(45) FIRST" +
+			"\n    ,ADD COLUMN first_name VARCHAR(45) AFTER actor_id" +
+			"\n    ,ADD COLUMN age INT" +
+			"\n    ,ALGORITHM=INSTANT" +
+			"\n;\n",
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			src := *srcCatalog
+			src.VersionNums = tt.versionNums
+			m := newMySQLMigration(&src, destCatalog, false)
+			m.columnOrder = true
+			_, bufs, _ := m.sql("column_order")
+			if len(bufs) == 0 {
+				t.Fatal(testutil.Callers(), "no migrations generated")
+			}
+			if diff := testutil.Diff(bufs[0].String(), tt.want); diff != "" {
+				t.Error(testutil.Callers(), diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,970 @@
+package ddl
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type oracleMigration struct {
+	versionNums      VersionNums
+	currentSchema    string
+	defaultCollation string
+	warnings         []string
+
+	// splitBy splits the tables file per table ("table") or per step
+	// ("step").
+	splitBy string
+
+	// 0. Rename the tables, columns, indexes and constraints.
+	renames []renameOperation
+
+	// 1. Drop the foreign keys.
+	dropFkeys []*Constraint
+
+	// 2. Create (or alter) the sequences, then drop and create the tables.
+	// Oracle schemas are database users, so they are never created or dropped
+	// by a migration.
+	createSequences []*Sequence
+	alterSequences  [][2]*Sequence
+	dropTables      []*Table
+	createTables    []*Table
+
+	// 3. Execute each ALTER TABLE.
+	alterTables []oracleAlterTable
+
+	// 4. Add the foreign keys.
+	addFkeys []*Constraint
+
+	// 5. Drop the sequences once nothing depends on them.
+	dropSequences []*Sequence
+}
+
+type oracleAlterTable struct {
+	tableSchema     string
+	tableName       string
+	dropConstraints []*Constraint
+	dropIndexes     []*Index
+	dropColumns     []*Column
+	addColumns      []*Column
+	alterColumns    [][2]*Column
+	createIndexes   []*Index
+	addConstraints  []*Constraint
+
+	// Set comments on the table and columns. Each pair is made up of the src
+	// and dest objects (the src object is nil if the column is being added).
+	commentTable   [2]*Table
+	commentColumns [][2]*Column
+}
+
+// oracleBlock collects DDL statements and writes them out as a single PL/SQL
+// anonymous block. Oracle drivers can only execute one statement at a time,
+// so every statement in a migration file is wrapped in an EXECUTE IMMEDIATE
+// inside the block.
+type oracleBlock struct {
+	stmts []string
+	stmt  bytes.Buffer
+}
+
+// end ends the statement that is currently being written into b.stmt.
+func (b *oracleBlock) end() {
+	stmt := strings.TrimSpace(b.stmt.String())
+	stmt = strings.TrimSpace(strings.TrimSuffix(stmt, ";"))
+	if stmt != "" {
+		b.stmts = append(b.stmts, stmt)
+	}
+	b.stmt.Reset()
+}
+
+// writeTo writes the PL/SQL block into buf. Nothing is written if the block
+// does not contain any statements.
+func (b *oracleBlock) writeTo(buf *bytes.Buffer) {
+	b.end()
+	if len(b.stmts) == 0 {
+		return
+	}
+	buf.WriteString("BEGIN\n")
+	for _, stmt := range b.stmts {
+		buf.WriteString("    EXECUTE IMMEDIATE '" + EscapeQuote(stmt, '\'') + "';\n")
+	}
+	buf.WriteString("END;\n")
+}
+
+// writeUndoTo is like writeTo, except that every statement ignores the errors
+// raised when the object it changes does not exist (or already exists). This
+// lets an undo migration run against a partially applied migration, since
+// Oracle does not support IF [NOT] EXISTS and each DDL statement commits on
+// its own.
+func (b *oracleBlock) writeUndoTo(buf *bytes.Buffer) {
+	b.end()
+	if len(b.stmts) == 0 {
+		return
+	}
+	buf.WriteString("DECLARE\n" +
+		"    PROCEDURE exec_ddl(stmt VARCHAR2) IS\n" +
+		"    BEGIN\n" +
+		"        EXECUTE IMMEDIATE stmt;\n" +
+		"    EXCEPTION WHEN OTHERS THEN\n" +
+		"        IF SQLCODE NOT IN (" + oracleExistenceErrors + ") THEN\n" +
+		"            RAISE;\n" +
+		"        END IF;\n" +
+		"    END;\n" +
+		"BEGIN\n")
+	for _, stmt := range b.stmts {
+		buf.WriteString("    exec_ddl('" + EscapeQuote(stmt, '\'') + "');\n")
+	}
+	buf.WriteString("END;\n")
+}
+
+// oracleExistenceErrors are the error codes that Oracle raises when a DDL
+// statement refers to an object that does not exist or creates an object that
+// already exists.
+//
+//   - ORA-00904: invalid identifier (the column does not exist)
+//   - ORA-00942: table or view does not exist
+//   - ORA-00955: name is already used by an existing object
+//   - ORA-01408: such column list already indexed
+//   - ORA-01418: specified index does not exist
+//   - ORA-01430: column being added already exists in table
+//   - ORA-01442: column to be modified to NOT NULL is already NOT NULL
+//   - ORA-01451: column to be modified to NULL cannot be modified to NULL
+//   - ORA-02260: table can have only one primary key
+//   - ORA-02261: such unique or primary key already exists in the table
+//   - ORA-02264: name already used by an existing constraint
+//   - ORA-02275: such a referential constraint already exists in the table
+//   - ORA-02289: sequence does not exist
+//   - ORA-02443: cannot drop constraint - nonexistent constraint
+//   - ORA-23292: the constraint does not exist
+const oracleExistenceErrors = "-904, -942, -955, -1408, -1418, -1430, -1442, -1451, -2260, -2261, -2264, -2275, -2289, -2443, -23292"
+
+func newOracleMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) oracleMigration {
+	const dialect = DialectOracle
+	m := oracleMigration{
+		versionNums:      srcCatalog.VersionNums,
+		currentSchema:    srcCatalog.CurrentSchema,
+		defaultCollation: srcCatalog.DefaultCollation,
+	}
+	srcCatalog, m.renames, m.warnings = resolveRenames(dialect, srcCatalog, destCatalog, dropObjects)
+	srcCache, destCache := NewCatalogCache(srcCatalog), NewCatalogCache(destCatalog)
+	for i := range destCatalog.Schemas {
+		destSchema := &destCatalog.Schemas[i]
+		if destSchema.Ignore {
+			continue
+		}
+		srcSchema := srcCache.GetSchema(srcCatalog, destSchema.SchemaName)
+		for j := range destSchema.Sequences {
+			destSequence := &destSchema.Sequences[j]
+			if destSequence.Ignore {
+				continue
+			}
+			srcSequence := srcCache.GetSequence(srcSchema, destSequence.SequenceName)
+			if srcSequence == nil {
+				// CREATE SEQUENCE.
+				m.createSequences = append(m.createSequences, destSequence)
+				continue
+			}
+			// ALTER SEQUENCE.
+			if forward, _, isDifferent := diffSequences(srcSequence, destSequence); isDifferent {
+				// Oracle sequences have no data type.
+				forward.dataType = ""
+				if forward.startValue != 0 {
+					m.warnings = append(m.warnings, fmt.Sprintf("%s: changing the start value of an existing sequence is not supported, you will have to recreate the sequence manually", m.qualifiedName(destSequence.SequenceSchema, destSequence.SequenceName)))
+					forward.startValue = 0
+				}
+				if forward != (sequenceOptions{}) {
+					m.alterSequences = append(m.alterSequences, [2]*Sequence{srcSequence, destSequence})
+				}
+			}
+		}
+		if dropObjects && srcSchema != nil {
+			for j := range srcSchema.Sequences {
+				srcSequence := &srcSchema.Sequences[j]
+				if srcSequence.Ignore {
+					continue
+				}
+				if destCache.GetSequence(destSchema, srcSequence.SequenceName) == nil {
+					// DROP SEQUENCE.
+					m.dropSequences = append(m.dropSequences, srcSequence)
+				}
+			}
+		}
+	}
+	if dropObjects {
+		for i := range srcCatalog.Schemas {
+			srcSchema := &srcCatalog.Schemas[i]
+			if srcSchema.Ignore {
+				continue
+			}
+			destSchema := destCache.GetSchema(destCatalog, srcSchema.SchemaName)
+			if destSchema == nil {
+				// The schema itself is left alone, but the tables inside it
+				// are dropped.
+				for j := range srcSchema.Tables {
+					srcTable := &srcSchema.Tables[j]
+					if srcTable.Ignore {
+						continue
+					}
+					// DROP TABLE.
+					m.dropTables = append(m.dropTables, srcTable)
+					// DROP FOREIGN KEY.
+					srcFkeys := srcCache.GetForeignKeys(srcTable)
+					m.dropFkeys = append(m.dropFkeys, srcFkeys...)
+				}
+				continue
+			}
+		}
+	}
+	for i := range destCatalog.Schemas {
+		destSchema := &destCatalog.Schemas[i]
+		if destSchema.Ignore {
+			continue
+		}
+		srcSchema := srcCache.GetSchema(srcCatalog, destSchema.SchemaName)
+		if srcSchema == nil {
+			if destSchema.SchemaName != "" && destSchema.SchemaName != m.currentSchema {
+				m.warnings = append(m.warnings, fmt.Sprintf("%s: schema does not exist and will not be created (Oracle schemas are database users, create the user first)", QuoteIdentifier(dialect, destSchema.SchemaName)))
+			}
+			for j := range destSchema.Tables {
+				destTable := &destSchema.Tables[j]
+				if destTable.Ignore {
+					continue
+				}
+				// CREATE TABLE.
+				m.createTables = append(m.createTables, destTable)
+				// ADD FOREIGN KEY.
+				destFkeys := destCache.GetForeignKeys(destTable)
+				m.addFkeys = append(m.addFkeys, destFkeys...)
+			}
+			continue
+		}
+		if dropObjects {
+			for j := range srcSchema.Tables {
+				srcTable := &srcSchema.Tables[j]
+				if srcTable.Ignore {
+					continue
+				}
+				destTable := destCache.GetTable(destSchema, srcTable.TableName)
+				if destTable == nil {
+					// DROP TABLE.
+					m.dropTables = append(m.dropTables, srcTable)
+					// DROP FOREIGN KEY.
+					srcFkeys := srcCache.GetForeignKeys(srcTable)
+					m.dropFkeys = append(m.dropFkeys, srcFkeys...)
+				}
+			}
+		}
+		for j := range destSchema.Tables {
+			destTable := &destSchema.Tables[j]
+			if destTable.Ignore {
+				continue
+			}
+			srcTable := srcCache.GetTable(srcSchema, destTable.TableName)
+			if srcTable == nil {
+				// CREATE TABLE.
+				m.createTables = append(m.createTables, destTable)
+				// ADD FOREIGN KEY.
+				destFkeys := destCache.GetForeignKeys(destTable)
+				m.addFkeys = append(m.addFkeys, destFkeys...)
+				continue
+			}
+			// ALTER TABLE.
+			alterTable := oracleAlterTable{
+				tableSchema: destTable.TableSchema,
+				tableName:   destTable.TableName,
+			}
+			if srcTable.Comment != destTable.Comment {
+				// COMMENT ON TABLE.
+				alterTable.commentTable = [2]*Table{srcTable, destTable}
+			}
+			if dropObjects {
+				for k := range srcTable.Constraints {
+					srcConstraint := &srcTable.Constraints[k]
+					if srcConstraint.Ignore {
+						continue
+					}
+					destConstraint := destCache.GetConstraint(destTable, srcConstraint.ConstraintName)
+					if destConstraint == nil {
+						switch srcConstraint.ConstraintType {
+						case PRIMARY_KEY, UNIQUE, CHECK:
+							// DROP PRIMARY KEY, DROP UNIQUE, DROP CHECK.
+							alterTable.dropConstraints = append(alterTable.dropConstraints, srcConstraint)
+						case FOREIGN_KEY:
+							// DROP FOREIGN KEY.
+							m.dropFkeys = append(m.dropFkeys, srcConstraint)
+						}
+					}
+				}
+				for k := range srcTable.Indexes {
+					srcIndex := &srcTable.Indexes[k]
+					if srcIndex.Ignore {
+						continue
+					}
+					destIndex := destCache.GetIndex(destTable, srcIndex.IndexName)
+					if destIndex == nil {
+						// DROP INDEX.
+						alterTable.dropIndexes = append(alterTable.dropIndexes, srcIndex)
+					}
+				}
+				for k := range srcTable.Columns {
+					srcColumn := &srcTable.Columns[k]
+					if srcColumn.Ignore {
+						continue
+					}
+					destColumn := destCache.GetColumn(destTable, srcColumn.ColumnName)
+					if destColumn == nil {
+						// DROP COLUMN.
+						alterTable.dropColumns = append(alterTable.dropColumns, srcColumn)
+					}
+				}
+			}
+			for k := range destTable.Columns {
+				destColumn := &destTable.Columns[k]
+				if destColumn.Ignore {
+					continue
+				}
+				srcColumn := srcCache.GetColumn(srcTable, destColumn.ColumnName)
+				if srcColumn == nil {
+					// ADD COLUMN.
+					alterTable.addColumns = append(alterTable.addColumns, destColumn)
+					if destColumn.Comment != "" {
+						// COMMENT ON COLUMN.
+						alterTable.commentColumns = append(alterTable.commentColumns, [2]*Column{nil, destColumn})
+					}
+					continue
+				}
+				if srcColumn.Comment != destColumn.Comment {
+					// COMMENT ON COLUMN.
+					alterTable.commentColumns = append(alterTable.commentColumns, [2]*Column{srcColumn, destColumn})
+				}
+				columnsAreDifferent := func() bool {
+					srcType, srcArg1, srcArg2 := normalizeColumnType(dialect, srcColumn.ColumnType)
+					destType, destArg1, destArg2 := normalizeColumnType(dialect, destColumn.ColumnType)
+					if [3]string{srcType, srcArg1, srcArg2} != [3]string{destType, destArg1, destArg2} {
+						return true
+					}
+					if (srcColumn.ColumnIdentity != "" || destColumn.ColumnIdentity != "") && oracleIdentityIsDifferent(srcColumn, destColumn) {
+						return true
+					}
+					if destColumn.ColumnIdentity == "" {
+						srcDefault := normalizeColumnDefault(dialect, srcColumn.ColumnDefault)
+						destDefault := normalizeColumnDefault(dialect, destColumn.ColumnDefault)
+						if srcDefault != destDefault {
+							return true
+						}
+					}
+					if oracleIsNotNull(srcColumn) != oracleIsNotNull(destColumn) {
+						return true
+					}
+					return false
+				}()
+				if columnsAreDifferent {
+					// ALTER COLUMN.
+					alterTable.alterColumns = append(alterTable.alterColumns, [2]*Column{srcColumn, destColumn})
+				}
+			}
+			for k := range destTable.Indexes {
+				destIndex := &destTable.Indexes[k]
+				if destIndex.Ignore {
+					continue
+				}
+				srcIndex := srcCache.GetIndex(srcTable, destIndex.IndexName)
+				if srcIndex == nil {
+					// CREATE INDEX.
+					alterTable.createIndexes = append(alterTable.createIndexes, destIndex)
+				} else if indexesAreDifferent(dialect, srcIndex, destIndex) {
+					// DROP INDEX + CREATE INDEX.
+					alterTable.dropIndexes = append(alterTable.dropIndexes, srcIndex)
+					alterTable.createIndexes = append(alterTable.createIndexes, destIndex)
+				}
+			}
+			addingPrimaryKey := false
+			for k := range destTable.Constraints {
+				destConstraint := &destTable.Constraints[k]
+				if destConstraint.Ignore {
+					continue
+				}
+				srcConstraint := srcCache.GetConstraint(srcTable, destConstraint.ConstraintName)
+				if srcConstraint == nil {
+					switch destConstraint.ConstraintType {
+					case PRIMARY_KEY, UNIQUE, CHECK:
+						// ADD PRIMARY KEY, ADD UNIQUE, ADD CHECK.
+						addingPrimaryKey = addingPrimaryKey || destConstraint.ConstraintType == PRIMARY_KEY
+						alterTable.addConstraints = append(alterTable.addConstraints, destConstraint)
+					case FOREIGN_KEY:
+						// ADD FOREIGN KEY.
+						m.addFkeys = append(m.addFkeys, destConstraint)
+					}
+				}
+			}
+			// If we aren't configured to drop constraints, we have to manually
+			// drop the existing primary key if a new primary key is being
+			// added because there can only be one primary key at a time.
+			if addingPrimaryKey && !dropObjects {
+				srcPkey := srcCache.GetPrimaryKey(srcTable)
+				if srcPkey != nil {
+					alterTable.dropConstraints = append(alterTable.dropConstraints, srcPkey)
+				}
+			}
+			if len(alterTable.dropConstraints) > 0 ||
+				len(alterTable.dropIndexes) > 0 ||
+				len(alterTable.dropColumns) > 0 ||
+				len(alterTable.addColumns) > 0 ||
+				len(alterTable.alterColumns) > 0 ||
+				len(alterTable.createIndexes) > 0 ||
+				len(alterTable.addConstraints) > 0 ||
+				alterTable.commentTable[1] != nil ||
+				len(alterTable.commentColumns) > 0 {
+				m.alterTables = append(m.alterTables, alterTable)
+			}
+		}
+	}
+	return m
+}
+
+// oracleIsNotNull reports whether a column is NOT NULL. Primary key and
+// identity columns are always NOT NULL in Oracle.
+func oracleIsNotNull(column *Column) bool {
+	return column.IsNotNull || column.IsPrimaryKey || column.ColumnIdentity != ""
+}
+
+func (m *oracleMigration) sql(prefix string) (filenames []string, bufs []*bytes.Buffer, warnings []string) {
+	const dialect = DialectOracle
+	warnings = m.warnings
+	n := 0
+
+	// RENAME.
+	if len(m.renames) > 0 {
+		n++
+		// ${prefix}_${n}_renames.sql
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_renames.sql")
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		var blk oracleBlock
+		for _, rename := range m.renames {
+			m.writeRename(&blk, rename.objectType, rename.tableSchema, rename.tableName, rename.oldName, rename.newName)
+		}
+		blk.writeTo(buf)
+		// ${prefix}_${n}_renames.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		var undoblk oracleBlock
+		for i := len(m.renames) - 1; i >= 0; i-- {
+			rename := m.renames[i]
+			tableName := rename.tableName
+			if rename.objectType == "TABLE" {
+				tableName = rename.newName
+			}
+			m.writeRename(&undoblk, rename.objectType, rename.tableSchema, tableName, rename.newName, rename.oldName)
+		}
+		undoblk.writeUndoTo(undobuf)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+	}
+
+	// DROP FOREIGN KEY.
+	for _, fkey := range m.dropFkeys {
+		n++
+		name := strings.ReplaceAll(fkey.ConstraintName, " ", "_")
+		// ${prefix}_${n}_drop_${constraint}.sql
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_drop_"+name+".sql")
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		tableName := m.qualifiedName(fkey.TableSchema, fkey.TableName)
+		var blk oracleBlock
+		blk.stmt.WriteString("ALTER TABLE " + tableName + " DROP CONSTRAINT " + QuoteIdentifier(dialect, fkey.ConstraintName))
+		blk.writeTo(buf)
+		// ${prefix}_${n}_drop_${constraint}.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		var undoblk oracleBlock
+		undoblk.stmt.WriteString("ALTER TABLE " + tableName + " ADD ")
+		writeConstraintDefinition(dialect, &undoblk.stmt, m.currentSchema, fkey)
+		undoblk.writeUndoTo(undobuf)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+	}
+
+	// CREATE SEQUENCE + ALTER SEQUENCE.
+	if len(m.createSequences) > 0 || len(m.alterSequences) > 0 {
+		n++
+		// ${prefix}_${n}_sequences.sql
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_sequences.sql")
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		var blk oracleBlock
+		for _, sequence := range m.createSequences {
+			writeCreateSequence(dialect, &blk.stmt, m.currentSchema, sequence, false)
+			blk.end()
+		}
+		for _, sequences := range m.alterSequences {
+			forward, _, _ := diffSequences(sequences[0], sequences[1])
+			// Oracle cannot ALTER SEQUENCE the start value.
+			forward.dataType, forward.startValue = "", 0
+			writeAlterSequence(dialect, &blk.stmt, m.currentSchema, sequences[1], forward)
+			blk.end()
+		}
+		blk.writeTo(buf)
+		// ${prefix}_${n}_sequences.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		var undoblk oracleBlock
+		for i := len(m.alterSequences) - 1; i >= 0; i-- {
+			_, backward, _ := diffSequences(m.alterSequences[i][0], m.alterSequences[i][1])
+			backward.dataType, backward.startValue = "", 0
+			writeAlterSequence(dialect, &undoblk.stmt, m.currentSchema, m.alterSequences[i][0], backward)
+			undoblk.end()
+		}
+		for i := len(m.createSequences) - 1; i >= 0; i-- {
+			sequence := m.createSequences[i]
+			undoblk.stmt.WriteString("DROP SEQUENCE " + m.qualifiedName(sequence.SequenceSchema, sequence.SequenceName))
+			undoblk.end()
+		}
+		undoblk.writeUndoTo(undobuf)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+	}
+
+	// DROP TABLE + CREATE TABLE.
+	for _, group := range groupTables(m.splitBy, m.currentSchema, m.dropTables, m.createTables) {
+		n++
+		// ${prefix}_${n}_${group}.sql
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_"+group.name+".sql")
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		var blk oracleBlock
+		for _, table := range group.dropTables {
+			blk.stmt.WriteString("DROP TABLE " + m.qualifiedName(table.TableSchema, table.TableName))
+			blk.end()
+		}
+		for _, table := range group.createTables {
+			for i := range table.Columns {
+				warnings = append(warnings, m.identityWarnings(&table.Columns[i])...)
+			}
+			m.writeTable(&blk, table)
+		}
+		blk.writeTo(buf)
+		// ${prefix}_${n}_${group}.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		var undoblk oracleBlock
+		for i := len(group.createTables) - 1; i >= 0; i-- {
+			table := group.createTables[i]
+			undoblk.stmt.WriteString("DROP TABLE " + m.qualifiedName(table.TableSchema, table.TableName))
+			undoblk.end()
+		}
+		for _, table := range group.dropTables {
+			m.writeTable(&undoblk, table)
+		}
+		undoblk.writeUndoTo(undobuf)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+	}
+
+	// ALTER TABLE.
+	for _, alterTable := range m.alterTables {
+		n++
+		name := strings.ReplaceAll(alterTable.tableName, " ", "_")
+		if alterTable.tableSchema != "" && alterTable.tableSchema != m.currentSchema {
+			name = strings.ReplaceAll(alterTable.tableSchema, " ", "_") + "_" + name
+		}
+		tableName := m.qualifiedName(alterTable.tableSchema, alterTable.tableName)
+		// ${prefix}_${n}_alter_${table}.sql
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_alter_"+name+".sql")
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		var blk oracleBlock
+		// DROP CONSTRAINT.
+		for _, constraint := range alterTable.dropConstraints {
+			blk.stmt.WriteString("ALTER TABLE " + tableName + " DROP CONSTRAINT " + QuoteIdentifier(dialect, constraint.ConstraintName))
+			blk.end()
+		}
+		// DROP INDEX.
+		for _, index := range alterTable.dropIndexes {
+			blk.stmt.WriteString("DROP INDEX " + m.qualifiedName(index.TableSchema, index.IndexName))
+			blk.end()
+		}
+		// DROP COLUMN.
+		for _, column := range alterTable.dropColumns {
+			blk.stmt.WriteString("ALTER TABLE " + tableName + " DROP COLUMN " + QuoteIdentifier(dialect, column.ColumnName))
+			blk.end()
+		}
+		// ADD COLUMN.
+		for _, column := range alterTable.addColumns {
+			warnings = append(warnings, m.identityWarnings(column)...)
+			blk.stmt.WriteString("ALTER TABLE " + tableName + " ADD (")
+			writeColumnDefinition(dialect, &blk.stmt, m.defaultCollation, column, false)
+			blk.stmt.WriteString(")")
+			blk.end()
+		}
+		// ALTER COLUMN.
+		for _, columns := range alterTable.alterColumns {
+			srcColumn, destColumn := columns[0], columns[1]
+			columnName := destColumn.ColumnName
+			srcType, srcArg1, srcArg2 := normalizeColumnType(dialect, srcColumn.ColumnType)
+			destType, destArg1, destArg2 := normalizeColumnType(dialect, destColumn.ColumnType)
+			if [3]string{srcType, srcArg1, srcArg2} != [3]string{destType, destArg1, destArg2} {
+				switch [2]string{srcType, destType} {
+				case [2]string{"VARCHAR2", "VARCHAR2"}, [2]string{"NVARCHAR2", "NVARCHAR2"}, [2]string{"CHAR", "CHAR"}, [2]string{"RAW", "RAW"}:
+					srcLimit, _ := strconv.Atoi(srcArg1)
+					destLimit, _ := strconv.Atoi(destArg1)
+					if srcLimit > 0 && destLimit > 0 && destLimit < srcLimit {
+						warnings = append(warnings, fmt.Sprintf("%s: column %q decreasing limit from %q to %q is unsafe", tableName, columnName, srcColumn.ColumnType, destColumn.ColumnType))
+					}
+				case [2]string{"NUMBER", "NUMBER"}:
+					srcScale, _ := strconv.Atoi(srcArg2)
+					destScale, _ := strconv.Atoi(destArg2)
+					if srcScale != destScale && destScale != 0 {
+						warnings = append(warnings, fmt.Sprintf("%s: column %q changing scale from %q to %q is unsafe", tableName, columnName, srcColumn.ColumnType, destColumn.ColumnType))
+					}
+					srcPrecision, _ := strconv.Atoi(srcArg1)
+					destPrecision, _ := strconv.Atoi(destArg1)
+					if srcPrecision > 0 && destPrecision > 0 && destPrecision < srcPrecision {
+						warnings = append(warnings, fmt.Sprintf("%s: column %q decreasing precision from %q to %q is unsafe", tableName, columnName, srcColumn.ColumnType, destColumn.ColumnType))
+					}
+				default:
+					// LOB type changes are warned about by writeAlterColumn.
+					if !oracleIsLOBChange(srcType, destType) {
+						warnings = append(warnings, fmt.Sprintf("%s: column %q changing type from %q to %q may be unsafe", tableName, columnName, srcColumn.ColumnType, destColumn.ColumnType))
+					}
+				}
+			}
+			warnings = append(warnings, m.writeAlterColumn(&blk, tableName, srcColumn, destColumn)...)
+		}
+		// CREATE INDEX.
+		for _, index := range alterTable.createIndexes {
+			writeCreateIndex(dialect, &blk.stmt, m.currentSchema, index, false, false)
+			blk.end()
+		}
+		// ADD CONSTRAINT.
+		for _, constraint := range alterTable.addConstraints {
+			blk.stmt.WriteString("ALTER TABLE " + tableName + " ADD ")
+			writeConstraintDefinition(dialect, &blk.stmt, m.currentSchema, constraint)
+			blk.end()
+		}
+		// COMMENT ON.
+		if table := alterTable.commentTable[1]; table != nil {
+			m.writeComment(&blk, "TABLE", alterTable.tableSchema, alterTable.tableName, "", table.Comment)
+		}
+		for _, columns := range alterTable.commentColumns {
+			column := columns[1]
+			m.writeComment(&blk, "COLUMN", alterTable.tableSchema, alterTable.tableName, column.ColumnName, column.Comment)
+		}
+		blk.writeTo(buf)
+		// ${prefix}_${n}_alter_${table}.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		warnings = append(warnings, m.writeUndoAlterTable(undobuf, tableName, alterTable)...)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+	}
+
+	// ADD FOREIGN KEY.
+	for _, fkey := range m.addFkeys {
+		n++
+		name := strings.ReplaceAll(fkey.ConstraintName, " ", "_")
+		// ${prefix}_${n}_add_${constraint}.sql
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_add_"+name+".sql")
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		tableName := m.qualifiedName(fkey.TableSchema, fkey.TableName)
+		if fkey.UpdateRule != "" && fkey.UpdateRule != NO_ACTION {
+			warnings = append(warnings, fmt.Sprintf("%s: constraint %q ON UPDATE %s is not supported by Oracle and will be ignored", tableName, fkey.ConstraintName, fkey.UpdateRule))
+		}
+		var blk oracleBlock
+		blk.stmt.WriteString("ALTER TABLE " + tableName + " ADD ")
+		writeConstraintDefinition(dialect, &blk.stmt, m.currentSchema, fkey)
+		blk.writeTo(buf)
+		// ${prefix}_${n}_add_${constraint}.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		var undoblk oracleBlock
+		undoblk.stmt.WriteString("ALTER TABLE " + tableName + " DROP CONSTRAINT " + QuoteIdentifier(dialect, fkey.ConstraintName))
+		undoblk.writeUndoTo(undobuf)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+	}
+
+	// DROP SEQUENCE.
+	if len(m.dropSequences) > 0 {
+		n++
+		// ${prefix}_${n}_drop_sequences.sql
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_drop_sequences.sql")
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		var blk oracleBlock
+		for _, sequence := range m.dropSequences {
+			blk.stmt.WriteString("DROP SEQUENCE " + m.qualifiedName(sequence.SequenceSchema, sequence.SequenceName))
+			blk.end()
+		}
+		blk.writeTo(buf)
+		// ${prefix}_${n}_drop_sequences.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		var undoblk oracleBlock
+		for i := len(m.dropSequences) - 1; i >= 0; i-- {
+			sequence := m.dropSequences[i]
+			writeCreateSequence(dialect, &undoblk.stmt, m.currentSchema, sequence, false)
+			undoblk.end()
+		}
+		undoblk.writeUndoTo(undobuf)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+	}
+
+	return filenames, bufs, warnings
+}
+
+// qualifiedName returns the quoted name of a table, index or sequence,
+// qualified with its schema if it does not belong to the current schema.
+func (m *oracleMigration) qualifiedName(schemaName, name string) string {
+	const dialect = DialectOracle
+	if schemaName != "" && schemaName != m.currentSchema {
+		return QuoteIdentifier(dialect, schemaName) + "." + QuoteIdentifier(dialect, name)
+	}
+	return QuoteIdentifier(dialect, name)
+}
+
+// identityWarnings returns a warning if the column is an identity column and
+// the database does not support identity columns (Oracle 12c+ only).
+func (m *oracleMigration) identityWarnings(column *Column) (warnings []string) {
+	if column.Ignore || column.ColumnIdentity == "" || !m.versionNums.LowerThan(12) {
+		return nil
+	}
+	tableName := m.qualifiedName(column.TableSchema, column.TableName)
+	return []string{fmt.Sprintf("%s: column %q uses %q which is only supported in Oracle 12c+", tableName, column.ColumnName, column.ColumnIdentity)}
+}
+
+// writeTable writes the CREATE TABLE statement for a table, followed by its
+// CREATE INDEX statements and the comments on the table and its columns.
+func (m *oracleMigration) writeTable(blk *oracleBlock, table *Table) {
+	const dialect = DialectOracle
+	writeCreateTable(dialect, &blk.stmt, m.currentSchema, m.defaultCollation, table, true, false)
+	blk.end()
+	for _, index := range table.Indexes {
+		if index.Ignore {
+			continue
+		}
+		writeCreateIndex(dialect, &blk.stmt, m.currentSchema, &index, false, false)
+		blk.end()
+	}
+	if table.Comment != "" {
+		m.writeComment(blk, "TABLE", table.TableSchema, table.TableName, "", table.Comment)
+	}
+	for _, column := range table.Columns {
+		if column.Ignore || column.Comment == "" {
+			continue
+		}
+		m.writeComment(blk, "COLUMN", table.TableSchema, table.TableName, column.ColumnName, column.Comment)
+	}
+}
+
+// oracleIdentityIsDifferent reports whether the identity definition (including
+// its START WITH and INCREMENT BY options) of two columns differ.
+func oracleIdentityIsDifferent(fromColumn, toColumn *Column) bool {
+	if fromColumn.ColumnIdentity != toColumn.ColumnIdentity {
+		return true
+	}
+	fromStart, fromIncrement := identityOptions(fromColumn)
+	toStart, toIncrement := identityOptions(toColumn)
+	return fromStart != toStart || fromIncrement != toIncrement
+}
+
+// writeAlterColumn writes the statements that change a column from
+// fromColumn to toColumn. It returns warnings for every change that cannot be
+// made.
+func (m *oracleMigration) writeAlterColumn(blk *oracleBlock, tableName string, fromColumn, toColumn *Column) (warnings []string) {
+	const dialect = DialectOracle
+	columnName := QuoteIdentifier(dialect, toColumn.ColumnName)
+	// Do we need to remove IDENTITY? Or do we need to change it?
+	if fromColumn.ColumnIdentity != "" && toColumn.ColumnIdentity == "" {
+		blk.stmt.WriteString("ALTER TABLE " + tableName + " MODIFY (" + columnName + " DROP IDENTITY)")
+		blk.end()
+	} else if fromColumn.ColumnIdentity == "" && toColumn.ColumnIdentity != "" {
+		warnings = append(warnings, fmt.Sprintf("%s: column %q cannot be changed to %q (Oracle can only add identity columns, not convert existing columns into them)", tableName, toColumn.ColumnName, toColumn.ColumnIdentity))
+	} else if fromColumn.ColumnIdentity != "" && oracleIdentityIsDifferent(fromColumn, toColumn) {
+		identity := toColumn.ColumnIdentity
+		fromStart, fromIncrement := identityOptions(fromColumn)
+		toStart, toIncrement := identityOptions(toColumn)
+		if fromStart != toStart || fromIncrement != toIncrement {
+			// Spell out both options so that an option being reset to its
+			// default is also applied.
+			identity += " (START WITH " + strconv.FormatInt(toStart, 10) + " INCREMENT BY " + strconv.FormatInt(toIncrement, 10) + ")"
+		}
+		blk.stmt.WriteString("ALTER TABLE " + tableName + " MODIFY (" + columnName + " " + identity + ")")
+		blk.end()
+	}
+	var clauses []string
+	// Do we need to change the type?
+	fromType, fromArg1, fromArg2 := normalizeColumnType(dialect, fromColumn.ColumnType)
+	toType, toArg1, toArg2 := normalizeColumnType(dialect, toColumn.ColumnType)
+	if [3]string{fromType, fromArg1, fromArg2} != [3]string{toType, toArg1, toArg2} {
+		if oracleIsLOBChange(fromType, toType) {
+			warnings = append(warnings, fmt.Sprintf("%s: column %q cannot be changed from %q to %q (Oracle cannot MODIFY a column into or out of a LOB type), add a new column, copy the data over and drop the old column instead", tableName, toColumn.ColumnName, fromColumn.ColumnType, toColumn.ColumnType))
+		} else {
+			clauses = append(clauses, strings.ToUpper(toColumn.ColumnType))
+		}
+	}
+	// Do we need to change the DEFAULT?
+	if toColumn.ColumnIdentity == "" {
+		fromDefault := normalizeColumnDefault(dialect, fromColumn.ColumnDefault)
+		toDefault := normalizeColumnDefault(dialect, toColumn.ColumnDefault)
+		if fromColumn.ColumnIdentity != "" {
+			fromDefault = ""
+		}
+		if fromDefault != toDefault {
+			if toColumn.ColumnDefault == "" {
+				clauses = append(clauses, "DEFAULT NULL")
+			} else {
+				clauses = append(clauses, "DEFAULT "+toColumn.ColumnDefault)
+			}
+		}
+	}
+	// Do we need to change the nullability? Dropping an identity does not drop
+	// its NOT NULL constraint, so the column is still NOT NULL after that.
+	fromIsNotNull := oracleIsNotNull(fromColumn)
+	toIsNotNull := oracleIsNotNull(toColumn)
+	if fromColumn.ColumnIdentity != "" && toColumn.ColumnIdentity == "" {
+		fromIsNotNull = true
+	}
+	if fromIsNotNull != toIsNotNull {
+		if toIsNotNull {
+			clauses = append(clauses, "NOT NULL")
+		} else {
+			clauses = append(clauses, "NULL")
+		}
+	}
+	if len(clauses) > 0 {
+		blk.stmt.WriteString("ALTER TABLE " + tableName + " MODIFY (" + columnName + " " + strings.Join(clauses, " ") + ")")
+		blk.end()
+	}
+	return warnings
+}
+
+// oracleIsLOBChange reports whether changing a column from fromType to
+// toType (as normalized by normalizeColumnType) converts it into or out of a
+// LOB type, which ALTER TABLE MODIFY cannot do (ORA-22858, ORA-22859). The
+// only exceptions are LONG to CLOB/NCLOB and LONG RAW to BLOB.
+func oracleIsLOBChange(fromType, toType string) bool {
+	isLOB := func(typ string) bool {
+		return typ == "CLOB" || typ == "NCLOB" || typ == "BLOB"
+	}
+	if isLOB(fromType) == isLOB(toType) {
+		return false
+	}
+	switch [2]string{fromType, toType} {
+	case [2]string{"LONG", "CLOB"}, [2]string{"LONG", "NCLOB"}, [2]string{"LONG RAW", "BLOB"}:
+		return false
+	}
+	return true
+}
+
+// writeUndoAlterTable writes the statements that revert the changes made by
+// an ALTER TABLE migration, in reverse order. It returns warnings for every
+// change that cannot be fully reverted.
+func (m *oracleMigration) writeUndoAlterTable(buf *bytes.Buffer, tableName string, alterTable oracleAlterTable) (warnings []string) {
+	const dialect = DialectOracle
+	var blk oracleBlock
+	// COMMENT ON.
+	for i := len(alterTable.commentColumns) - 1; i >= 0; i-- {
+		srcColumn := alterTable.commentColumns[i][0]
+		if srcColumn == nil {
+			// The column is being added, so its comment will be dropped
+			// together with the column.
+			continue
+		}
+		m.writeComment(&blk, "COLUMN", alterTable.tableSchema, alterTable.tableName, srcColumn.ColumnName, srcColumn.Comment)
+	}
+	if table := alterTable.commentTable[0]; table != nil {
+		m.writeComment(&blk, "TABLE", alterTable.tableSchema, alterTable.tableName, "", table.Comment)
+	}
+	// ADD CONSTRAINT.
+	for i := len(alterTable.addConstraints) - 1; i >= 0; i-- {
+		blk.stmt.WriteString("ALTER TABLE " + tableName + " DROP CONSTRAINT " + QuoteIdentifier(dialect, alterTable.addConstraints[i].ConstraintName))
+		blk.end()
+	}
+	// CREATE INDEX.
+	for i := len(alterTable.createIndexes) - 1; i >= 0; i-- {
+		index := alterTable.createIndexes[i]
+		blk.stmt.WriteString("DROP INDEX " + m.qualifiedName(index.TableSchema, index.IndexName))
+		blk.end()
+	}
+	// ALTER COLUMN.
+	for i := len(alterTable.alterColumns) - 1; i >= 0; i-- {
+		srcColumn, destColumn := alterTable.alterColumns[i][0], alterTable.alterColumns[i][1]
+		if srcColumn.ColumnIdentity == "" && destColumn.ColumnIdentity != "" {
+			// The identity was never added to the column, so there is no
+			// identity to drop.
+			column := *destColumn
+			column.ColumnIdentity = ""
+			destColumn = &column
+		}
+		srcType, _, _ := normalizeColumnType(dialect, srcColumn.ColumnType)
+		destType, _, _ := normalizeColumnType(dialect, destColumn.ColumnType)
+		if oracleIsLOBChange(srcType, destType) {
+			// The type was never changed, so there is no type to change back.
+			column := *destColumn
+			column.ColumnType = srcColumn.ColumnType
+			destColumn = &column
+		}
+		warnings = append(warnings, m.writeAlterColumn(&blk, tableName, destColumn, srcColumn)...)
+	}
+	// ADD COLUMN.
+	for i := len(alterTable.addColumns) - 1; i >= 0; i-- {
+		blk.stmt.WriteString("ALTER TABLE " + tableName + " DROP COLUMN " + QuoteIdentifier(dialect, alterTable.addColumns[i].ColumnName))
+		blk.end()
+	}
+	// DROP COLUMN.
+	for _, column := range alterTable.dropColumns {
+		blk.stmt.WriteString("ALTER TABLE " + tableName + " ADD (")
+		writeColumnDefinition(dialect, &blk.stmt, m.defaultCollation, column, false)
+		blk.stmt.WriteString(")")
+		blk.end()
+		if column.Comment != "" {
+			m.writeComment(&blk, "COLUMN", alterTable.tableSchema, alterTable.tableName, column.ColumnName, column.Comment)
+		}
+	}
+	// DROP INDEX.
+	for _, index := range alterTable.dropIndexes {
+		writeCreateIndex(dialect, &blk.stmt, m.currentSchema, index, false, false)
+		blk.end()
+	}
+	// DROP CONSTRAINT.
+	for _, constraint := range alterTable.dropConstraints {
+		blk.stmt.WriteString("ALTER TABLE " + tableName + " ADD ")
+		writeConstraintDefinition(dialect, &blk.stmt, m.currentSchema, constraint)
+		blk.end()
+	}
+	blk.writeUndoTo(buf)
+	return warnings
+}
+
+// writeRename writes the statement that renames a table, column, index or
+// constraint from oldName to newName.
+func (m *oracleMigration) writeRename(blk *oracleBlock, objectType, tableSchema, tableName, oldName, newName string) {
+	const dialect = DialectOracle
+	switch objectType {
+	case "TABLE":
+		blk.stmt.WriteString("ALTER TABLE " + m.qualifiedName(tableSchema, oldName) + " RENAME TO " + QuoteIdentifier(dialect, newName))
+	case "COLUMN":
+		blk.stmt.WriteString("ALTER TABLE " + m.qualifiedName(tableSchema, tableName) + " RENAME COLUMN " + QuoteIdentifier(dialect, oldName) + " TO " + QuoteIdentifier(dialect, newName))
+	case "INDEX":
+		blk.stmt.WriteString("ALTER INDEX " + m.qualifiedName(tableSchema, oldName) + " RENAME TO " + QuoteIdentifier(dialect, newName))
+	case "CONSTRAINT":
+		blk.stmt.WriteString("ALTER TABLE " + m.qualifiedName(tableSchema, tableName) + " RENAME CONSTRAINT " + QuoteIdentifier(dialect, oldName) + " TO " + QuoteIdentifier(dialect, newName))
+	}
+	blk.end()
+}
+
+// writeComment writes the COMMENT ON statement for a table or column. Oracle
+// does not support comments on indexes or constraints.
+func (m *oracleMigration) writeComment(blk *oracleBlock, objectType, tableSchema, tableName, columnName, comment string) {
+	const dialect = DialectOracle
+	blk.stmt.WriteString("COMMENT ON " + objectType + " " + m.qualifiedName(tableSchema, tableName))
+	if objectType == "COLUMN" {
+		blk.stmt.WriteString("." + QuoteIdentifier(dialect, columnName))
+	}
+	// Oracle treats the empty string as NULL, which removes the comment.
+	blk.stmt.WriteString(" IS '" + EscapeQuote(comment, '\'') + "'")
+	blk.end()
+}
@@ -0,0 +1,182 @@
+package ddl
+
+import (
+	"io"
+	"testing"
+	"testing/fstest"
+	"unicode/utf8"
+
+	"github.com/bokwoon95/sqddl/internal/testutil"
+)
+
+func TestPlanCmd(t *testing.T) {
+	type TT struct {
+		description string
+		versionNums VersionNums
+		script      string
+		want        []PlanStatement
+	}
+
+	assert := func(t *testing.T, tt TT) {
+		planCmd := &PlanCmd{
+			SrcCatalog: &Catalog{Dialect: DialectPostgres, VersionNums: tt.versionNums},
+			DirFS: fstest.MapFS{
+				"01_migration.sql": &fstest.MapFile{Data: []byte(tt.script)},
+			},
+			Filenames: []string{"01_migration.sql"},
+			Stdout:    io.Discard,
+		}
+		statements, err := planCmd.Results()
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		for i := range statements {
+			statements[i].Filename = ""
+			statements[i].Statement = ""
+			statements[i].Notes = nil
+		}
+		if diff := testutil.Diff(statements, tt.want); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+	}
+
+	tests := []TT{{
+		description: "add column with default (Postgres 10)",
+		versionNums: VersionNums{10},
+		script:      "ALTER TABLE actor ADD COLUMN score INT DEFAULT 0;",
+		want: []PlanStatement{
+			{Table: "actor", LockLevel: "ACCESS EXCLUSIVE", Blocks: "reads and writes", Duration: "table rewrite", Rewrite: true},
+		},
+	}, {
+		description: "add column with default (Postgres 11)",
+		versionNums: VersionNums{11},
+		script:      "ALTER TABLE actor ADD COLUMN score INT DEFAULT 0;",
+		want: []PlanStatement{
+			{Table: "actor", LockLevel: "ACCESS EXCLUSIVE", Blocks: "reads and writes", Duration: "instant"},
+		},
+	}, {
+		description: "add column with volatile default",
+		script:      "ALTER TABLE actor ADD COLUMN token UUID DEFAULT gen_random_uuid();",
+		want: []PlanStatement{
+			{Table: "actor", LockLevel: "ACCESS EXCLUSIVE", Blocks: "reads and writes", Duration: "table rewrite", Rewrite: true},
+		},
+	}, {
+		description: "indexes",
+		script: "CREATE INDEX actor_name_idx ON actor (last_name, first_name);\n" +
+			"CREATE INDEX CONCURRENTLY film_title_idx ON public.film USING btree (title);\n" +
+			"DROP INDEX CONCURRENTLY IF EXISTS film_title_idx;",
+		want: []PlanStatement{
+			{Table: "actor", LockLevel: "SHARE", Blocks: "writes", Duration: "index build"},
+			{Table: "public.film", LockLevel: "SHARE UPDATE EXCLUSIVE", Blocks: "schema changes", Duration: "index build", Concurrent: true},
+			{Table: "film_title_idx", LockLevel: "SHARE UPDATE EXCLUSIVE", Blocks: "schema changes", Duration: "instant", Concurrent: true},
+		},
+	}, {
+		description: "constraints",
+		script: "ALTER TABLE film ADD CONSTRAINT film_language_id_fkey FOREIGN KEY (language_id) REFERENCES language (language_id) NOT VALID;\n" +
+			"ALTER TABLE film VALIDATE CONSTRAINT film_language_id_fkey;\n" +
+			"ALTER TABLE film ADD CONSTRAINT film_length_check CHECK (length BETWEEN 1 AND 1000);",
+		want: []PlanStatement{
+			{Table: "film", LockLevel: "SHARE ROW EXCLUSIVE", Blocks: "writes", Duration: "instant"},
+			{Table: "film", LockLevel: "SHARE UPDATE EXCLUSIVE", Blocks: "schema changes", Duration: "table scan", Concurrent: true},
+			{Table: "film", LockLevel: "ACCESS EXCLUSIVE", Blocks: "reads and writes", Duration: "table scan"},
+		},
+	}, {
+		description: "multiple subcommands",
+		script:      "ALTER TABLE film ADD COLUMN price NUMERIC(10, 2), ALTER COLUMN title SET STATISTICS 500, ALTER COLUMN title SET NOT NULL, SET (fillfactor = 70);",
+		want: []PlanStatement{
+			{Table: "film", LockLevel: "ACCESS EXCLUSIVE", Blocks: "reads and writes", Duration: "table scan"},
+		},
+	}, {
+		description: "attach partition",
+		versionNums: VersionNums{12, 4},
+		script:      "ALTER TABLE payment ATTACH PARTITION payment_p2022 FOR VALUES FROM ('2022-01-01') TO ('2023-01-01');",
+		want: []PlanStatement{
+			{Table: "payment", LockLevel: "SHARE UPDATE EXCLUSIVE", Blocks: "schema changes", Duration: "table scan", Concurrent: true},
+		},
+	}, {
+		description: "non ddl statements",
+		script: "-- comment; with a semicolon\n" +
+			"CREATE TABLE IF NOT EXISTS tag (tag_id INT, name TEXT);\n" +
+			"UPDATE film SET description = 'it''s; a film' WHERE film_id < 100;\n" +
+			"DO $$ BEGIN PERFORM 1; END $$;\n" +
+			"COMMENT ON TABLE film IS 'films';",
+		want: []PlanStatement{
+			{Table: "tag", LockLevel: "ACCESS EXCLUSIVE", Blocks: "nothing", Duration: "instant", Concurrent: true},
+			{Table: "film", LockLevel: "ROW EXCLUSIVE", Blocks: "nothing", Duration: "rows touched", Concurrent: true},
+			{Blocks: "nothing", Duration: "unknown", Concurrent: true},
+			{Table: "film", LockLevel: "SHARE UPDATE EXCLUSIVE", Blocks: "schema changes", Duration: "instant", Concurrent: true},
+		},
+	}}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			assert(t, tt)
+		})
+	}
+
+	t.Run("alter column type", func(t *testing.T) {
+		t.Parallel()
+		planCmd := &PlanCmd{
+			SrcCatalog: &Catalog{
+				Dialect: DialectPostgres,
+				Schemas: []Schema{{
+					SchemaName: "",
+					Tables: []Table{{
+						TableName: "film",
+						Columns: []Column{
+							{TableName: "film", ColumnName: "title", ColumnType: "VARCHAR(255)"},
+							{TableName: "film", ColumnName: "rating", ColumnType: "TEXT"},
+						},
+					}},
+				}},
+			},
+			DirFS: fstest.MapFS{
+				"01_migration.sql": &fstest.MapFile{Data: []byte(
+					"ALTER TABLE film ALTER COLUMN title TYPE TEXT;\n" +
+						"ALTER TABLE film ALTER COLUMN rating TYPE VARCHAR(10);\n" +
+						"ALTER TABLE film ALTER COLUMN title TYPE VARCHAR(300) USING title::VARCHAR(300);\n",
+				)},
+			},
+			Filenames: []string{"01_migration.sql"},
+			Stdout:    io.Discard,
+		}
+		statements, err := planCmd.Results()
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		var gotRewrites []bool
+		for _, s := range statements {
+			gotRewrites = append(gotRewrites, s.Rewrite)
+		}
+		wantRewrites := []bool{false, true, true}
+		if diff := testutil.Diff(gotRewrites, wantRewrites); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+	})
+
+	t.Run("summary", func(t *testing.T) {
+		t.Parallel()
+		// Every character in the comment is 3 bytes long, so truncating by
+		// bytes would cut one in half.
+		stmt := "COMMENT ON COLUMN film.title IS\n    '映画のタイトル。映画のタイトル。映画のタイトル。映画のタイトル。';"
+		gotSummary := planSummary(stmt)
+		wantSummary := "COMMENT ON COLUMN film.title IS '映画のタイトル。映画のタイトル。映画のタイトル。..."
+		if diff := testutil.Diff(gotSummary, wantSummary); diff != "" {
+			t.Error(testutil.Callers(), diff)
+		}
+		if !utf8.ValidString(gotSummary) {
+			t.Errorf(testutil.Callers()+" invalid UTF-8 in summary %q", gotSummary)
+		}
+	})
+
+	t.Run("unsupported dialect", func(t *testing.T) {
+		t.Parallel()
+		planCmd := &PlanCmd{Dialect: DialectMySQL, Stdout: io.Discard}
+		_, err := planCmd.Results()
+		if err == nil {
+			t.Fatal(testutil.Callers(), "expected error, got nil")
+		}
+	})
+}
@@ -13,16 +13,28 @@ import (
 
 func Test_postgresMigration(t *testing.T) {
 	type TT struct {
-		dir         string
-		dropObjects bool
+		dir          string
+		dropObjects  bool
+		zeroDowntime bool
 	}
 	tests := []TT{
-		{"testdata/postgres_schema", true},
-		{"testdata/postgres_table", true},
-		{"testdata/postgres_drop", true},
-		{"testdata/postgres_add", false},
-		{"testdata/postgres_alter", false},
-		{"testdata/postgres_ignore", true},
+		{"testdata/postgres_schema", true, false},
+		{"testdata/postgres_table", true, false},
+		{"testdata/postgres_drop", true, false},
+		{"testdata/postgres_add", false, false},
+		{"testdata/postgres_alter", false, false},
+		{"testdata/postgres_ignore", true, false},
+		{"testdata/postgres_comment", false, false},
+		{"testdata/postgres_rename", true, false},
+		{"testdata/postgres_extension", true, false},
+		{"testdata/postgres_check", true, false},
+		{"testdata/postgres_index", false, false},
+		{"testdata/postgres_exclude", true, false},
+		{"testdata/postgres_partition", true, false},
+		{"testdata/postgres_sequence", true, false},
+		{"testdata/postgres_identity", false, false},
+		{"testdata/postgres_options", true, false},
+		{"testdata/postgres_zero_downtime", true, true},
 	}
 	newCatalog := func(t *testing.T, filename string) *Catalog {
 		file, err := os.Open(filename)
@@ -49,6 +61,9 @@ func Test_postgresMigration(t *testing.T) {
 			srcCatalog := newCatalog(t, tt.dir+"/src.go.txt")
 			destCatalog := newCatalog(t, tt.dir+"/dest.go.txt")
 			m := newPostgresMigration(srcCatalog, destCatalog, tt.dropObjects)
+			if tt.zeroDowntime {
+				m.planExpandContract(tt.dropObjects)
+			}
 			filenames, bufs, warnings := m.sql(strings.TrimPrefix(filepath.Base(tt.dir), "postgres_"))
 			for i, filename := range filenames {
 				b, err := os.ReadFile(tt.dir + "/" + filename)
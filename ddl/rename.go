@@ -0,0 +1,431 @@
+package ddl
+
+import (
+	"fmt"
+)
+
+// renameOperation represents the renaming of a table, column, index or
+// constraint.
+type renameOperation struct {
+	// objectType is one of "TABLE", "COLUMN", "INDEX" or "CONSTRAINT".
+	objectType string
+
+	// tableSchema is the schema of the table.
+	tableSchema string
+
+	// tableName is the name of the table. For a table rename it is the name
+	// of the table before the rename, otherwise it is the name of the table
+	// after any table renames have been applied.
+	tableName string
+
+	// oldName is the name of the object before the rename.
+	oldName string
+
+	// newName is the name of the object after the rename.
+	newName string
+
+	// column is the dest column of a column rename.
+	column *Column
+}
+
+// resolveRenames figures out which tables and columns in the srcCatalog are
+// being renamed (as indicated by the RenamedFrom field of the tables and
+// columns in the destCatalog). It returns a copy of the srcCatalog with the
+// renames applied so that the migration builders can diff it against the
+// destCatalog as usual, together with the list of renames that need to be
+// executed beforehand.
+//
+// Indexes and constraints whose names were generated by GenerateName are
+// renamed along with their table and columns, as long as the dialect
+// supports it.
+//
+// If dropObjects is true, resolveRenames also returns warnings for tables and
+// columns that look like they were renamed (i.e. an object is dropped and
+// another object with an identical definition is created) but are missing
+// the renamedfrom modifier.
+func resolveRenames(dialect string, srcCatalog, destCatalog *Catalog, dropObjects bool) (renamedCatalog *Catalog, renames []renameOperation, warnings []string) {
+	renamedCatalog = srcCatalog
+	if hasRenames(destCatalog) {
+		// Writing into an empty catalog never fails.
+		renamedCatalog = &Catalog{}
+		_ = srcCatalog.WriteCatalog(renamedCatalog)
+	}
+	srcCache, destCache := NewCatalogCache(renamedCatalog), NewCatalogCache(destCatalog)
+	isGeneratedIndex := make(map[*Index]bool)
+	isGeneratedConstraint := make(map[*Constraint]bool)
+	for i := range renamedCatalog.Schemas {
+		schema := &renamedCatalog.Schemas[i]
+		for j := range schema.Tables {
+			table := &schema.Tables[j]
+			for k := range table.Indexes {
+				index := &table.Indexes[k]
+				isGeneratedIndex[index] = index.IndexName == GenerateName(INDEX, table.TableName, index.Columns)
+			}
+			for k := range table.Constraints {
+				constraint := &table.Constraints[k]
+				isGeneratedConstraint[constraint] = constraint.ConstraintName == GenerateName(constraint.ConstraintType, table.TableName, constraint.Columns)
+			}
+		}
+	}
+	for i := range destCatalog.Schemas {
+		destSchema := &destCatalog.Schemas[i]
+		if destSchema.Ignore {
+			continue
+		}
+		srcSchema := srcCache.GetSchema(renamedCatalog, destSchema.SchemaName)
+		if srcSchema == nil {
+			continue
+		}
+		isRenamed := make(map[*Table]bool)
+		// RENAME TABLE.
+		for j := range destSchema.Tables {
+			destTable := &destSchema.Tables[j]
+			if destTable.Ignore || destTable.RenamedFrom == "" {
+				continue
+			}
+			if srcCache.GetTable(srcSchema, destTable.TableName) != nil || destCache.GetTable(destSchema, destTable.RenamedFrom) != nil {
+				continue
+			}
+			srcTable := srcCache.GetTable(srcSchema, destTable.RenamedFrom)
+			if srcTable == nil {
+				continue
+			}
+			renames = append(renames, renameOperation{
+				objectType:  "TABLE",
+				tableSchema: srcTable.TableSchema,
+				tableName:   srcTable.TableName,
+				oldName:     srcTable.TableName,
+				newName:     destTable.TableName,
+			})
+			renameTable(renamedCatalog, srcTable, destTable.TableName)
+			srcCache = NewCatalogCache(renamedCatalog)
+			isRenamed[srcTable] = true
+		}
+		// RENAME COLUMN.
+		for j := range destSchema.Tables {
+			destTable := &destSchema.Tables[j]
+			if destTable.Ignore {
+				continue
+			}
+			srcTable := srcCache.GetTable(srcSchema, destTable.TableName)
+			if srcTable == nil {
+				continue
+			}
+			for k := range destTable.Columns {
+				destColumn := &destTable.Columns[k]
+				if destColumn.Ignore || destColumn.RenamedFrom == "" {
+					continue
+				}
+				if srcCache.GetColumn(srcTable, destColumn.ColumnName) != nil || destCache.GetColumn(destTable, destColumn.RenamedFrom) != nil {
+					continue
+				}
+				srcColumn := srcCache.GetColumn(srcTable, destColumn.RenamedFrom)
+				if srcColumn == nil {
+					continue
+				}
+				renames = append(renames, renameOperation{
+					objectType:  "COLUMN",
+					tableSchema: srcTable.TableSchema,
+					tableName:   srcTable.TableName,
+					oldName:     srcColumn.ColumnName,
+					newName:     destColumn.ColumnName,
+					column:      destColumn,
+				})
+				renameColumn(renamedCatalog, srcTable, srcColumn, destColumn.ColumnName)
+				srcCache = NewCatalogCache(renamedCatalog)
+				isRenamed[srcTable] = true
+			}
+		}
+		// SQLite does not support renaming indexes, and its constraints are
+		// unnamed.
+		if dialect == DialectSQLite {
+			continue
+		}
+		// RENAME INDEX, RENAME CONSTRAINT.
+		for j := range srcSchema.Tables {
+			srcTable := &srcSchema.Tables[j]
+			if !isRenamed[srcTable] {
+				continue
+			}
+			destTable := destCache.GetTable(destSchema, srcTable.TableName)
+			if destTable == nil {
+				continue
+			}
+			for k := range srcTable.Indexes {
+				srcIndex := &srcTable.Indexes[k]
+				if srcIndex.Ignore || !isGeneratedIndex[srcIndex] {
+					continue
+				}
+				indexName := GenerateName(INDEX, srcTable.TableName, srcIndex.Columns)
+				if indexName == srcIndex.IndexName || srcCache.GetIndex(srcTable, indexName) != nil || destCache.GetIndex(destTable, indexName) == nil {
+					continue
+				}
+				renames = append(renames, renameOperation{
+					objectType:  "INDEX",
+					tableSchema: srcTable.TableSchema,
+					tableName:   srcTable.TableName,
+					oldName:     srcIndex.IndexName,
+					newName:     indexName,
+				})
+				srcIndex.IndexName = indexName
+				srcCache = NewCatalogCache(renamedCatalog)
+			}
+			for k := range srcTable.Constraints {
+				srcConstraint := &srcTable.Constraints[k]
+				if srcConstraint.Ignore || !isGeneratedConstraint[srcConstraint] {
+					continue
+				}
+				// MySQL can only rename the index backing a UNIQUE constraint.
+				if dialect == DialectMySQL && srcConstraint.ConstraintType != UNIQUE {
+					continue
+				}
+				constraintName := GenerateName(srcConstraint.ConstraintType, srcTable.TableName, srcConstraint.Columns)
+				if constraintName == srcConstraint.ConstraintName || srcCache.GetConstraint(srcTable, constraintName) != nil || destCache.GetConstraint(destTable, constraintName) == nil {
+					continue
+				}
+				renames = append(renames, renameOperation{
+					objectType:  "CONSTRAINT",
+					tableSchema: srcTable.TableSchema,
+					tableName:   srcTable.TableName,
+					oldName:     srcConstraint.ConstraintName,
+					newName:     constraintName,
+				})
+				srcConstraint.ConstraintName = constraintName
+				srcCache = NewCatalogCache(renamedCatalog)
+			}
+		}
+	}
+	if dropObjects {
+		warnings = renameWarnings(dialect, renamedCatalog, destCatalog)
+	}
+	return renamedCatalog, renames, warnings
+}
+
+// renameWarnings returns warnings for every dropped table or column that
+// looks like it was renamed instead.
+func renameWarnings(dialect string, srcCatalog, destCatalog *Catalog) (warnings []string) {
+	srcCache, destCache := NewCatalogCache(srcCatalog), NewCatalogCache(destCatalog)
+	qualifiedName := func(tableSchema, tableName string) string {
+		if tableSchema != "" && tableSchema != srcCatalog.CurrentSchema {
+			return QuoteIdentifier(dialect, tableSchema) + "." + QuoteIdentifier(dialect, tableName)
+		}
+		return QuoteIdentifier(dialect, tableName)
+	}
+	for i := range destCatalog.Schemas {
+		destSchema := &destCatalog.Schemas[i]
+		if destSchema.Ignore {
+			continue
+		}
+		srcSchema := srcCache.GetSchema(srcCatalog, destSchema.SchemaName)
+		if srcSchema == nil {
+			continue
+		}
+		var droppedTables, createdTables []*Table
+		for j := range srcSchema.Tables {
+			srcTable := &srcSchema.Tables[j]
+			if !srcTable.Ignore && !isVirtualTable(srcTable) && destCache.GetTable(destSchema, srcTable.TableName) == nil {
+				droppedTables = append(droppedTables, srcTable)
+			}
+		}
+		for j := range destSchema.Tables {
+			destTable := &destSchema.Tables[j]
+			if destTable.Ignore || isVirtualTable(destTable) {
+				continue
+			}
+			srcTable := srcCache.GetTable(srcSchema, destTable.TableName)
+			if srcTable == nil {
+				createdTables = append(createdTables, destTable)
+				continue
+			}
+			var droppedColumns, addedColumns []*Column
+			for k := range srcTable.Columns {
+				srcColumn := &srcTable.Columns[k]
+				if !srcColumn.Ignore && destCache.GetColumn(destTable, srcColumn.ColumnName) == nil {
+					droppedColumns = append(droppedColumns, srcColumn)
+				}
+			}
+			for k := range destTable.Columns {
+				destColumn := &destTable.Columns[k]
+				if !destColumn.Ignore && srcCache.GetColumn(srcTable, destColumn.ColumnName) == nil {
+					addedColumns = append(addedColumns, destColumn)
+				}
+			}
+			for _, srcColumn := range droppedColumns {
+				var match *Column
+				matches := 0
+				for _, destColumn := range addedColumns {
+					if columnsAreIdentical(dialect, srcColumn, destColumn) {
+						match = destColumn
+						matches++
+					}
+				}
+				if matches != 1 {
+					continue
+				}
+				warnings = append(warnings, fmt.Sprintf("%s: dropping column %q and adding column %q with an identical definition. If the column was renamed, add the modifier renamedfrom=%s to column %q", qualifiedName(destTable.TableSchema, destTable.TableName), srcColumn.ColumnName, match.ColumnName, srcColumn.ColumnName, match.ColumnName))
+			}
+		}
+		for _, srcTable := range droppedTables {
+			var match *Table
+			matches := 0
+			for _, destTable := range createdTables {
+				if tablesAreIdentical(dialect, srcCache, srcTable, destTable) {
+					match = destTable
+					matches++
+				}
+			}
+			if matches != 1 {
+				continue
+			}
+			warnings = append(warnings, fmt.Sprintf("%s: dropping table and creating table %s with identical columns. If the table was renamed, add the modifier renamedfrom=%s to table %s", qualifiedName(srcTable.TableSchema, srcTable.TableName), qualifiedName(match.TableSchema, match.TableName), srcTable.TableName, qualifiedName(match.TableSchema, match.TableName)))
+		}
+	}
+	return warnings
+}
+
+// columnsAreIdentical checks if two columns have the same definition
+// (ignoring the column name).
+func columnsAreIdentical(dialect string, srcColumn, destColumn *Column) bool {
+	srcType, srcArg1, srcArg2 := normalizeColumnType(dialect, srcColumn.ColumnType)
+	destType, destArg1, destArg2 := normalizeColumnType(dialect, destColumn.ColumnType)
+	if [3]string{srcType, srcArg1, srcArg2} != [3]string{destType, destArg1, destArg2} {
+		return false
+	}
+	if normalizeColumnDefault(dialect, srcColumn.ColumnDefault) != normalizeColumnDefault(dialect, destColumn.ColumnDefault) {
+		return false
+	}
+	if srcColumn.IsNotNull != destColumn.IsNotNull || srcColumn.IsAutoincrement != destColumn.IsAutoincrement {
+		return false
+	}
+	if (srcColumn.ColumnIdentity == "") != (destColumn.ColumnIdentity == "") {
+		return false
+	}
+	return srcColumn.GeneratedExpr == destColumn.GeneratedExpr
+}
+
+// tablesAreIdentical checks if two tables have the same columns (ignoring
+// the table name).
+func tablesAreIdentical(dialect string, srcCache *CatalogCache, srcTable, destTable *Table) bool {
+	n := 0
+	for i := range destTable.Columns {
+		destColumn := &destTable.Columns[i]
+		if destColumn.Ignore {
+			continue
+		}
+		n++
+		srcColumn := srcCache.GetColumn(srcTable, destColumn.ColumnName)
+		if srcColumn == nil || !columnsAreIdentical(dialect, srcColumn, destColumn) {
+			return false
+		}
+	}
+	for i := range srcTable.Columns {
+		if !srcTable.Columns[i].Ignore {
+			n--
+		}
+	}
+	return n == 0
+}
+
+// renameTable renames a table in the catalog, updating any foreign keys that
+// reference it.
+func renameTable(catalog *Catalog, table *Table, tableName string) {
+	oldTableName := table.TableName
+	for i := range catalog.Schemas {
+		schema := &catalog.Schemas[i]
+		for j := range schema.Tables {
+			t := &schema.Tables[j]
+			for k := range t.Columns {
+				column := &t.Columns[k]
+				if column.ReferencesTable == oldTableName && referencesSchema(column.TableSchema, column.ReferencesSchema) == table.TableSchema {
+					column.ReferencesTable = tableName
+				}
+			}
+			for k := range t.Constraints {
+				constraint := &t.Constraints[k]
+				if constraint.ReferencesTable == oldTableName && referencesSchema(constraint.TableSchema, constraint.ReferencesSchema) == table.TableSchema {
+					constraint.ReferencesTable = tableName
+				}
+			}
+		}
+	}
+	table.TableName = tableName
+	for i := range table.Columns {
+		table.Columns[i].TableName = tableName
+	}
+	for i := range table.Constraints {
+		table.Constraints[i].TableName = tableName
+	}
+	for i := range table.Indexes {
+		table.Indexes[i].TableName = tableName
+	}
+	for i := range table.Triggers {
+		table.Triggers[i].TableName = tableName
+	}
+}
+
+// renameColumn renames a column in the catalog, updating any indexes and
+// constraints that use it as well as any foreign keys that reference it.
+func renameColumn(catalog *Catalog, table *Table, column *Column, columnName string) {
+	oldColumnName := column.ColumnName
+	replaceName := func(names []string) {
+		for i, name := range names {
+			if name == oldColumnName {
+				names[i] = columnName
+			}
+		}
+	}
+	column.ColumnName = columnName
+	for i := range table.Constraints {
+		replaceName(table.Constraints[i].Columns)
+	}
+	for i := range table.Indexes {
+		replaceName(table.Indexes[i].Columns)
+		replaceName(table.Indexes[i].IncludeColumns)
+	}
+	for i := range catalog.Schemas {
+		schema := &catalog.Schemas[i]
+		for j := range schema.Tables {
+			t := &schema.Tables[j]
+			for k := range t.Columns {
+				c := &t.Columns[k]
+				if c.ReferencesTable == table.TableName && c.ReferencesColumn == oldColumnName && referencesSchema(c.TableSchema, c.ReferencesSchema) == table.TableSchema {
+					c.ReferencesColumn = columnName
+				}
+			}
+			for k := range t.Constraints {
+				constraint := &t.Constraints[k]
+				if constraint.ReferencesTable == table.TableName && referencesSchema(constraint.TableSchema, constraint.ReferencesSchema) == table.TableSchema {
+					replaceName(constraint.ReferencesColumns)
+				}
+			}
+		}
+	}
+}
+
+// referencesSchema returns the schema referenced by a foreign key, which
+// defaults to the schema of the table if empty.
+func referencesSchema(tableSchema, referencesSchema string) string {
+	if referencesSchema == "" {
+		return tableSchema
+	}
+	return referencesSchema
+}
+
+// hasRenames reports if any table or column in the catalog was renamed.
+func hasRenames(catalog *Catalog) bool {
+	for i := range catalog.Schemas {
+		schema := &catalog.Schemas[i]
+		for j := range schema.Tables {
+			table := &schema.Tables[j]
+			if table.RenamedFrom != "" {
+				return true
+			}
+			for k := range table.Columns {
+				if table.Columns[k].RenamedFrom != "" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
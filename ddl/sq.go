@@ -59,6 +59,14 @@ func QuoteIdentifier(dialect string, identifier string) string {
 				_, needsQuoting = mysqlKeywords[strings.ToLower(identifier)]
 			case DialectSQLServer:
 				_, needsQuoting = sqlserverKeywords[strings.ToLower(identifier)]
+			case DialectOracle:
+				_, needsQuoting = oracleKeywords[strings.ToLower(identifier)]
+				if needsQuoting {
+					// Oracle folds unquoted identifiers to uppercase, so a
+					// lowercase keyword has to be quoted in uppercase in
+					// order to refer to the same identifier.
+					return `"` + strings.ToUpper(identifier) + `"`
+				}
 			}
 		}
 	}
@@ -250,6 +258,31 @@ var sqlserverKeywords = map[string]struct{}{
 	"writetext": {}, "exit": {}, "proc": {},
 }
 
+// Oracle keyword reference:
+// https://docs.oracle.com/en/database/oracle/oracle-database/19/sqlrf/Oracle-SQL-Reserved-Words.html
+var oracleKeywords = map[string]struct{}{
+	"access": {}, "add": {}, "all": {}, "alter": {}, "and": {}, "any": {}, "as": {},
+	"asc": {}, "audit": {}, "between": {}, "by": {}, "char": {}, "check": {},
+	"cluster": {}, "column": {}, "column_value": {}, "comment": {}, "compress": {},
+	"connect": {}, "create": {}, "current": {}, "date": {}, "decimal": {},
+	"default": {}, "delete": {}, "desc": {}, "distinct": {}, "drop": {}, "else": {},
+	"exclusive": {}, "exists": {}, "file": {}, "float": {}, "for": {}, "from": {},
+	"grant": {}, "group": {}, "having": {}, "identified": {}, "immediate": {},
+	"in": {}, "increment": {}, "index": {}, "initial": {}, "insert": {},
+	"integer": {}, "intersect": {}, "into": {}, "is": {}, "level": {}, "like": {},
+	"lock": {}, "long": {}, "maxextents": {}, "minus": {}, "mlslabel": {},
+	"mode": {}, "modify": {}, "nested_table_id": {}, "noaudit": {},
+	"nocompress": {}, "not": {}, "nowait": {}, "null": {}, "number": {}, "of": {},
+	"offline": {}, "on": {}, "online": {}, "option": {}, "or": {}, "order": {},
+	"pctfree": {}, "prior": {}, "public": {}, "raw": {}, "rename": {},
+	"resource": {}, "revoke": {}, "row": {}, "rowid": {}, "rownum": {}, "rows": {},
+	"select": {}, "session": {}, "set": {}, "share": {}, "size": {}, "smallint": {},
+	"start": {}, "successful": {}, "synonym": {}, "sysdate": {}, "table": {},
+	"then": {}, "to": {}, "trigger": {}, "uid": {}, "union": {}, "unique": {},
+	"update": {}, "user": {}, "validate": {}, "values": {}, "varchar": {},
+	"varchar2": {}, "view": {}, "whenever": {}, "where": {}, "with": {},
+}
+
 type Timestamp struct {
 	time.Time
 	Valid   bool
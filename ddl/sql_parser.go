@@ -0,0 +1,2166 @@
+package ddl
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SQLParser is used to parse SQL DDL statements (CREATE TABLE, CREATE INDEX,
+// ALTER TABLE ADD CONSTRAINT, CREATE VIEW, etc) into a Catalog without
+// needing a database. Statements that the SQLParser does not understand
+// (INSERT, CREATE FUNCTION, CREATE TRIGGER, etc) are skipped.
+type SQLParser struct {
+	// ObjectTypes controls what object types will be written to the Catalog.
+	// An empty slice means all object types will be written. The possible
+	// object types are: "EXTENSIONS", "ENUMS", "DOMAINS", "SEQUENCES",
+	// "VIEWS" and "TABLES".
+	ObjectTypes []string
+
+	files             []sqlFile
+	parserDiagnostics *parserDiagnostics
+	dialect           string
+	catalog           *Catalog
+	cache             *CatalogCache
+	enums             map[string]struct{}
+	domains           map[string]Domain
+	tables            [][2]string
+	fkeyChecks        []sqlFkeyCheck
+}
+
+type sqlFile struct {
+	tokenFile *token.File
+	src       string
+}
+
+// sqlFkeyCheck is a FOREIGN KEY constraint whose referenced table and columns
+// can only be checked once all statements have been parsed.
+type sqlFkeyCheck struct {
+	loc            location
+	tableSchema    string
+	tableName      string
+	constraintName string
+}
+
+// NewSQLParser creates a new SQLParser. An existing token.Fileset can be
+// passed in. If not, passing in nil is fine and a new token.FileSet will be
+// instantiated.
+func NewSQLParser(fset *token.FileSet) *SQLParser {
+	if fset == nil {
+		fset = token.NewFileSet()
+	}
+	return &SQLParser{parserDiagnostics: &parserDiagnostics{
+		fset: fset,
+	}}
+}
+
+// ParseFile reads an fs.File containing SQL statements. The statements are
+// only parsed when WriteCatalog is called, because how they are parsed
+// depends on the dialect of the Catalog.
+func (p *SQLParser) ParseFile(f fs.File) error {
+	fileinfo, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	src, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	p.addFile(fileinfo.Name(), src)
+	return nil
+}
+
+func (p *SQLParser) addFile(name string, src []byte) {
+	tokenFile := p.parserDiagnostics.fset.AddFile(name, -1, len(src))
+	tokenFile.SetLinesForContent(src)
+	p.files = append(p.files, sqlFile{tokenFile: tokenFile, src: string(src)})
+}
+
+// WriteCatalog populates the Catalog by parsing the SQL files. Unqualified
+// object names are assumed to belong to the Catalog's CurrentSchema.
+func (p *SQLParser) WriteCatalog(catalog *Catalog) error {
+	p.dialect = catalog.Dialect
+	p.catalog = catalog
+	p.cache = NewCatalogCache(catalog)
+	p.enums = make(map[string]struct{})
+	p.domains = make(map[string]Domain)
+	p.tables = p.tables[:0]
+	p.fkeyChecks = p.fkeyChecks[:0]
+
+	for i := range p.files {
+		file := &p.files[i]
+		tokens, ok := p.tokenize(file)
+		if !ok {
+			continue
+		}
+		for _, statement := range p.splitStatements(file, tokens) {
+			statement.parse()
+		}
+	}
+
+	// Validate the tables and columns referenced by FOREIGN KEY constraints.
+	for _, check := range p.fkeyChecks {
+		table := p.cache.GetTable(p.cache.GetSchema(catalog, check.tableSchema), check.tableName)
+		constraint := p.cache.GetConstraint(table, check.constraintName)
+		if constraint == nil {
+			continue
+		}
+		refTableName := constraint.ReferencesTable
+		if constraint.ReferencesSchema != "" {
+			refTableName = constraint.ReferencesSchema + "." + refTableName
+		}
+		refTable := p.cache.GetTable(p.cache.GetSchema(catalog, constraint.ReferencesSchema), constraint.ReferencesTable)
+		if refTable == nil {
+			p.report(check.loc, fmt.Sprintf("%s: table %s does not exist", constraint.ConstraintName, refTableName))
+			continue
+		}
+		if len(constraint.ReferencesColumns) == 0 {
+			pkey := p.cache.GetPrimaryKey(refTable)
+			if pkey == nil {
+				p.report(check.loc, fmt.Sprintf("%s: table %s has no primary key", constraint.ConstraintName, refTableName))
+				continue
+			}
+			constraint.ReferencesColumns = cloneSlice(pkey.Columns)
+		}
+		for _, columnName := range constraint.ReferencesColumns {
+			if p.cache.GetColumn(refTable, columnName) == nil {
+				p.report(check.loc, fmt.Sprintf("%s: column %s.%s does not exist", constraint.ConstraintName, refTableName, columnName))
+			}
+		}
+	}
+
+	for _, tableID := range p.tables {
+		table := p.cache.GetTable(p.cache.GetSchema(catalog, tableID[0]), tableID[1])
+		if table == nil {
+			continue
+		}
+		// Resolve enum and domain column types.
+		if p.dialect == DialectPostgres {
+			for i := range table.Columns {
+				column := &table.Columns[i]
+				typeName := strings.ToLower(column.ColumnType)
+				if j := strings.LastIndexByte(typeName, '.'); j >= 0 {
+					typeName = typeName[j+1:]
+				}
+				if domain, ok := p.domains[typeName]; ok {
+					column.DomainName = column.ColumnType
+					column.ColumnType = domain.UnderlyingType
+				} else if _, ok := p.enums[typeName]; ok {
+					column.IsEnum = true
+				}
+			}
+		}
+		// Set IsPrimaryKey, IsUnique and the REFERENCES fields for the
+		// columns which are the only column in their constraint.
+		for _, constraint := range table.Constraints {
+			if constraint.Ignore || len(constraint.Columns) != 1 {
+				continue
+			}
+			column := p.cache.GetColumn(table, constraint.Columns[0])
+			if column == nil {
+				continue
+			}
+			switch constraint.ConstraintType {
+			case PRIMARY_KEY:
+				column.IsPrimaryKey = true
+			case UNIQUE:
+				column.IsUnique = true
+			case FOREIGN_KEY:
+				if len(constraint.ReferencesColumns) != 1 {
+					continue
+				}
+				column.ReferencesSchema = constraint.ReferencesSchema
+				column.ReferencesTable = constraint.ReferencesTable
+				column.ReferencesColumn = constraint.ReferencesColumns[0]
+				column.UpdateRule = constraint.UpdateRule
+				column.DeleteRule = constraint.DeleteRule
+				column.IsDeferrable = constraint.IsDeferrable
+				column.IsInitiallyDeferred = constraint.IsInitiallyDeferred
+			}
+		}
+		// Set PRIMARY KEY columns to NOT NULL.
+		pkey := p.cache.GetPrimaryKey(table)
+		if pkey != nil && !pkey.Ignore {
+			for _, columnName := range pkey.Columns {
+				column := p.cache.GetColumn(table, columnName)
+				if column == nil {
+					continue
+				}
+				if p.dialect == DialectSQLite && strings.EqualFold(column.ColumnType, "INTEGER") && column.IsPrimaryKey {
+					// An INTEGER PRIMARY KEY is an alias for the ROWID, which
+					// can never be NULL.
+					continue
+				}
+				column.IsNotNull = true
+			}
+		}
+	}
+	return p.Error()
+}
+
+// Error returns the errors encountered after calling WriteCatalog.
+func (p *SQLParser) Error() error {
+	if len(p.parserDiagnostics.msgs) > 0 {
+		return p.parserDiagnostics
+	}
+	return nil
+}
+
+func (p *SQLParser) report(loc location, msg string) {
+	p.parserDiagnostics.locs = append(p.parserDiagnostics.locs, loc)
+	p.parserDiagnostics.msgs = append(p.parserDiagnostics.msgs, msg)
+}
+
+func (p *SQLParser) includeObjectType(objectType string) bool {
+	if len(p.ObjectTypes) == 0 {
+		return true
+	}
+	for _, t := range p.ObjectTypes {
+		if t == objectType {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultSchema returns the schema that an object belongs to if its schema
+// was not specified.
+func (p *SQLParser) defaultSchema(schemaName string) string {
+	if schemaName == "" {
+		return p.catalog.CurrentSchema
+	}
+	return schemaName
+}
+
+type location struct {
+	pos        token.Pos
+	structName string
+	fieldName  string
+	keys       []string
+}
+
+type parserDiagnostics struct {
+	fset *token.FileSet
+	locs []location
+	msgs []string
+}
+
+// Error implements the error interface.
+func (d *parserDiagnostics) Error() string {
+	buf := bufpool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer bufpool.Put(buf)
+	for i, msg := range d.msgs {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		if i >= len(d.locs) {
+			buf.WriteString(msg)
+			continue
+		}
+		loc := d.locs[i]
+		if d.fset != nil {
+			pos := d.fset.Position(loc.pos)
+			if pos.IsValid() {
+				buf.WriteString(pos.String() + ": ")
+			}
+		}
+		if loc.structName != "" && loc.fieldName != "" {
+			buf.WriteString(loc.structName + "." + loc.fieldName + ": ")
+		}
+		if len(loc.keys) > 0 {
+			for j, key := range loc.keys {
+				if j > 0 {
+					buf.WriteByte('.')
+				}
+				buf.WriteString(key)
+			}
+			buf.WriteString(": ")
+		}
+		buf.WriteString(msg)
+	}
+	return buf.String()
+}
+
+type sqlTokenType int
+
+const (
+	sqlWord sqlTokenType = iota + 1 // A keyword or an unquoted identifier.
+	sqlQuotedIdentifier
+	sqlString
+	sqlNumber
+	sqlPunct
+)
+
+type sqlToken struct {
+	typ   sqlTokenType
+	value string // The unquoted value of identifiers and strings.
+	start int    // The start offset of the token in the source.
+	end   int    // The end offset of the token in the source.
+}
+
+// is reports if the token is the given keyword.
+func (tok sqlToken) is(keyword string) bool {
+	return tok.typ == sqlWord && strings.EqualFold(tok.value, keyword)
+}
+
+// isPunct reports if the token is the given punctuation.
+func (tok sqlToken) isPunct(punct string) bool {
+	return tok.typ == sqlPunct && tok.value == punct
+}
+
+func (tok sqlToken) isIdentifier() bool {
+	return tok.typ == sqlWord || tok.typ == sqlQuotedIdentifier
+}
+
+// tokenize splits a file into tokens, skipping whitespace and comments.
+func (p *SQLParser) tokenize(file *sqlFile) (tokens []sqlToken, ok bool) {
+	src := file.src
+	unterminated := func(start int, what string) {
+		p.report(location{pos: file.tokenFile.Pos(start)}, "unterminated "+what)
+	}
+	for i := 0; i < len(src); {
+		char := src[i]
+		switch {
+		case char == ' ' || char == '\t' || char == '\n' || char == '\r' || char == '\f' || char == '\v':
+			i++
+		case strings.HasPrefix(src[i:], "--") || (char == '#' && p.dialect == DialectMySQL):
+			j := strings.IndexByte(src[i:], '\n')
+			if j < 0 {
+				i = len(src)
+			} else {
+				i += j + 1
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			j := strings.Index(src[i+2:], "*/")
+			if j < 0 {
+				unterminated(i, "comment")
+				return nil, false
+			}
+			i += j + 4
+		case char == '\'':
+			end, value, ok := scanQuoted(src, i, '\'', p.dialect == DialectMySQL)
+			if !ok {
+				unterminated(i, "string")
+				return nil, false
+			}
+			tokens = append(tokens, sqlToken{typ: sqlString, value: value, start: i, end: end})
+			i = end
+		case char == '"' || (char == '`' && p.dialect != DialectPostgres && p.dialect != DialectSQLServer) || (char == '[' && (p.dialect == DialectSQLServer || p.dialect == DialectSQLite)):
+			quote := char
+			if quote == '[' {
+				quote = ']'
+			}
+			end, value, ok := scanQuoted(src, i, quote, false)
+			if !ok {
+				if char == '"' && p.dialect == DialectMySQL {
+					unterminated(i, "string")
+				} else {
+					unterminated(i, "quoted identifier")
+				}
+				return nil, false
+			}
+			typ := sqlQuotedIdentifier
+			if char == '"' && p.dialect == DialectMySQL {
+				typ = sqlString
+			}
+			tokens = append(tokens, sqlToken{typ: typ, value: value, start: i, end: end})
+			i = end
+		case char == '$' && p.dialect == DialectPostgres && isDollarQuote(src[i:]):
+			j := strings.IndexByte(src[i+1:], '$')
+			tag := src[i : i+j+2]
+			k := strings.Index(src[i+len(tag):], tag)
+			if k < 0 {
+				unterminated(i, "dollar-quoted string")
+				return nil, false
+			}
+			end := i + len(tag) + k + len(tag)
+			tokens = append(tokens, sqlToken{typ: sqlString, value: src[i+len(tag) : end-len(tag)], start: i, end: end})
+			i = end
+		case isDigit(char) || (char == '.' && i+1 < len(src) && isDigit(src[i+1])):
+			j := i
+			for j < len(src) && (isDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			if j < len(src) && (src[j] == 'e' || src[j] == 'E') {
+				k := j + 1
+				if k < len(src) && (src[k] == '+' || src[k] == '-') {
+					k++
+				}
+				if k < len(src) && isDigit(src[k]) {
+					j = k
+					for j < len(src) && isDigit(src[j]) {
+						j++
+					}
+				}
+			}
+			tokens = append(tokens, sqlToken{typ: sqlNumber, value: src[i:j], start: i, end: j})
+			i = j
+		default:
+			r, size := utf8.DecodeRuneInString(src[i:])
+			if !p.isIdentifierStart(r) {
+				value := src[i : i+size]
+				if strings.HasPrefix(src[i:], "::") {
+					value = "::"
+				}
+				tokens = append(tokens, sqlToken{typ: sqlPunct, value: value, start: i, end: i + len(value)})
+				i += len(value)
+				continue
+			}
+			j := i + size
+			for j < len(src) {
+				r, size := utf8.DecodeRuneInString(src[j:])
+				if !p.isIdentifierStart(r) && !unicode.IsDigit(r) && r != '$' && r != '#' && r != '@' {
+					break
+				}
+				j += size
+			}
+			word := src[i:j]
+			// E'...' is a Postgres string that supports backslash escapes.
+			if p.dialect == DialectPostgres && (word == "E" || word == "e") && j < len(src) && src[j] == '\'' {
+				end, value, ok := scanQuoted(src, j, '\'', true)
+				if !ok {
+					unterminated(i, "string")
+					return nil, false
+				}
+				tokens = append(tokens, sqlToken{typ: sqlString, value: value, start: i, end: end})
+				i = end
+				continue
+			}
+			tokens = append(tokens, sqlToken{typ: sqlWord, value: word, start: i, end: j})
+			i = j
+		}
+	}
+	return tokens, true
+}
+
+func (p *SQLParser) isIdentifierStart(r rune) bool {
+	if r == '_' || unicode.IsLetter(r) {
+		return true
+	}
+	// @variables and #temporary_tables.
+	return p.dialect == DialectSQLServer && (r == '@' || r == '#')
+}
+
+func isDigit(char byte) bool {
+	return '0' <= char && char <= '9'
+}
+
+// isDollarQuote reports if s starts with a Postgres dollar quote tag e.g. $$
+// or $body$.
+func isDollarQuote(s string) bool {
+	for i := 1; i < len(s); i++ {
+		char := s[i]
+		if char == '$' {
+			return true
+		}
+		if char != '_' && !('a' <= char && char <= 'z') && !('A' <= char && char <= 'Z') && !(i > 1 && isDigit(char)) {
+			return false
+		}
+	}
+	return false
+}
+
+// scanQuoted scans a quoted string or identifier starting at src[start]. A
+// doubled closing quote is an escaped quote.
+func scanQuoted(src string, start int, quote byte, backslashEscapes bool) (end int, value string, ok bool) {
+	var b strings.Builder
+	for i := start + 1; i < len(src); i++ {
+		char := src[i]
+		if backslashEscapes && char == '\\' && i+1 < len(src) {
+			b.WriteByte(char)
+			b.WriteByte(src[i+1])
+			i++
+			continue
+		}
+		if char == quote {
+			if i+1 < len(src) && src[i+1] == quote {
+				b.WriteByte(quote)
+				i++
+				continue
+			}
+			return i + 1, b.String(), true
+		}
+		b.WriteByte(char)
+	}
+	return -1, "", false
+}
+
+// splitStatements splits the tokens into statements delimited by semicolons.
+// Semicolons inside brackets or inside the BEGIN ... END block of a trigger
+// or routine do not end a statement.
+func (p *SQLParser) splitStatements(file *sqlFile, tokens []sqlToken) []*sqlStatement {
+	var statements []*sqlStatement
+	var brackets []sqlToken
+	var blockDepth, start int
+	isBlockStatement := false
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		if i == start {
+			isBlockStatement = false
+			if tok.is("CREATE") {
+				for j := i + 1; j < len(tokens) && j < i+16 && !tokens[j].isPunct("(") && !tokens[j].isPunct(";"); j++ {
+					if tokens[j].is("TRIGGER") || tokens[j].is("FUNCTION") || tokens[j].is("PROCEDURE") || tokens[j].is("PROC") || tokens[j].is("PACKAGE") || tokens[j].is("EVENT") {
+						isBlockStatement = true
+						break
+					}
+				}
+			}
+		}
+		switch {
+		case tok.isPunct("("):
+			brackets = append(brackets, tok)
+		case tok.isPunct(")"):
+			if len(brackets) == 0 {
+				p.report(location{pos: file.tokenFile.Pos(tok.start)}, "unexpected )")
+				return nil
+			}
+			brackets = brackets[:len(brackets)-1]
+		case isBlockStatement && (tok.is("BEGIN") || tok.is("CASE")):
+			next := sqlToken{}
+			if i+1 < len(tokens) {
+				next = tokens[i+1]
+			}
+			if tok.is("BEGIN") && (next.isPunct(";") || next.is("TRANSACTION") || next.is("TRAN") || next.is("WORK")) {
+				continue
+			}
+			blockDepth++
+		case isBlockStatement && tok.is("END") && blockDepth > 0:
+			next := sqlToken{}
+			if i+1 < len(tokens) {
+				next = tokens[i+1]
+			}
+			if next.is("IF") || next.is("LOOP") || next.is("WHILE") || next.is("REPEAT") || next.is("FOR") {
+				continue
+			}
+			blockDepth--
+		case tok.isPunct(";") && len(brackets) == 0 && blockDepth == 0:
+			if i > start {
+				statements = append(statements, &sqlStatement{p: p, file: file, tokens: tokens[start:i]})
+			}
+			start = i + 1
+		}
+	}
+	if len(brackets) > 0 {
+		p.report(location{pos: file.tokenFile.Pos(brackets[0].start)}, "missing closing bracket")
+		return nil
+	}
+	if start < len(tokens) {
+		statements = append(statements, &sqlStatement{p: p, file: file, tokens: tokens[start:]})
+	}
+	return statements
+}
+
+// sqlStatement holds the tokens of a single SQL statement and the position of
+// the current token.
+type sqlStatement struct {
+	p            *SQLParser
+	file         *sqlFile
+	tokens       []sqlToken
+	i            int
+	isNotValid   bool
+	columnChecks []sqlColumnCheck
+}
+
+// sqlColumnCheck is a list of columns referenced by a constraint or index
+// that must exist in the table. The columns are only checked after the whole
+// statement has been parsed, because a CREATE TABLE statement may define a
+// table constraint before the columns it references.
+type sqlColumnCheck struct {
+	loc         location
+	tableSchema string
+	tableName   string
+	objectName  string
+	columns     []string
+}
+
+func (s *sqlStatement) parse() {
+	switch {
+	case s.accept("CREATE"):
+		s.parseCreate()
+	case s.accept("ALTER", "TABLE"):
+		s.parseAlterTable()
+	case s.accept("COMMENT", "ON"):
+		s.parseComment()
+	}
+	for _, check := range s.columnChecks {
+		table := s.p.cache.GetTable(s.p.cache.GetSchema(s.p.catalog, check.tableSchema), check.tableName)
+		if table == nil {
+			continue
+		}
+		for _, columnName := range check.columns {
+			if s.p.cache.GetColumn(table, columnName) == nil {
+				s.p.report(check.loc, fmt.Sprintf("%s: column %s does not exist in table %s", check.objectName, columnName, table.TableName))
+			}
+		}
+	}
+}
+
+// tok returns the token n tokens after the current token. If there is no such
+// token, a token with a zero type is returned.
+func (s *sqlStatement) tok(n int) sqlToken {
+	if s.i+n < len(s.tokens) {
+		return s.tokens[s.i+n]
+	}
+	end := s.tokens[len(s.tokens)-1].end
+	return sqlToken{start: end, end: end}
+}
+
+func (s *sqlStatement) atEnd() bool {
+	return s.i >= len(s.tokens)
+}
+
+// atElementEnd reports if the current token ends an element of a comma
+// separated list.
+func (s *sqlStatement) atElementEnd() bool {
+	return s.atEnd() || s.tok(0).isPunct(",") || s.tok(0).isPunct(")")
+}
+
+// accept consumes the given sequence of keywords if they match the current
+// tokens.
+func (s *sqlStatement) accept(keywords ...string) bool {
+	for j, keyword := range keywords {
+		if !s.tok(j).is(keyword) {
+			return false
+		}
+	}
+	s.i += len(keywords)
+	return true
+}
+
+func (s *sqlStatement) acceptPunct(punct string) bool {
+	if s.tok(0).isPunct(punct) {
+		s.i++
+		return true
+	}
+	return false
+}
+
+func (s *sqlStatement) expect(keywords ...string) bool {
+	if s.accept(keywords...) {
+		return true
+	}
+	s.errorf("expected %s, got %s", strings.Join(keywords, " "), s.describe(s.tok(0)))
+	return false
+}
+
+func (s *sqlStatement) expectPunct(punct string) bool {
+	if s.acceptPunct(punct) {
+		return true
+	}
+	s.errorf("expected %s, got %s", punct, s.describe(s.tok(0)))
+	return false
+}
+
+func (s *sqlStatement) location(tok sqlToken) location {
+	return location{pos: s.file.tokenFile.Pos(tok.start)}
+}
+
+func (s *sqlStatement) errorf(format string, a ...any) {
+	s.p.report(s.location(s.tok(0)), fmt.Sprintf(format, a...))
+}
+
+func (s *sqlStatement) describe(tok sqlToken) string {
+	if tok.typ == 0 {
+		return "end of statement"
+	}
+	return strconv.Quote(s.file.src[tok.start:tok.end])
+}
+
+// text returns the source text from the start of the token at index i up to
+// the end of the token before the current token.
+func (s *sqlStatement) text(i int) string {
+	if i >= s.i {
+		return ""
+	}
+	return strings.TrimSpace(s.file.src[s.tokens[i].start:s.tokens[s.i-1].end])
+}
+
+func (s *sqlStatement) parseIdentifier(what string) (string, bool) {
+	tok := s.tok(0)
+	switch tok.typ {
+	case sqlQuotedIdentifier:
+		s.i++
+		return tok.value, true
+	case sqlWord:
+		s.i++
+		if s.p.dialect == DialectPostgres {
+			// Postgres folds unquoted identifiers to lower case.
+			return strings.ToLower(tok.value), true
+		}
+		return tok.value, true
+	}
+	s.errorf("expected %s, got %s", what, s.describe(tok))
+	return "", false
+}
+
+// parseQualifiedName parses a dot-separated name e.g. schema.table.column.
+func (s *sqlStatement) parseQualifiedName(what string) ([]string, bool) {
+	name, ok := s.parseIdentifier(what)
+	if !ok {
+		return nil, false
+	}
+	names := []string{name}
+	for s.acceptPunct(".") {
+		name, ok = s.parseIdentifier(what)
+		if !ok {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+	return names, true
+}
+
+// parseObjectName parses a name that is optionally qualified by its schema
+// (and database).
+func (s *sqlStatement) parseObjectName(what string) (schemaName, name string, ok bool) {
+	names, ok := s.parseQualifiedName(what)
+	if !ok {
+		return "", "", false
+	}
+	name = names[len(names)-1]
+	if len(names) > 1 {
+		schemaName = names[len(names)-2]
+	}
+	return schemaName, name, true
+}
+
+// parseGroup parses a bracketed group and returns the text inside the
+// brackets.
+func (s *sqlStatement) parseGroup() (string, bool) {
+	open := s.tok(0)
+	if !open.isPunct("(") {
+		s.errorf("expected (, got %s", s.describe(open))
+		return "", false
+	}
+	depth := 0
+	for j := s.i; j < len(s.tokens); j++ {
+		switch {
+		case s.tokens[j].isPunct("("):
+			depth++
+		case s.tokens[j].isPunct(")"):
+			depth--
+			if depth == 0 {
+				s.i = j + 1
+				return strings.TrimSpace(s.file.src[open.end:s.tokens[j].start]), true
+			}
+		}
+	}
+	s.errorf("missing closing bracket")
+	return "", false
+}
+
+// skipToElementEnd skips tokens until the end of the current element of a
+// comma separated list.
+func (s *sqlStatement) skipToElementEnd() {
+	for !s.atElementEnd() {
+		if s.tok(0).isPunct("(") {
+			if _, ok := s.parseGroup(); !ok {
+				s.i = len(s.tokens)
+			}
+			continue
+		}
+		s.i++
+	}
+}
+
+// parseText parses tokens (and bracketed groups) until the end of the current
+// element or until stop returns true for a token after the first, and returns
+// the source text of the parsed tokens.
+func (s *sqlStatement) parseText(stop func(tok sqlToken) bool) string {
+	start := s.i
+	for !s.atElementEnd() {
+		if s.i > start && stop(s.tok(0)) {
+			break
+		}
+		if s.tok(0).isPunct("(") {
+			if _, ok := s.parseGroup(); !ok {
+				s.i = len(s.tokens)
+			}
+			continue
+		}
+		s.i++
+	}
+	return s.text(start)
+}
+
+func (s *sqlStatement) parseString(what string) (string, bool) {
+	tok := s.tok(0)
+	if tok.typ != sqlString {
+		s.errorf("expected %s, got %s", what, s.describe(tok))
+		return "", false
+	}
+	s.i++
+	return tok.value, true
+}
+
+// parseInt parses an optionally signed integer.
+func (s *sqlStatement) parseInt(what string) (int64, bool) {
+	sign := ""
+	if s.tok(0).isPunct("-") || s.tok(0).isPunct("+") {
+		sign = s.tok(0).value
+		s.i++
+	}
+	tok := s.tok(0)
+	if tok.typ == sqlNumber {
+		if num, err := strconv.ParseInt(sign+tok.value, 10, 64); err == nil {
+			s.i++
+			return num, true
+		}
+	}
+	s.errorf("expected %s, got %s", what, s.describe(tok))
+	return 0, false
+}
+
+// parseValue parses an option value which may be a word, string or number.
+func (s *sqlStatement) parseValue(what string) (string, bool) {
+	tok := s.tok(0)
+	switch tok.typ {
+	case sqlWord, sqlQuotedIdentifier, sqlString, sqlNumber:
+		s.i++
+		return tok.value, true
+	}
+	s.errorf("expected %s, got %s", what, s.describe(tok))
+	return "", false
+}
+
+func (s *sqlStatement) parseCreate() {
+	var isUnique, isMaterialized, isUnlogged, isTemporary, isVirtual, orReplace bool
+	var indexType string
+	for j := 0; j < 16 && !s.atEnd() && !s.tok(0).isPunct("("); j++ {
+		tok := s.tok(0)
+		s.i++
+		switch strings.ToUpper(tok.value) {
+		case "TABLE":
+			if !isTemporary {
+				s.parseCreateTable(isUnlogged, isVirtual)
+			}
+			return
+		case "INDEX":
+			s.parseCreateIndex(isUnique, indexType)
+			return
+		case "VIEW":
+			s.parseCreateView(isMaterialized, orReplace)
+			return
+		case "SCHEMA":
+			s.parseCreateSchema()
+			return
+		case "EXTENSION":
+			s.parseCreateExtension()
+			return
+		case "TYPE":
+			s.parseCreateType()
+			return
+		case "DOMAIN":
+			s.parseCreateDomain()
+			return
+		case "SEQUENCE":
+			if !isTemporary {
+				s.parseCreateSequence()
+			}
+			return
+		case "TRIGGER", "FUNCTION", "PROCEDURE", "PROC", "DATABASE", "ROLE", "USER":
+			return
+		case "UNIQUE":
+			isUnique = true
+		case "MATERIALIZED":
+			isMaterialized = true
+		case "UNLOGGED":
+			isUnlogged = true
+		case "TEMP", "TEMPORARY":
+			isTemporary = true
+		case "VIRTUAL":
+			isVirtual = true
+		case "REPLACE":
+			orReplace = true
+		case "FULLTEXT", "SPATIAL", "CLUSTERED", "NONCLUSTERED":
+			indexType = strings.ToUpper(tok.value)
+		}
+	}
+}
+
+func (s *sqlStatement) parseCreateTable(isUnlogged, isVirtual bool) {
+	ifNotExists := s.accept("IF", "NOT", "EXISTS")
+	nameTok := s.tok(0)
+	schemaName, tableName, ok := s.parseObjectName("table name")
+	if !ok {
+		return
+	}
+	if !s.p.includeObjectType("TABLES") {
+		return
+	}
+	schemaName = s.p.defaultSchema(schemaName)
+	if s.accept("PARTITION", "OF") {
+		s.parseCreatePartition(schemaName, tableName)
+		return
+	}
+	if !isVirtual && !s.tok(0).isPunct("(") {
+		// CREATE TABLE ... AS SELECT, the columns are unknown.
+		return
+	}
+	schema := s.p.cache.GetOrCreateSchema(s.p.catalog, schemaName)
+	if s.p.cache.GetTable(schema, tableName) != nil {
+		if !ifNotExists {
+			s.p.report(s.location(nameTok), fmt.Sprintf("table %s already exists", tableName))
+		}
+		return
+	}
+	table := s.p.cache.GetOrCreateTable(schema, tableName)
+	table.IsUnlogged = isUnlogged && s.p.dialect == DialectPostgres
+	s.p.tables = append(s.p.tables, [2]string{table.TableSchema, table.TableName})
+	if isVirtual {
+		s.parseVirtualTable(table)
+		return
+	}
+	s.i++ // (
+	if s.acceptPunct(")") {
+		s.parseTableOptions(table)
+		return
+	}
+	for {
+		if !s.parseTableElement(table) {
+			return
+		}
+		s.skipToElementEnd()
+		if s.acceptPunct(",") {
+			continue
+		}
+		if !s.expectPunct(")") {
+			return
+		}
+		break
+	}
+	s.parseTableOptions(table)
+}
+
+// parseVirtualTable parses the rest of a CREATE VIRTUAL TABLE statement
+// (SQLite only). Module arguments that consist of a single identifier are
+// treated as columns.
+func (s *sqlStatement) parseVirtualTable(table *Table) {
+	table.IsVirtual = true
+	table.SQL = s.file.src[s.tokens[0].start:s.tokens[len(s.tokens)-1].end] + ";"
+	if !s.expect("USING") {
+		return
+	}
+	if _, ok := s.parseIdentifier("module name"); !ok {
+		return
+	}
+	if !s.acceptPunct("(") {
+		return
+	}
+	for !s.atEnd() {
+		start := s.i
+		s.skipToElementEnd()
+		args := s.tokens[start:s.i]
+		if len(args) == 1 || (len(args) == 2 && args[1].is("UNINDEXED")) {
+			if args[0].isIdentifier() {
+				s.p.cache.GetOrCreateColumn(table, args[0].value, "")
+			}
+		}
+		if !s.acceptPunct(",") {
+			break
+		}
+	}
+	s.expectPunct(")")
+}
+
+// parseCreatePartition parses the rest of a CREATE TABLE ... PARTITION OF
+// statement (Postgres only).
+func (s *sqlStatement) parseCreatePartition(schemaName, partitionName string) {
+	parentTok := s.tok(0)
+	parentSchemaName, parentName, ok := s.parseObjectName("table name")
+	if !ok {
+		return
+	}
+	parentSchemaName = s.p.defaultSchema(parentSchemaName)
+	parent := s.p.cache.GetTable(s.p.cache.GetSchema(s.p.catalog, parentSchemaName), parentName)
+	if parent == nil {
+		s.p.report(s.location(parentTok), fmt.Sprintf("table %s does not exist", parentName))
+		return
+	}
+	if s.tok(0).isPunct("(") {
+		if _, ok := s.parseGroup(); !ok {
+			return
+		}
+	}
+	if !s.tok(0).is("FOR") && !s.tok(0).is("DEFAULT") {
+		s.errorf("expected FOR VALUES or DEFAULT, got %s", s.describe(s.tok(0)))
+		return
+	}
+	start := s.i
+	for !s.atEnd() && !s.tok(0).is("PARTITION") && !s.tok(0).is("WITH") && !s.tok(0).is("TABLESPACE") {
+		if s.tok(0).isPunct("(") {
+			if _, ok := s.parseGroup(); !ok {
+				return
+			}
+			continue
+		}
+		s.i++
+	}
+	for _, partition := range parent.Partitions {
+		if partition.PartitionName == partitionName {
+			s.p.report(s.location(parentTok), fmt.Sprintf("partition %s already exists", partitionName))
+			return
+		}
+	}
+	parent.Partitions = append(parent.Partitions, Partition{
+		TableSchema:    parent.TableSchema,
+		TableName:      parent.TableName,
+		PartitionName:  partitionName,
+		PartitionBound: s.text(start),
+	})
+}
+
+// parseTableElement parses a column definition, a table constraint or (MySQL
+// only) an index definition.
+func (s *sqlStatement) parseTableElement(table *Table) bool {
+	tok := s.tok(0)
+	switch {
+	case tok.is("CONSTRAINT"), tok.is("PRIMARY"), tok.is("UNIQUE"), tok.is("FOREIGN"), tok.is("CHECK"),
+		tok.is("EXCLUDE") && (s.tok(1).is("USING") || s.tok(1).isPunct("(")):
+		return s.parseTableConstraint(table)
+	case tok.is("LIKE"):
+		s.skipToElementEnd()
+		return true
+	case s.p.dialect == DialectMySQL && (tok.is("INDEX") || tok.is("KEY") || tok.is("FULLTEXT") || tok.is("SPATIAL")):
+		return s.parseTableIndex(table)
+	}
+	return s.parseColumnDefinition(table)
+}
+
+// sqlColumnTypeTerminators are the keywords which end a column type.
+var sqlColumnTypeTerminators = map[string]struct{}{
+	"CONSTRAINT": {}, "NOT": {}, "NULL": {}, "PRIMARY": {}, "UNIQUE": {},
+	"CHECK": {}, "REFERENCES": {}, "DEFAULT": {}, "GENERATED": {}, "AS": {},
+	"AUTO_INCREMENT": {}, "AUTOINCREMENT": {}, "IDENTITY": {}, "COLLATE": {},
+	"COMMENT": {}, "ON": {}, "CHARSET": {}, "STORED": {}, "VIRTUAL": {},
+	"PERSISTED": {}, "SPARSE": {}, "ROWGUIDCOL": {}, "INVISIBLE": {},
+	"VISIBLE": {}, "KEY": {},
+}
+
+func (s *sqlStatement) parseColumnDefinition(table *Table) bool {
+	nameTok := s.tok(0)
+	columnName, ok := s.parseIdentifier("column name")
+	if !ok {
+		return false
+	}
+	if s.p.cache.GetColumn(table, columnName) != nil {
+		s.p.report(s.location(nameTok), fmt.Sprintf("column %s already exists in table %s", columnName, table.TableName))
+		s.skipToElementEnd()
+		return true
+	}
+	column := Column{
+		TableSchema: table.TableSchema,
+		TableName:   table.TableName,
+		ColumnName:  columnName,
+	}
+	isTypeTerminator := func(tok sqlToken) bool {
+		if tok.typ != sqlWord {
+			return false
+		}
+		if _, ok := sqlColumnTypeTerminators[strings.ToUpper(tok.value)]; ok {
+			return true
+		}
+		return tok.is("CHARACTER") && s.tok(1).is("SET")
+	}
+	// The column type may be omitted e.g. a SQL Server computed column.
+	if !isTypeTerminator(s.tok(0)) {
+		column.ColumnType = strings.Join(strings.Fields(s.parseText(isTypeTerminator)), " ")
+	}
+	normalizedType, arg1, arg2 := normalizeColumnType(s.p.dialect, column.ColumnType)
+	switch normalizedType {
+	case "VARBINARY", "BINARY", "NVARCHAR", "VARCHAR", "CHAR", "VARCHAR2", "NVARCHAR2", "RAW":
+		column.CharacterLength = arg1
+	case "NUMERIC", "NUMBER":
+		column.NumericPrecision, column.NumericScale = arg1, arg2
+	}
+
+	var constraints []Constraint
+	var constraintName string
+	for !s.atElementEnd() {
+		tok := s.tok(0)
+		switch {
+		case s.accept("CONSTRAINT"):
+			constraintName, ok = s.parseIdentifier("constraint name")
+			if !ok {
+				return false
+			}
+			continue
+		case s.accept("NOT", "NULL"):
+			column.IsNotNull = true
+		case s.accept("NULL"):
+		case s.accept("PRIMARY", "KEY"):
+			constraint := Constraint{ConstraintName: constraintName, ConstraintType: PRIMARY_KEY, Columns: []string{columnName}}
+			s.accept("ASC")
+			s.accept("DESC")
+			s.parseConstraintAttributes(&constraint)
+			constraints = append(constraints, constraint)
+		case s.accept("UNIQUE"):
+			s.accept("KEY")
+			constraint := Constraint{ConstraintName: constraintName, ConstraintType: UNIQUE, Columns: []string{columnName}}
+			s.parseConstraintAttributes(&constraint)
+			constraints = append(constraints, constraint)
+		case s.accept("CHECK"):
+			checkExpr, ok := s.parseGroup()
+			if !ok {
+				return false
+			}
+			constraint := Constraint{ConstraintName: constraintName, ConstraintType: CHECK, Columns: []string{columnName}, CheckExpr: checkExpr}
+			s.parseConstraintAttributes(&constraint)
+			constraints = append(constraints, constraint)
+		case s.accept("REFERENCES"):
+			constraint := Constraint{ConstraintName: constraintName, ConstraintType: FOREIGN_KEY, Columns: []string{columnName}}
+			if !s.parseReferences(&constraint) {
+				return false
+			}
+			constraints = append(constraints, constraint)
+		case s.accept("DEFAULT"):
+			column.ColumnDefault = s.parseText(func(tok sqlToken) bool {
+				if tok.typ != sqlWord {
+					return false
+				}
+				_, ok := sqlColumnTypeTerminators[strings.ToUpper(tok.value)]
+				return ok
+			})
+			if column.ColumnDefault == "" {
+				s.errorf("expected default value, got %s", s.describe(s.tok(0)))
+				return false
+			}
+			if s.p.dialect != DialectPostgres && !isLiteral(column.ColumnDefault) {
+				column.ColumnDefault = wrapBrackets(column.ColumnDefault)
+			}
+		case s.accept("GENERATED", "ALWAYS", "AS", "IDENTITY"):
+			column.ColumnIdentity = ALWAYS_IDENTITY
+			if !s.parseIdentityOptions(&column) {
+				return false
+			}
+		case s.accept("GENERATED", "BY", "DEFAULT", "AS", "IDENTITY"):
+			column.ColumnIdentity = DEFAULT_IDENTITY
+			if !s.parseIdentityOptions(&column) {
+				return false
+			}
+		case s.accept("GENERATED", "ALWAYS", "AS"), s.accept("AS"):
+			column.GeneratedExpr, ok = s.parseGroup()
+			if !ok {
+				return false
+			}
+			if s.accept("STORED") || s.accept("PERSISTED") {
+				column.GeneratedExprStored = true
+			} else {
+				s.accept("VIRTUAL")
+			}
+		case s.accept("IDENTITY"):
+			column.ColumnIdentity = IDENTITY
+			if s.tok(0).isPunct("(") {
+				args, ok := s.parseGroup()
+				if !ok {
+					return false
+				}
+				if seed, increment, found := strings.Cut(args, ","); found {
+					column.IdentityStart, _ = strconv.ParseInt(strings.TrimSpace(seed), 10, 64)
+					column.IdentityIncrement, _ = strconv.ParseInt(strings.TrimSpace(increment), 10, 64)
+				}
+			}
+		case s.accept("AUTO_INCREMENT"), s.accept("AUTOINCREMENT"):
+			column.IsAutoincrement = true
+		case s.accept("ON", "UPDATE"):
+			if s.tok(0).is("CURRENT_TIMESTAMP") || s.tok(0).is("NOW") || s.tok(0).is("LOCALTIMESTAMP") {
+				column.OnUpdateCurrentTimestamp = true
+			}
+			s.i++
+			if s.tok(0).isPunct("(") {
+				if _, ok := s.parseGroup(); !ok {
+					return false
+				}
+			}
+		case s.accept("ON", "CONFLICT"):
+			s.i++
+		case s.accept("COLLATE"):
+			column.CollationName, ok = s.parseValue("collation name")
+			if !ok {
+				return false
+			}
+		case s.accept("COMMENT"):
+			column.Comment, ok = s.parseString("comment")
+			if !ok {
+				return false
+			}
+		case s.accept("CHARACTER", "SET"), s.accept("CHARSET"):
+			s.i++
+		case tok.isPunct("("):
+			if _, ok := s.parseGroup(); !ok {
+				return false
+			}
+		default:
+			s.i++
+		}
+		constraintName = ""
+	}
+	s.p.cache.AddOrUpdateColumn(table, column)
+	for _, constraint := range constraints {
+		s.addConstraint(table, constraint, s.location(nameTok), nil)
+	}
+	return true
+}
+
+// parseIdentityOptions parses the optional sequence options of an identity
+// column e.g. (START WITH 10 INCREMENT BY 10).
+func (s *sqlStatement) parseIdentityOptions(column *Column) bool {
+	if !s.tok(0).isPunct("(") {
+		return true
+	}
+	options, ok := s.parseGroup()
+	if !ok {
+		return false
+	}
+	fields := strings.Fields(strings.ToUpper(options))
+	for i := 0; i < len(fields); i++ {
+		var num *int64
+		switch fields[i] {
+		case "START":
+			num = &column.IdentityStart
+		case "INCREMENT":
+			num = &column.IdentityIncrement
+		default:
+			continue
+		}
+		if i+1 < len(fields) && (fields[i+1] == "WITH" || fields[i+1] == "BY") {
+			i++
+		}
+		if i+1 < len(fields) {
+			*num, _ = strconv.ParseInt(fields[i+1], 10, 64)
+			i++
+		}
+	}
+	return true
+}
+
+// parseTableConstraint parses a table constraint, optionally preceded by
+// CONSTRAINT <name>.
+func (s *sqlStatement) parseTableConstraint(table *Table) bool {
+	var constraint Constraint
+	var ok bool
+	if s.accept("CONSTRAINT") {
+		constraint.ConstraintName, ok = s.parseIdentifier("constraint name")
+		if !ok {
+			return false
+		}
+	}
+	loc := s.location(s.tok(0))
+	var columnsToCheck []string
+	switch {
+	case s.accept("PRIMARY", "KEY"), s.accept("UNIQUE"):
+		constraint.ConstraintType = PRIMARY_KEY
+		if s.tokens[s.i-1].is("UNIQUE") {
+			constraint.ConstraintType = UNIQUE
+			if !s.accept("KEY") {
+				s.accept("INDEX")
+			}
+			s.accept("NULLS", "DISTINCT")
+			s.accept("NULLS", "NOT", "DISTINCT")
+		}
+		if s.accept("CLUSTERED") {
+			constraint.IsClustered = true
+		} else if !s.accept("NONCLUSTERED") {
+			constraint.IsClustered = constraint.ConstraintType == PRIMARY_KEY && s.p.dialect == DialectSQLServer
+		}
+		if s.p.dialect == DialectMySQL && s.tok(0).isIdentifier() && !s.tok(0).is("USING") {
+			// MySQL allows naming the underlying index.
+			indexName, _ := s.parseIdentifier("index name")
+			if constraint.ConstraintName == "" {
+				constraint.ConstraintName = indexName
+			}
+		}
+		if s.accept("USING") {
+			s.i++
+		}
+		elements, ok := s.parseIndexElements()
+		if !ok {
+			return false
+		}
+		for _, element := range elements {
+			constraint.Columns = append(constraint.Columns, element.expr)
+		}
+		columnsToCheck = constraint.Columns
+	case s.accept("FOREIGN", "KEY"):
+		constraint.ConstraintType = FOREIGN_KEY
+		if s.tok(0).isIdentifier() {
+			// MySQL allows naming the underlying index.
+			indexName, _ := s.parseIdentifier("index name")
+			if constraint.ConstraintName == "" {
+				constraint.ConstraintName = indexName
+			}
+		}
+		elements, ok := s.parseIndexElements()
+		if !ok {
+			return false
+		}
+		for _, element := range elements {
+			constraint.Columns = append(constraint.Columns, element.expr)
+		}
+		columnsToCheck = constraint.Columns
+		if !s.expect("REFERENCES") || !s.parseReferences(&constraint) {
+			return false
+		}
+		if len(constraint.ReferencesColumns) > 0 && len(constraint.ReferencesColumns) != len(constraint.Columns) {
+			s.p.report(loc, fmt.Sprintf("number of referencing columns (%d) does not match number of referenced columns (%d)", len(constraint.Columns), len(constraint.ReferencesColumns)))
+			return false
+		}
+	case s.accept("CHECK"):
+		constraint.ConstraintType = CHECK
+		constraint.CheckExpr, ok = s.parseGroup()
+		if !ok {
+			return false
+		}
+	case s.accept("EXCLUDE"):
+		constraint.ConstraintType = EXCLUDE
+		if s.accept("USING") {
+			constraint.ExclusionIndexType, ok = s.parseIdentifier("index method")
+			if !ok {
+				return false
+			}
+		}
+		elements, ok := s.parseIndexElements()
+		if !ok {
+			return false
+		}
+		for _, element := range elements {
+			constraint.Columns = append(constraint.Columns, element.expr)
+			constraint.ExclusionOperators = append(constraint.ExclusionOperators, element.operator)
+			if element.isColumn {
+				columnsToCheck = append(columnsToCheck, element.expr)
+			}
+		}
+		if s.accept("WHERE") {
+			constraint.ExclusionPredicate, ok = s.parseGroup()
+			if !ok {
+				return false
+			}
+		}
+	default:
+		s.errorf("expected PRIMARY KEY, UNIQUE, FOREIGN KEY, CHECK or EXCLUDE, got %s", s.describe(s.tok(0)))
+		return false
+	}
+	s.parseConstraintAttributes(&constraint)
+	s.addConstraint(table, constraint, loc, columnsToCheck)
+	return true
+}
+
+// parseReferences parses the rest of a REFERENCES clause.
+func (s *sqlStatement) parseReferences(constraint *Constraint) bool {
+	schemaName, tableName, ok := s.parseObjectName("table name")
+	if !ok {
+		return false
+	}
+	constraint.ReferencesSchema = s.p.defaultSchema(schemaName)
+	constraint.ReferencesTable = tableName
+	if s.tok(0).isPunct("(") {
+		elements, ok := s.parseIndexElements()
+		if !ok {
+			return false
+		}
+		for _, element := range elements {
+			constraint.ReferencesColumns = append(constraint.ReferencesColumns, element.expr)
+		}
+	}
+	for {
+		switch {
+		case s.accept("MATCH", "FULL"):
+			constraint.MatchOption = "MATCH FULL"
+		case s.accept("MATCH", "PARTIAL"):
+			constraint.MatchOption = "MATCH PARTIAL"
+		case s.accept("MATCH", "SIMPLE"):
+		case s.accept("ON", "DELETE"):
+			constraint.DeleteRule, ok = s.parseReferentialAction()
+			if !ok {
+				return false
+			}
+		case s.accept("ON", "UPDATE"):
+			constraint.UpdateRule, ok = s.parseReferentialAction()
+			if !ok {
+				return false
+			}
+		default:
+			s.parseConstraintAttributes(constraint)
+			return true
+		}
+	}
+}
+
+func (s *sqlStatement) parseReferentialAction() (string, bool) {
+	switch {
+	case s.accept("CASCADE"):
+		return CASCADE, true
+	case s.accept("RESTRICT"):
+		return RESTRICT, true
+	case s.accept("NO", "ACTION"):
+		return NO_ACTION, true
+	case s.accept("SET", "NULL"):
+		return SET_NULL, true
+	case s.accept("SET", "DEFAULT"):
+		return SET_DEFAULT, true
+	}
+	s.errorf("expected CASCADE, RESTRICT, NO ACTION, SET NULL or SET DEFAULT, got %s", s.describe(s.tok(0)))
+	return "", false
+}
+
+// parseConstraintAttributes parses the attributes that may follow a
+// constraint e.g. DEFERRABLE, NOT VALID.
+func (s *sqlStatement) parseConstraintAttributes(constraint *Constraint) {
+	for {
+		switch {
+		case s.accept("DEFERRABLE"):
+			constraint.IsDeferrable = true
+		case s.accept("NOT", "DEFERRABLE"):
+			constraint.IsDeferrable = false
+		case s.accept("INITIALLY", "DEFERRED"):
+			constraint.IsInitiallyDeferred = true
+		case s.accept("INITIALLY", "IMMEDIATE"):
+			constraint.IsInitiallyDeferred = false
+		case s.accept("NOT", "VALID"):
+			constraint.IsNotValid = true
+		case s.accept("NOT", "FOR", "REPLICATION"), s.accept("NOT", "ENFORCED"), s.accept("ENFORCED"), s.accept("NO", "INHERIT"):
+		case s.accept("CLUSTERED"):
+			constraint.IsClustered = true
+		case s.accept("NONCLUSTERED"):
+			constraint.IsClustered = false
+		case s.accept("ON", "CONFLICT"):
+			s.i++
+		default:
+			return
+		}
+	}
+}
+
+// addConstraint adds a constraint to the table, generating a name for it if
+// it has none.
+func (s *sqlStatement) addConstraint(table *Table, constraint Constraint, loc location, columnsToCheck []string) {
+	if constraint.ConstraintName == "" {
+		if s.p.dialect == DialectMySQL && constraint.ConstraintType == PRIMARY_KEY {
+			constraint.ConstraintName = "PRIMARY"
+		} else {
+			var columnNames []string
+			if constraint.ConstraintType != CHECK || len(constraint.Columns) == 1 {
+				columnNames = constraint.Columns
+			}
+			constraint.ConstraintName = GenerateName(constraint.ConstraintType, table.TableName, columnNames)
+			for n := 1; s.p.cache.GetConstraint(table, constraint.ConstraintName) != nil; n++ {
+				constraint.ConstraintName = GenerateName(constraint.ConstraintType, table.TableName, columnNames) + strconv.Itoa(n)
+			}
+		}
+	}
+	if s.p.cache.GetConstraint(table, constraint.ConstraintName) != nil {
+		s.p.report(loc, fmt.Sprintf("constraint %s already exists in table %s", constraint.ConstraintName, table.TableName))
+		return
+	}
+	if constraint.ConstraintType == PRIMARY_KEY && s.p.cache.GetPrimaryKey(table) != nil {
+		s.p.report(loc, fmt.Sprintf("table %s already has a primary key", table.TableName))
+		return
+	}
+	if s.isNotValid && (constraint.ConstraintType == FOREIGN_KEY || constraint.ConstraintType == CHECK) {
+		constraint.IsNotValid = true
+	}
+	s.p.cache.AddOrUpdateConstraint(table, constraint)
+	if len(columnsToCheck) > 0 {
+		s.columnChecks = append(s.columnChecks, sqlColumnCheck{
+			loc:         loc,
+			tableSchema: table.TableSchema,
+			tableName:   table.TableName,
+			objectName:  constraint.ConstraintName,
+			columns:     columnsToCheck,
+		})
+	}
+	if constraint.ConstraintType == FOREIGN_KEY {
+		s.p.fkeyChecks = append(s.p.fkeyChecks, sqlFkeyCheck{
+			loc:            loc,
+			tableSchema:    table.TableSchema,
+			tableName:      table.TableName,
+			constraintName: constraint.ConstraintName,
+		})
+	}
+}
+
+// sqlIndexElement is an element of an index, constraint or exclusion
+// constraint column list.
+type sqlIndexElement struct {
+	expr       string
+	isColumn   bool
+	descending bool
+	opclass    string
+	operator   string
+}
+
+// parseIndexElements parses a bracketed list of columns or expressions
+// together with their ordering, opclass and (for exclusion constraints)
+// operator.
+func (s *sqlStatement) parseIndexElements() ([]sqlIndexElement, bool) {
+	if !s.expectPunct("(") {
+		return nil, false
+	}
+	var elements []sqlIndexElement
+	for {
+		var element sqlIndexElement
+		start := s.i
+		tok := s.tok(0)
+		switch {
+		case tok.isPunct("("):
+			if _, ok := s.parseGroup(); !ok {
+				return nil, false
+			}
+			element.expr = s.text(start)
+		case tok.isIdentifier() && s.tok(1).isPunct("(") && !(s.p.dialect == DialectMySQL && s.tok(2).typ == sqlNumber && s.tok(3).isPunct(")")):
+			// A function call.
+			s.i++
+			if _, ok := s.parseGroup(); !ok {
+				return nil, false
+			}
+			element.expr = s.text(start)
+		case tok.isIdentifier():
+			element.expr, _ = s.parseIdentifier("column name")
+			element.isColumn = true
+			if s.tok(0).isPunct("(") {
+				// A MySQL index prefix length e.g. title(10).
+				s.parseGroup()
+			}
+		default:
+			s.errorf("expected column name or expression, got %s", s.describe(tok))
+			return nil, false
+		}
+	options:
+		for {
+			switch {
+			case s.accept("COLLATE"):
+				if _, ok := s.parseValue("collation name"); !ok {
+					return nil, false
+				}
+			case s.accept("ASC"):
+			case s.accept("DESC"):
+				element.descending = true
+			case s.accept("NULLS", "FIRST"), s.accept("NULLS", "LAST"):
+			case s.accept("WITH"):
+				element.operator = s.parseText(func(sqlToken) bool { return false })
+			case s.p.dialect == DialectPostgres && s.tok(0).isIdentifier():
+				element.opclass, _ = s.parseIdentifier("opclass")
+				if s.tok(0).isPunct("(") {
+					s.parseGroup()
+				}
+			default:
+				break options
+			}
+		}
+		elements = append(elements, element)
+		if s.acceptPunct(",") {
+			continue
+		}
+		if !s.expectPunct(")") {
+			return nil, false
+		}
+		return elements, true
+	}
+}
+
+// parseTableIndex parses an index definition inside a CREATE TABLE statement
+// (MySQL only) e.g. INDEX actor_last_name_idx (last_name).
+func (s *sqlStatement) parseTableIndex(table *Table) bool {
+	loc := s.location(s.tok(0))
+	var index Index
+	if s.accept("FULLTEXT") || s.accept("SPATIAL") {
+		index.IndexType = strings.ToUpper(s.tokens[s.i-1].value)
+	}
+	if !s.accept("INDEX") {
+		s.accept("KEY")
+	}
+	if s.tok(0).isIdentifier() && !s.tok(0).is("USING") {
+		index.IndexName, _ = s.parseIdentifier("index name")
+	}
+	if s.accept("USING") {
+		index.IndexType, _ = s.parseIdentifier("index type")
+	}
+	elements, ok := s.parseIndexElements()
+	if !ok {
+		return false
+	}
+	if s.accept("USING") {
+		index.IndexType, _ = s.parseIdentifier("index type")
+	}
+	s.addIndex(table, index, elements, loc)
+	return true
+}
+
+// addIndex adds an index to the table, generating a name for it if it has
+// none.
+func (s *sqlStatement) addIndex(table *Table, index Index, elements []sqlIndexElement, loc location) {
+	var columnsToCheck []string
+	hasDescending, hasOpclass := false, false
+	for _, element := range elements {
+		index.Columns = append(index.Columns, element.expr)
+		if element.isColumn {
+			columnsToCheck = append(columnsToCheck, element.expr)
+		}
+		hasDescending = hasDescending || element.descending
+		hasOpclass = hasOpclass || element.opclass != ""
+	}
+	if hasDescending {
+		index.Descending = make([]bool, len(elements))
+		for i, element := range elements {
+			index.Descending[i] = element.descending
+		}
+	}
+	if hasOpclass {
+		index.Opclasses = make([]string, len(elements))
+		for i, element := range elements {
+			index.Opclasses[i] = element.opclass
+		}
+	}
+	if index.IndexName == "" {
+		index.IndexName = GenerateName(INDEX, table.TableName, columnsToCheck)
+	}
+	if s.p.cache.GetIndex(table, index.IndexName) != nil {
+		s.p.report(loc, fmt.Sprintf("index %s already exists in table %s", index.IndexName, table.TableName))
+		return
+	}
+	s.p.cache.AddOrUpdateIndex(table, index)
+	s.columnChecks = append(s.columnChecks, sqlColumnCheck{
+		loc:         loc,
+		tableSchema: table.TableSchema,
+		tableName:   table.TableName,
+		objectName:  index.IndexName,
+		columns:     append(columnsToCheck, index.IncludeColumns...),
+	})
+}
+
+// parseTableOptions parses the options that follow the column definitions of
+// a CREATE TABLE statement.
+func (s *sqlStatement) parseTableOptions(table *Table) {
+	var ok bool
+	for !s.atEnd() {
+		switch {
+		case s.acceptPunct(","):
+		case s.accept("WITHOUT", "ROWID"):
+			table.IsWithoutRowid = true
+		case s.accept("STRICT"):
+			table.IsStrict = true
+		case s.accept("ENGINE"):
+			s.acceptPunct("=")
+			table.Engine, ok = s.parseValue("engine")
+			if !ok {
+				return
+			}
+		case s.accept("DEFAULT", "CHARSET"), s.accept("CHARSET"), s.accept("DEFAULT", "CHARACTER", "SET"), s.accept("CHARACTER", "SET"):
+			s.acceptPunct("=")
+			table.Charset, ok = s.parseValue("charset")
+			if !ok {
+				return
+			}
+		case s.accept("COMMENT"):
+			s.acceptPunct("=")
+			table.Comment, ok = s.parseString("comment")
+			if !ok {
+				return
+			}
+		case s.accept("PARTITION", "BY"):
+			if !s.parsePartitionBy(table) {
+				return
+			}
+		case s.accept("WITH"):
+			if !s.tok(0).isPunct("(") {
+				continue
+			}
+			params, ok := s.parseGroup()
+			if !ok {
+				return
+			}
+			if s.p.dialect != DialectPostgres {
+				continue
+			}
+			for _, param := range splitArgs(params) {
+				name, value, _ := strings.Cut(param, "=")
+				table.StorageParams = append(table.StorageParams, strings.TrimSpace(name)+"="+strings.TrimSpace(value))
+			}
+		case s.tok(0).isPunct("("):
+			if _, ok := s.parseGroup(); !ok {
+				return
+			}
+		default:
+			s.i++
+		}
+	}
+}
+
+// parsePartitionBy parses the rest of a PARTITION BY clause.
+func (s *sqlStatement) parsePartitionBy(table *Table) bool {
+	start := s.i
+	for s.tok(0).typ == sqlWord {
+		s.i++
+	}
+	table.PartitionStrategy = strings.ToUpper(strings.Join(strings.Fields(s.text(start)), " "))
+	if table.PartitionStrategy == "" {
+		s.errorf("expected partition strategy, got %s", s.describe(s.tok(0)))
+		return false
+	}
+	var ok bool
+	table.PartitionKey, ok = s.parseGroup()
+	if !ok {
+		return false
+	}
+	if s.accept("PARTITIONS") {
+		s.i++
+	}
+	if s.p.dialect != DialectMySQL || !s.tok(0).isPunct("(") {
+		return true
+	}
+	// MySQL partition definitions.
+	s.i++
+	for {
+		if !s.expect("PARTITION") {
+			return false
+		}
+		partitionName, ok := s.parseIdentifier("partition name")
+		if !ok {
+			return false
+		}
+		table.Partitions = append(table.Partitions, Partition{
+			TableSchema:    table.TableSchema,
+			TableName:      table.TableName,
+			PartitionName:  partitionName,
+			PartitionBound: s.parseText(func(sqlToken) bool { return false }),
+		})
+		if s.acceptPunct(",") {
+			continue
+		}
+		return s.expectPunct(")")
+	}
+}
+
+func (s *sqlStatement) parseCreateIndex(isUnique bool, indexType string) {
+	loc := s.location(s.tok(0))
+	index := Index{IsUnique: isUnique}
+	if indexType != "NONCLUSTERED" {
+		index.IndexType = indexType
+	}
+	s.accept("CONCURRENTLY")
+	ifNotExists := s.accept("IF", "NOT", "EXISTS")
+	var ok bool
+	if !s.tok(0).is("ON") {
+		_, index.IndexName, ok = s.parseObjectName("index name")
+		if !ok {
+			return
+		}
+	}
+	if s.accept("USING") {
+		index.IndexType, ok = s.parseIdentifier("index type")
+		if !ok {
+			return
+		}
+	}
+	if !s.expect("ON") {
+		return
+	}
+	s.accept("ONLY")
+	tableTok := s.tok(0)
+	schemaName, tableName, ok := s.parseObjectName("table name")
+	if !ok {
+		return
+	}
+	if s.accept("USING") {
+		index.IndexType, ok = s.parseIdentifier("index type")
+		if !ok {
+			return
+		}
+	}
+	elements, ok := s.parseIndexElements()
+	if !ok {
+		return
+	}
+	for !s.atEnd() {
+		switch {
+		case s.accept("INCLUDE"):
+			includeElements, ok := s.parseIndexElements()
+			if !ok {
+				return
+			}
+			for _, element := range includeElements {
+				index.IncludeColumns = append(index.IncludeColumns, element.expr)
+			}
+		case s.accept("WHERE"):
+			index.Predicate = s.parseText(func(tok sqlToken) bool { return tok.is("WITH") || tok.is("ON") })
+		case s.accept("USING"):
+			index.IndexType, ok = s.parseIdentifier("index type")
+			if !ok {
+				return
+			}
+		case s.accept("COMMENT"):
+			index.Comment, ok = s.parseString("comment")
+			if !ok {
+				return
+			}
+		case s.tok(0).isPunct("("):
+			if _, ok := s.parseGroup(); !ok {
+				return
+			}
+		default:
+			s.i++
+		}
+	}
+	if !s.p.includeObjectType("TABLES") {
+		return
+	}
+	schemaName = s.p.defaultSchema(schemaName)
+	schema := s.p.cache.GetSchema(s.p.catalog, schemaName)
+	table := s.p.cache.GetTable(schema, tableName)
+	if table == nil {
+		if view := s.p.cache.GetView(schema, tableName); view != nil {
+			index.IsViewIndex = true
+			for _, element := range elements {
+				index.Columns = append(index.Columns, element.expr)
+			}
+			if index.IndexName == "" {
+				index.IndexName = GenerateName(INDEX, view.ViewName, index.Columns)
+			}
+			if s.p.cache.GetViewIndex(view, index.IndexName) == nil {
+				s.p.cache.AddOrUpdateViewIndex(view, index)
+			} else if !ifNotExists {
+				s.p.report(loc, fmt.Sprintf("index %s already exists in view %s", index.IndexName, view.ViewName))
+			}
+			return
+		}
+		s.p.report(s.location(tableTok), fmt.Sprintf("table %s does not exist", tableName))
+		return
+	}
+	if ifNotExists && index.IndexName != "" && s.p.cache.GetIndex(table, index.IndexName) != nil {
+		return
+	}
+	s.addIndex(table, index, elements, loc)
+}
+
+func (s *sqlStatement) parseCreateView(isMaterialized, orReplace bool) {
+	start := s.tokens[0].start
+	ifNotExists := s.accept("IF", "NOT", "EXISTS")
+	nameTok := s.tok(0)
+	schemaName, viewName, ok := s.parseObjectName("view name")
+	if !ok {
+		return
+	}
+	view := View{
+		ViewSchema:     s.p.defaultSchema(schemaName),
+		ViewName:       viewName,
+		IsMaterialized: isMaterialized,
+	}
+	if s.tok(0).isPunct("(") {
+		elements, ok := s.parseIndexElements()
+		if !ok {
+			return
+		}
+		for _, element := range elements {
+			view.Columns = append(view.Columns, element.expr)
+		}
+	}
+	for !s.atEnd() && !s.tok(0).is("AS") {
+		if s.tok(0).isPunct("(") {
+			if _, ok := s.parseGroup(); !ok {
+				return
+			}
+			continue
+		}
+		s.i++
+	}
+	if !s.expect("AS") {
+		return
+	}
+	if s.atEnd() {
+		s.errorf("expected query, got end of statement")
+		return
+	}
+	switch s.p.dialect {
+	case DialectSQLite, DialectSQLServer:
+		view.SQL = s.file.src[start:s.tokens[len(s.tokens)-1].end]
+	default:
+		view.SQL = s.file.src[s.tok(0).start:s.tokens[len(s.tokens)-1].end]
+	}
+	if !s.p.includeObjectType("VIEWS") {
+		return
+	}
+	schema := s.p.cache.GetOrCreateSchema(s.p.catalog, view.ViewSchema)
+	if s.p.cache.GetView(schema, viewName) != nil && !orReplace {
+		if !ifNotExists {
+			s.p.report(s.location(nameTok), fmt.Sprintf("view %s already exists", viewName))
+		}
+		return
+	}
+	s.p.cache.AddOrUpdateView(schema, view)
+}
+
+func (s *sqlStatement) parseCreateSchema() {
+	s.accept("IF", "NOT", "EXISTS")
+	// CREATE SCHEMA AUTHORIZATION <user> names the schema after the user.
+	s.accept("AUTHORIZATION")
+	schemaName, ok := s.parseIdentifier("schema name")
+	if !ok {
+		return
+	}
+	if s.p.dialect == DialectMySQL || s.p.dialect == DialectSQLite {
+		// A MySQL schema is a database, and SQLite has no CREATE SCHEMA.
+		return
+	}
+	s.p.cache.GetOrCreateSchema(s.p.catalog, schemaName)
+}
+
+func (s *sqlStatement) parseCreateExtension() {
+	s.accept("IF", "NOT", "EXISTS")
+	extension, ok := s.parseIdentifier("extension name")
+	if !ok || !s.p.includeObjectType("EXTENSIONS") {
+		return
+	}
+	s.p.cache.AddExtension(s.p.catalog, extension)
+}
+
+func (s *sqlStatement) parseCreateType() {
+	schemaName, typeName, ok := s.parseObjectName("type name")
+	if !ok || !s.accept("AS", "ENUM") {
+		return
+	}
+	enum := Enum{EnumSchema: s.p.defaultSchema(schemaName), EnumName: typeName}
+	if !s.expectPunct("(") {
+		return
+	}
+	for !s.acceptPunct(")") {
+		label, ok := s.parseString("enum label")
+		if !ok {
+			return
+		}
+		enum.EnumLabels = append(enum.EnumLabels, label)
+		if !s.acceptPunct(",") && !s.tok(0).isPunct(")") {
+			s.errorf("expected , or ), got %s", s.describe(s.tok(0)))
+			return
+		}
+	}
+	s.p.enums[strings.ToLower(typeName)] = struct{}{}
+	if !s.p.includeObjectType("ENUMS") {
+		return
+	}
+	schema := s.p.cache.GetOrCreateSchema(s.p.catalog, enum.EnumSchema)
+	s.p.cache.AddOrUpdateEnum(schema, enum)
+}
+
+func (s *sqlStatement) parseCreateDomain() {
+	schemaName, domainName, ok := s.parseObjectName("domain name")
+	if !ok {
+		return
+	}
+	domain := Domain{DomainSchema: s.p.defaultSchema(schemaName), DomainName: domainName}
+	s.accept("AS")
+	domain.UnderlyingType = strings.Join(strings.Fields(s.parseText(func(tok sqlToken) bool {
+		return tok.is("COLLATE") || tok.is("DEFAULT") || tok.is("CONSTRAINT") || tok.is("NOT") || tok.is("NULL") || tok.is("CHECK")
+	})), " ")
+	var checkName string
+	for !s.atEnd() {
+		switch {
+		case s.accept("COLLATE"):
+			domain.CollationName, ok = s.parseValue("collation name")
+			if !ok {
+				return
+			}
+		case s.accept("DEFAULT"):
+			domain.ColumnDefault = s.parseText(func(tok sqlToken) bool {
+				return tok.is("COLLATE") || tok.is("CONSTRAINT") || tok.is("NOT") || tok.is("NULL") || tok.is("CHECK")
+			})
+		case s.accept("CONSTRAINT"):
+			checkName, ok = s.parseIdentifier("constraint name")
+			if !ok {
+				return
+			}
+			continue
+		case s.accept("NOT", "NULL"):
+			domain.IsNotNull = true
+		case s.accept("NULL"):
+		case s.accept("CHECK"):
+			checkExpr, ok := s.parseGroup()
+			if !ok {
+				return
+			}
+			if checkName == "" {
+				checkName = GenerateName(CHECK, domainName, nil)
+			}
+			domain.CheckNames = append(domain.CheckNames, checkName)
+			domain.CheckExprs = append(domain.CheckExprs, checkExpr)
+		default:
+			s.i++
+		}
+		checkName = ""
+	}
+	s.p.domains[strings.ToLower(domainName)] = domain
+	if !s.p.includeObjectType("DOMAINS") {
+		return
+	}
+	schema := s.p.cache.GetOrCreateSchema(s.p.catalog, domain.DomainSchema)
+	s.p.cache.AddOrUpdateDomain(schema, domain)
+}
+
+func (s *sqlStatement) parseCreateSequence() {
+	s.accept("IF", "NOT", "EXISTS")
+	schemaName, sequenceName, ok := s.parseObjectName("sequence name")
+	if !ok {
+		return
+	}
+	sequence := Sequence{SequenceSchema: s.p.defaultSchema(schemaName), SequenceName: sequenceName}
+	for !s.atEnd() {
+		switch {
+		case s.accept("AS"):
+			sequence.DataType, ok = s.parseValue("data type")
+		case s.accept("START", "WITH"), s.accept("START"):
+			sequence.StartValue, ok = s.parseInt("start value")
+		case s.accept("INCREMENT", "BY"), s.accept("INCREMENT"):
+			sequence.IncrementBy, ok = s.parseInt("increment")
+		case s.accept("MINVALUE"):
+			sequence.MinValue, ok = s.parseInt("minimum value")
+		case s.accept("MAXVALUE"):
+			sequence.MaxValue, ok = s.parseInt("maximum value")
+		case s.accept("CYCLE"):
+			sequence.IsCycle = true
+		case s.accept("NO", "CYCLE"), s.accept("NOCYCLE"):
+			sequence.IsCycle = false
+		case s.accept("OWNED", "BY"):
+			var names []string
+			if s.accept("NONE") {
+				continue
+			}
+			names, ok = s.parseQualifiedName("column name")
+			if ok && len(names) >= 2 {
+				sequence.OwnedByTable = names[len(names)-2]
+				sequence.OwnedByColumn = names[len(names)-1]
+			}
+		default:
+			s.i++
+		}
+		if !ok {
+			return
+		}
+	}
+	if !s.p.includeObjectType("SEQUENCES") {
+		return
+	}
+	schema := s.p.cache.GetOrCreateSchema(s.p.catalog, sequence.SequenceSchema)
+	s.p.cache.AddOrUpdateSequence(schema, sequence)
+}
+
+func (s *sqlStatement) parseAlterTable() {
+	s.accept("IF", "EXISTS")
+	s.accept("ONLY")
+	tableTok := s.tok(0)
+	schemaName, tableName, ok := s.parseObjectName("table name")
+	if !ok {
+		return
+	}
+	if s.accept("WITH", "NOCHECK") {
+		s.isNotValid = true
+	} else {
+		s.accept("WITH", "CHECK")
+	}
+	if !s.tok(0).is("ADD") || !s.p.includeObjectType("TABLES") {
+		// Only ALTER TABLE ... ADD is supported.
+		return
+	}
+	table := s.p.cache.GetTable(s.p.cache.GetSchema(s.p.catalog, s.p.defaultSchema(schemaName)), tableName)
+	if table == nil {
+		s.p.report(s.location(tableTok), fmt.Sprintf("table %s does not exist", tableName))
+		return
+	}
+	for s.accept("ADD") {
+		for {
+			if s.accept("COLUMN") {
+				s.accept("IF", "NOT", "EXISTS")
+				ok = s.parseColumnDefinition(table)
+			} else {
+				ok = s.parseTableElement(table)
+			}
+			if !ok {
+				return
+			}
+			s.skipToElementEnd()
+			if !s.acceptPunct(",") {
+				return
+			}
+			// SQL Server adds a comma separated list of columns or
+			// constraints without repeating ADD.
+			if s.tok(0).is("ADD") || s.p.dialect != DialectSQLServer {
+				break
+			}
+		}
+	}
+}
+
+// parseComment parses a COMMENT ON statement (Postgres and Oracle).
+func (s *sqlStatement) parseComment() {
+	var objectType string
+	switch {
+	case s.accept("TABLE"):
+		objectType = "TABLE"
+	case s.accept("COLUMN"):
+		objectType = "COLUMN"
+	case s.accept("VIEW"), s.accept("MATERIALIZED", "VIEW"):
+		objectType = "VIEW"
+	default:
+		return
+	}
+	nameTok := s.tok(0)
+	names, ok := s.parseQualifiedName("name")
+	if !ok || !s.expect("IS") {
+		return
+	}
+	var comment string
+	if !s.accept("NULL") {
+		comment, ok = s.parseString("comment")
+		if !ok {
+			return
+		}
+	}
+	var schemaName, tableName, columnName string
+	if objectType == "COLUMN" {
+		if len(names) < 2 {
+			s.p.report(s.location(nameTok), "column name must be qualified by its table name")
+			return
+		}
+		names, columnName = names[:len(names)-1], names[len(names)-1]
+	}
+	tableName = names[len(names)-1]
+	if len(names) > 1 {
+		schemaName = names[len(names)-2]
+	}
+	schema := s.p.cache.GetSchema(s.p.catalog, s.p.defaultSchema(schemaName))
+	if objectType == "VIEW" {
+		if !s.p.includeObjectType("VIEWS") {
+			return
+		}
+		view := s.p.cache.GetView(schema, tableName)
+		if view == nil {
+			s.p.report(s.location(nameTok), fmt.Sprintf("view %s does not exist", tableName))
+			return
+		}
+		view.Comment = comment
+		return
+	}
+	if !s.p.includeObjectType("TABLES") {
+		return
+	}
+	table := s.p.cache.GetTable(schema, tableName)
+	if table == nil {
+		s.p.report(s.location(nameTok), fmt.Sprintf("table %s does not exist", tableName))
+		return
+	}
+	if objectType == "TABLE" {
+		table.Comment = comment
+		return
+	}
+	column := s.p.cache.GetColumn(table, columnName)
+	if column == nil {
+		s.p.report(s.location(nameTok), fmt.Sprintf("column %s does not exist in table %s", columnName, tableName))
+		return
+	}
+	column.Comment = comment
+}
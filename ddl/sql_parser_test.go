@@ -0,0 +1,236 @@
+package ddl
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bokwoon95/sqddl/internal/testutil"
+)
+
+func TestSQLParser(t *testing.T) {
+	for _, dialect := range []string{DialectSQLite, DialectPostgres, DialectMySQL, DialectSQLServer} {
+		dialect := dialect
+		t.Run(dialect, func(t *testing.T) {
+			t.Parallel()
+			var wantCatalog Catalog
+			b, err := os.ReadFile(filepath.Join("testdata", dialect, "schema.json"))
+			if err != nil {
+				t.Fatal(testutil.Callers(), err)
+			}
+			err = json.Unmarshal(b, &wantCatalog)
+			if err != nil {
+				t.Fatal(testutil.Callers(), err)
+			}
+			p := NewSQLParser(nil)
+			for _, name := range []string{"schema.sql", "constraints.sql", "indexes.sql"} {
+				file, err := os.Open(filepath.Join("testdata", dialect, name))
+				if err != nil {
+					t.Fatal(testutil.Callers(), err)
+				}
+				err = p.ParseFile(file)
+				file.Close()
+				if err != nil {
+					t.Fatal(testutil.Callers(), err)
+				}
+			}
+			gotCatalog := Catalog{Dialect: dialect, CurrentSchema: wantCatalog.CurrentSchema}
+			err = p.WriteCatalog(&gotCatalog)
+			if err != nil {
+				t.Fatal(testutil.Callers(), err)
+			}
+			gotCache := NewCatalogCache(&gotCatalog)
+			for _, wantSchema := range wantCatalog.Schemas {
+				gotSchema := gotCache.GetSchema(&gotCatalog, wantSchema.SchemaName)
+				for _, wantTable := range wantSchema.Tables {
+					gotTable := gotCache.GetTable(gotSchema, wantTable.TableName)
+					if gotTable == nil {
+						t.Errorf(testutil.Callers()+" table %s not found", wantTable.TableName)
+						continue
+					}
+					if diff := testutil.Diff(summarizeTable(dialect, *gotTable), summarizeTable(dialect, wantTable)); diff != "" {
+						t.Error(testutil.Callers(), wantTable.TableName, diff)
+					}
+				}
+			}
+		})
+	}
+}
+
+// summarizeTable returns the parts of a table that the SQLParser is expected
+// to reproduce from the SQL files.
+func summarizeTable(dialect string, table Table) []string {
+	var summary []string
+	if !isVirtualTable(&table) {
+		for _, column := range table.Columns {
+			columnType, _, _ := normalizeColumnType(dialect, column.ColumnType)
+			notNull := "notnull=" + strconv.FormatBool(column.IsNotNull)
+			if column.IsEnum || column.DomainName != "" {
+				columnType = ""
+			}
+			if dialect == DialectSQLServer && column.GeneratedExpr != "" {
+				// The type and nullability of a computed column are derived
+				// by SQL Server.
+				columnType, notNull = "", ""
+			}
+			summary = append(summary, "COLUMN "+column.ColumnName+" "+columnType+" "+notNull)
+		}
+	}
+	var objects []string
+	for _, constraint := range table.Constraints {
+		constraintName := constraint.ConstraintName
+		if dialect == DialectSQLite && constraint.ConstraintType != CHECK {
+			constraintName = ""
+		}
+		object := constraint.ConstraintType + " " + constraintName + " (" + strings.Join(constraint.Columns, ", ") + ")"
+		if constraint.ConstraintType == FOREIGN_KEY {
+			updateRule, deleteRule := constraint.UpdateRule, constraint.DeleteRule
+			if updateRule == "" {
+				updateRule = NO_ACTION
+			}
+			if deleteRule == "" {
+				deleteRule = NO_ACTION
+			}
+			object += " REFERENCES " + constraint.ReferencesTable + " (" + strings.Join(constraint.ReferencesColumns, ", ") + ") ON UPDATE " + updateRule + " ON DELETE " + deleteRule
+		}
+		objects = append(objects, object)
+	}
+	for _, index := range table.Indexes {
+		columns := index.Columns
+		if dialect == DialectSQLite {
+			// SQLite does not report the expressions of an index.
+			columns = nil
+			for _, column := range index.Columns {
+				if !strings.Contains(column, "(") {
+					columns = append(columns, column)
+				}
+			}
+		}
+		objects = append(objects, "INDEX "+index.IndexName+" ("+strings.Join(columns, ", ")+") unique="+strconv.FormatBool(index.IsUnique))
+	}
+	sort.Strings(objects)
+	return append(summary, objects...)
+}
+
+func TestSQLParserErrors(t *testing.T) {
+	type TT struct {
+		description string
+		dialect     string
+		sql         string
+		wantErr     string
+	}
+	tests := []TT{{
+		description: "unterminated string",
+		dialect:     DialectPostgres,
+		sql:         "CREATE TABLE actor (name TEXT DEFAULT 'abc);",
+		wantErr:     "1:39: unterminated string",
+	}, {
+		description: "missing closing bracket",
+		dialect:     DialectSQLite,
+		sql:         "CREATE TABLE actor (\n    actor_id INT\n;",
+		wantErr:     "1:20: missing closing bracket",
+	}, {
+		description: "duplicate table",
+		dialect:     DialectMySQL,
+		sql:         "CREATE TABLE actor (actor_id INT);\nCREATE TABLE actor (actor_id INT);",
+		wantErr:     "2:14: table actor already exists",
+	}, {
+		description: "duplicate table with IF NOT EXISTS",
+		dialect:     DialectMySQL,
+		sql:         "CREATE TABLE actor (actor_id INT);\nCREATE TABLE IF NOT EXISTS actor (actor_id INT);",
+	}, {
+		description: "duplicate column",
+		dialect:     DialectSQLServer,
+		sql:         "CREATE TABLE actor (actor_id INT, actor_id INT);",
+		wantErr:     "column actor_id already exists in table actor",
+	}, {
+		description: "constraint column does not exist",
+		dialect:     DialectPostgres,
+		sql:         "CREATE TABLE actor (actor_id INT, CONSTRAINT actor_actor_id_pkey PRIMARY KEY (id));",
+		wantErr:     "actor_actor_id_pkey: column id does not exist in table actor",
+	}, {
+		description: "multiple primary keys",
+		dialect:     DialectSQLite,
+		sql:         "CREATE TABLE actor (actor_id INT PRIMARY KEY, name TEXT, PRIMARY KEY (name));",
+		wantErr:     "table actor already has a primary key",
+	}, {
+		description: "index on table that does not exist",
+		dialect:     DialectPostgres,
+		sql:         "CREATE INDEX actor_name_idx ON actor (name);",
+		wantErr:     "table actor does not exist",
+	}, {
+		description: "alter table that does not exist",
+		dialect:     DialectPostgres,
+		sql:         "ALTER TABLE actor ADD CONSTRAINT actor_name_key UNIQUE (name);",
+		wantErr:     "table actor does not exist",
+	}, {
+		description: "foreign key table does not exist",
+		dialect:     DialectSQLite,
+		sql:         "CREATE TABLE film_actor (actor_id INT REFERENCES actor (actor_id));",
+		wantErr:     "film_actor_actor_id_fkey: table actor does not exist",
+	}, {
+		description: "foreign key column does not exist",
+		dialect:     DialectSQLite,
+		sql:         "CREATE TABLE actor (actor_id INT PRIMARY KEY);\nCREATE TABLE film_actor (actor_id INT REFERENCES actor (id));",
+		wantErr:     "film_actor_actor_id_fkey: column actor.id does not exist",
+	}, {
+		description: "statements that are not understood are skipped",
+		dialect:     DialectPostgres,
+		sql: "CREATE TABLE actor (actor_id INT);\n" +
+			"INSERT INTO actor (actor_id) VALUES (1);\n" +
+			"CREATE FUNCTION f() RETURNS TRIGGER AS $$ BEGIN RETURN NEW; END $$ LANGUAGE plpgsql;\n" +
+			"CREATE TRIGGER t BEFORE UPDATE ON actor FOR EACH ROW EXECUTE PROCEDURE f();",
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			p := NewSQLParser(nil)
+			p.addFile("", []byte(tt.sql))
+			err := p.WriteCatalog(&Catalog{Dialect: tt.dialect})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Error(testutil.Callers(), err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf(testutil.Callers()+" expected error %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf(testutil.Callers()+" expected error %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func Test_writeCatalogWithoutDevDB(t *testing.T) {
+	fsys := fstest.MapFS{
+		"schema.sql": {Data: []byte("CREATE TABLE actor (actor_id INT PRIMARY KEY, name TEXT);\n" +
+			"CREATE VIEW actor_names AS SELECT name FROM actor;\n" +
+			"CREATE INDEX actor_name_idx ON actor (name);"),
+		},
+	}
+	catalog := &Catalog{Dialect: DialectPostgres, CurrentSchema: "public"}
+	err := writeCatalog(catalog, fsys, "sqddl_history", "", "schema.sql")
+	if err != nil {
+		t.Fatal(testutil.Callers(), err)
+	}
+	cache := NewCatalogCache(catalog)
+	schema := cache.GetSchema(catalog, "public")
+	table := cache.GetTable(schema, "actor")
+	if table == nil {
+		t.Fatal(testutil.Callers(), "table actor not found")
+	}
+	if cache.GetIndex(table, "actor_name_idx") == nil {
+		t.Error(testutil.Callers(), "index actor_name_idx not found")
+	}
+	if cache.GetView(schema, "actor_names") != nil {
+		t.Error(testutil.Callers(), "views should not be written to the catalog")
+	}
+}
@@ -2,13 +2,20 @@ package ddl
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 )
 
 type sqliteMigration struct {
+	warnings     []string
+	renames      []renameOperation
 	dropTables   []*Table
 	createTables []*Table
 	alterTables  []sqliteAlterTable
+
+	// splitBy is either "table" or "step" if the migration should be split
+	// into one file per table or per step instead of a single file.
+	splitBy string
 }
 
 type sqliteAlterTable struct {
@@ -23,6 +30,10 @@ type sqliteAlterTable struct {
 	createIndexes   []*Index
 	columnIsDropped map[string]bool
 	columnIsAdded   map[string]bool
+
+	// alterOptions indicates if the STRICT or WITHOUT ROWID table options
+	// are being changed, which can only be done by copying the table.
+	alterOptions bool
 }
 
 func newSQLiteMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) sqliteMigration {
@@ -53,6 +64,7 @@ func newSQLiteMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) sqli
 		}
 		return m
 	}
+	srcCatalog, m.renames, m.warnings = resolveRenames(dialect, srcCatalog, destCatalog, dropObjects)
 
 	// Because SQLite doesn't support constraint names, we have to generate it
 	// ourselves (we need constraint names because that's how we identify the
@@ -203,6 +215,10 @@ func newSQLiteMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) sqli
 			if srcIndex == nil {
 				// CREATE INDEX.
 				alterTable.createIndexes = append(alterTable.createIndexes, destIndex)
+			} else if indexesAreDifferent(dialect, srcIndex, destIndex) {
+				// DROP INDEX + CREATE INDEX.
+				alterTable.dropIndexes = append(alterTable.dropIndexes, srcIndex)
+				alterTable.createIndexes = append(alterTable.createIndexes, destIndex)
 			}
 		}
 		for j := range destTable.Constraints {
@@ -216,7 +232,12 @@ func newSQLiteMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) sqli
 				alterTable.addConstraints = append(alterTable.addConstraints, destConstraint)
 			}
 		}
-		if len(alterTable.dropConstraints) > 0 ||
+		if srcTable.IsStrict != destTable.IsStrict || srcTable.IsWithoutRowid != destTable.IsWithoutRowid {
+			// STRICT | WITHOUT ROWID.
+			alterTable.alterOptions = true
+		}
+		if alterTable.alterOptions ||
+			len(alterTable.dropConstraints) > 0 ||
 			len(alterTable.dropIndexes) > 0 ||
 			len(alterTable.addColumns) > 0 ||
 			len(alterTable.alterColumns) > 0 ||
@@ -230,8 +251,16 @@ func newSQLiteMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) sqli
 				// creating any indexes -- the other operations all involve
 				// dropping objects. Zero them out so that only adding columns
 				// and creating indexes are left behind.
-				if len(alterTable.addColumns) > 0 || len(alterTable.createIndexes) > 0 {
-					alterTable.dropIndexes = alterTable.dropIndexes[:0]
+				if alterTable.alterOptions || len(alterTable.addColumns) > 0 || len(alterTable.createIndexes) > 0 {
+					// Keep dropping the indexes that are being recreated.
+					n := 0
+					for _, index := range alterTable.dropIndexes {
+						if destCache.GetIndex(destTable, index.IndexName) != nil {
+							alterTable.dropIndexes[n] = index
+							n++
+						}
+					}
+					alterTable.dropIndexes = alterTable.dropIndexes[:n]
 					alterTable.dropConstraints = alterTable.dropConstraints[:0]
 					alterTable.dropColumns = alterTable.dropColumns[:0]
 					alterTable.alterColumns = alterTable.alterColumns[:0]
@@ -245,22 +274,189 @@ func newSQLiteMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) sqli
 }
 
 func (m *sqliteMigration) sql(prefix string) (filenames []string, bufs []*bytes.Buffer, warnings []string) {
-	const (
-		dialect          = DialectSQLite
-		currentSchema    = ""
-		defaultCollation = ""
-	)
+	if m.splitBy != "" {
+		return m.splitSQL(prefix)
+	}
+	const dialect = DialectSQLite
 	// ${prefix}.sql
 	filenames = append(filenames, prefix+".sql")
 	buf := bufpool.Get().(*bytes.Buffer)
 	buf.Reset()
 	bufs = append(bufs, buf)
+	warnings = m.warnings
+
+	// RENAME.
+	m.writeRenames(buf)
+
+	copyTable, hasCopyTable := m.copyTables()
+	if hasCopyTable {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("PRAGMA legacy_alter_table = ON;\n")
+	}
+
+	// DROP TABLE.
+	for _, table := range m.dropTables {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		tableName := QuoteIdentifier(dialect, table.TableName)
+		buf.WriteString("DROP TABLE " + tableName + ";\n")
+	}
+
+	// CREATE TABLE.
+	for _, table := range m.createTables {
+		writeSQLiteTable(buf, table, false)
+	}
+
+	// ALTER TABLE | COPY TABLE.
+	for i := range m.alterTables {
+		m.alterTables[i].writeAlterTable(buf, copyTable[i])
+	}
+
+	if hasCopyTable {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("PRAGMA legacy_alter_table = OFF;\n")
+	}
+
+	if bufs[0].Len() == 0 {
+		filenames = filenames[1:]
+		return filenames, bufs, warnings
+	}
 
-	// Figure out which tables have to be copied.
-	copyTable := make([]bool, len(m.alterTables))
-	hasCopyTable := false
+	// ${prefix}.undo.sql
+	undobuf := bufpool.Get().(*bytes.Buffer)
+	undobuf.Reset()
+	if hasCopyTable {
+		undobuf.WriteString("PRAGMA legacy_alter_table = ON;\n")
+	}
+	for i := len(m.alterTables) - 1; i >= 0; i-- {
+		m.alterTables[i].writeUndoAlterTable(undobuf, copyTable[i])
+	}
+	for i := len(m.createTables) - 1; i >= 0; i-- {
+		if undobuf.Len() > 0 {
+			undobuf.WriteString("\n")
+		}
+		undobuf.WriteString("DROP TABLE IF EXISTS " + QuoteIdentifier(dialect, m.createTables[i].TableName) + ";\n")
+	}
+	for _, table := range m.dropTables {
+		writeSQLiteTable(undobuf, table, true)
+	}
+	if hasCopyTable {
+		if undobuf.Len() > 0 {
+			undobuf.WriteString("\n")
+		}
+		undobuf.WriteString("PRAGMA legacy_alter_table = OFF;\n")
+	}
+	m.writeUndoRenames(undobuf)
+	filenames, bufs = appendUndo(filenames, bufs, undobuf)
+	return filenames, bufs, warnings
+}
+
+// splitSQL is like sql, except the migration is split into one file per
+// table or one file per step (depending on splitBy) instead of a single file.
+// The files are numbered so that they run in the same order as the
+// statements in the single file would.
+func (m *sqliteMigration) splitSQL(prefix string) (filenames []string, bufs []*bytes.Buffer, warnings []string) {
+	const dialect = DialectSQLite
+	warnings = m.warnings
+	n := 0
+	addFile := func(name string, write func(buf, undobuf *bytes.Buffer)) {
+		n++
+		// ${prefix}_${n}_${name}.sql
+		filenames = append(filenames, prefix+"_"+fmt.Sprintf("%02d", n)+"_"+name+".sql")
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		// ${prefix}_${n}_${name}.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		write(buf, undobuf)
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+	}
+
+	// RENAME.
+	if len(m.renames) > 0 {
+		addFile("renames", func(buf, undobuf *bytes.Buffer) {
+			m.writeRenames(buf)
+			m.writeUndoRenames(undobuf)
+		})
+	}
+
+	// DROP TABLE + CREATE TABLE.
+	for _, group := range groupTables(m.splitBy, "", m.dropTables, m.createTables) {
+		group := group
+		addFile(group.name, func(buf, undobuf *bytes.Buffer) {
+			for _, table := range group.dropTables {
+				if buf.Len() > 0 {
+					buf.WriteString("\n")
+				}
+				buf.WriteString("DROP TABLE " + QuoteIdentifier(dialect, table.TableName) + ";\n")
+			}
+			for _, table := range group.createTables {
+				writeSQLiteTable(buf, table, false)
+			}
+			for i := len(group.createTables) - 1; i >= 0; i-- {
+				if undobuf.Len() > 0 {
+					undobuf.WriteString("\n")
+				}
+				undobuf.WriteString("DROP TABLE IF EXISTS " + QuoteIdentifier(dialect, group.createTables[i].TableName) + ";\n")
+			}
+			for _, table := range group.dropTables {
+				writeSQLiteTable(undobuf, table, true)
+			}
+		})
+	}
+
+	// ALTER TABLE | COPY TABLE.
+	copyTable, _ := m.copyTables()
+	writeAlterTables := func(indexes []int) func(buf, undobuf *bytes.Buffer) {
+		return func(buf, undobuf *bytes.Buffer) {
+			hasCopyTable := false
+			for _, i := range indexes {
+				hasCopyTable = hasCopyTable || copyTable[i]
+			}
+			if hasCopyTable {
+				buf.WriteString("PRAGMA legacy_alter_table = ON;\n")
+				undobuf.WriteString("PRAGMA legacy_alter_table = ON;\n")
+			}
+			for _, i := range indexes {
+				m.alterTables[i].writeAlterTable(buf, copyTable[i])
+			}
+			for j := len(indexes) - 1; j >= 0; j-- {
+				i := indexes[j]
+				m.alterTables[i].writeUndoAlterTable(undobuf, copyTable[i])
+			}
+			if hasCopyTable {
+				buf.WriteString("\nPRAGMA legacy_alter_table = OFF;\n")
+				undobuf.WriteString("\nPRAGMA legacy_alter_table = OFF;\n")
+			}
+		}
+	}
+	if m.splitBy == "table" {
+		for i, alterTable := range m.alterTables {
+			addFile("alter_"+tableFilename("", "", alterTable.destTable.TableName), writeAlterTables([]int{i}))
+		}
+	} else if len(m.alterTables) > 0 {
+		indexes := make([]int, len(m.alterTables))
+		for i := range indexes {
+			indexes[i] = i
+		}
+		addFile("alter_tables", writeAlterTables(indexes))
+	}
+	return filenames, bufs, warnings
+}
+
+// copyTables reports which of the alterTables have to be copied into a new
+// table instead of being altered in place, because SQLite's ALTER TABLE does
+// not support the change.
+func (m *sqliteMigration) copyTables() (copyTable []bool, hasCopyTable bool) {
+	copyTable = make([]bool, len(m.alterTables))
 	for i, alterTable := range m.alterTables {
-		if len(alterTable.alterColumns) > 0 {
+		if alterTable.alterOptions || len(alterTable.alterColumns) > 0 {
 			copyTable[i], hasCopyTable = true, true
 			continue
 		}
@@ -285,93 +481,165 @@ func (m *sqliteMigration) sql(prefix string) (filenames []string, bufs []*bytes.
 			}
 		}
 	}
+	return copyTable, hasCopyTable
+}
+
+func (m *sqliteMigration) writeRenames(buf *bytes.Buffer) {
+	for _, rename := range m.renames {
+		writeSQLiteRename(buf, rename.objectType, rename.tableName, rename.oldName, rename.newName)
+	}
+}
 
-	if hasCopyTable {
+func (m *sqliteMigration) writeUndoRenames(buf *bytes.Buffer) {
+	for i := len(m.renames) - 1; i >= 0; i-- {
+		rename := m.renames[i]
+		tableName := rename.tableName
+		if rename.objectType == "TABLE" {
+			tableName = rename.newName
+		}
+		writeSQLiteRename(buf, rename.objectType, tableName, rename.newName, rename.oldName)
+	}
+}
+
+// writeAlterTable writes the statements that alter the table, either in place
+// or by copying it into a new table.
+func (alterTable *sqliteAlterTable) writeAlterTable(buf *bytes.Buffer, copyTable bool) {
+	const (
+		dialect          = DialectSQLite
+		currentSchema    = ""
+		defaultCollation = ""
+	)
+	if copyTable {
+		// COPY TABLE.
 		if buf.Len() > 0 {
 			buf.WriteString("\n")
 		}
-		buf.WriteString("PRAGMA legacy_alter_table = ON;\n")
+		alterTable.copyTable(buf)
+		return
 	}
-
-	// DROP TABLE.
-	for _, table := range m.dropTables {
+	tableName := QuoteIdentifier(dialect, alterTable.destTable.TableName)
+	// DROP INDEX.
+	for _, index := range alterTable.dropIndexes {
 		if buf.Len() > 0 {
 			buf.WriteString("\n")
 		}
-		tableName := QuoteIdentifier(dialect, table.TableName)
-		buf.WriteString("DROP TABLE " + tableName + ";\n")
+		indexName := QuoteIdentifier(dialect, index.IndexName)
+		buf.WriteString("DROP INDEX " + indexName + ";\n")
 	}
-
-	// CREATE TABLE.
-	for _, table := range m.createTables {
+	// DROP COLUMN.
+	for _, column := range alterTable.dropColumns {
 		if buf.Len() > 0 {
 			buf.WriteString("\n")
 		}
-		writeCreateTable(dialect, buf, currentSchema, defaultCollation, table, true)
-		for _, index := range table.Indexes {
-			if buf.Len() > 0 {
-				buf.WriteString("\n")
-			}
-			writeCreateIndex(dialect, buf, currentSchema, &index, false)
+		columnName := QuoteIdentifier(dialect, column.ColumnName)
+		buf.WriteString("ALTER TABLE " + tableName + " DROP COLUMN " + columnName + ";\n")
+	}
+	// ADD COLUMN.
+	for _, column := range alterTable.addColumns {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
 		}
+		buf.WriteString("ALTER TABLE " + tableName + " ADD COLUMN ")
+		writeColumnDefinition(dialect, buf, defaultCollation, column, true)
+		buf.WriteString(";\n")
 	}
+	// CREATE INDEX.
+	for _, index := range alterTable.createIndexes {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		writeCreateIndex(dialect, buf, currentSchema, index, false, false)
+	}
+}
 
-	// ALTER TABLE | COPY TABLE.
-	for i, alterTable := range m.alterTables {
-		if copyTable[i] {
-			// COPY TABLE.
-			if buf.Len() > 0 {
-				buf.WriteString("\n")
-			}
-			alterTable.copyTable(buf)
-			continue
+// writeUndoAlterTable writes the statements that undo writeAlterTable.
+func (alterTable *sqliteAlterTable) writeUndoAlterTable(undobuf *bytes.Buffer, copyTable bool) {
+	const (
+		dialect          = DialectSQLite
+		currentSchema    = ""
+		defaultCollation = ""
+	)
+	if copyTable {
+		// COPY TABLE (from destTable back to srcTable).
+		if undobuf.Len() > 0 {
+			undobuf.WriteString("\n")
 		}
-		tableName := QuoteIdentifier(dialect, alterTable.destTable.TableName)
-		// DROP INDEX.
-		for _, index := range alterTable.dropIndexes {
-			if buf.Len() > 0 {
-				buf.WriteString("\n")
-			}
-			indexName := QuoteIdentifier(dialect, index.IndexName)
-			buf.WriteString("DROP INDEX " + indexName + ";\n")
+		undoTable := sqliteAlterTable{
+			srcTable:  alterTable.destTable,
+			destTable: alterTable.srcTable,
 		}
-		// DROP COLUMN.
-		for _, column := range alterTable.dropColumns {
-			if buf.Len() > 0 {
-				buf.WriteString("\n")
-			}
-			columnName := QuoteIdentifier(dialect, column.ColumnName)
-			buf.WriteString("ALTER TABLE " + tableName + " DROP COLUMN " + columnName + ";\n")
+		undoTable.copyTable(undobuf)
+		return
+	}
+	tableName := QuoteIdentifier(dialect, alterTable.destTable.TableName)
+	// DROP INDEX.
+	for j := len(alterTable.createIndexes) - 1; j >= 0; j-- {
+		if undobuf.Len() > 0 {
+			undobuf.WriteString("\n")
 		}
-		// ADD COLUMN.
-		for _, column := range alterTable.addColumns {
-			if buf.Len() > 0 {
-				buf.WriteString("\n")
-			}
-			buf.WriteString("ALTER TABLE " + tableName + " ADD COLUMN ")
-			writeColumnDefinition(dialect, buf, defaultCollation, column, true)
-			buf.WriteString(";\n")
+		indexName := QuoteIdentifier(dialect, alterTable.createIndexes[j].IndexName)
+		undobuf.WriteString("DROP INDEX IF EXISTS " + indexName + ";\n")
+	}
+	// DROP COLUMN.
+	for j := len(alterTable.addColumns) - 1; j >= 0; j-- {
+		if undobuf.Len() > 0 {
+			undobuf.WriteString("\n")
 		}
-		// CREATE INDEX.
-		for _, index := range alterTable.createIndexes {
-			if buf.Len() > 0 {
-				buf.WriteString("\n")
-			}
-			writeCreateIndex(dialect, buf, currentSchema, index, false)
+		columnName := QuoteIdentifier(dialect, alterTable.addColumns[j].ColumnName)
+		undobuf.WriteString("ALTER TABLE " + tableName + " DROP COLUMN " + columnName + ";\n")
+	}
+	// ADD COLUMN.
+	for _, column := range alterTable.dropColumns {
+		if undobuf.Len() > 0 {
+			undobuf.WriteString("\n")
+		}
+		undobuf.WriteString("ALTER TABLE " + tableName + " ADD COLUMN ")
+		writeColumnDefinition(dialect, undobuf, defaultCollation, column, true)
+		undobuf.WriteString(";\n")
+	}
+	// CREATE INDEX.
+	for _, index := range alterTable.dropIndexes {
+		if undobuf.Len() > 0 {
+			undobuf.WriteString("\n")
 		}
+		writeCreateIndex(dialect, undobuf, currentSchema, index, false, true)
 	}
+}
 
-	if hasCopyTable {
+// writeSQLiteTable writes the CREATE TABLE statement for a table, followed by
+// its CREATE INDEX statements. If ifNotExists is true the statements are
+// guarded with IF NOT EXISTS (used by undo migrations).
+func writeSQLiteTable(buf *bytes.Buffer, table *Table, ifNotExists bool) {
+	const dialect = DialectSQLite
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	writeCreateTable(dialect, buf, "", "", table, true, ifNotExists)
+	for _, index := range table.Indexes {
 		if buf.Len() > 0 {
 			buf.WriteString("\n")
 		}
-		buf.WriteString("PRAGMA legacy_alter_table = OFF;\n")
+		writeCreateIndex(dialect, buf, "", &index, false, ifNotExists)
 	}
+}
 
-	if bufs[0].Len() == 0 {
-		filenames = filenames[1:]
+// writeSQLiteRename writes the statement that renames a table or column from
+// oldName to newName. SQLite indexes and constraints cannot be renamed.
+func writeSQLiteRename(buf *bytes.Buffer, objectType, tableName, oldName, newName string) {
+	const dialect = DialectSQLite
+	tableName = QuoteIdentifier(dialect, tableName)
+	switch objectType {
+	case "TABLE":
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("ALTER TABLE " + tableName + " RENAME TO " + QuoteIdentifier(dialect, newName) + ";\n")
+	case "COLUMN":
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("ALTER TABLE " + tableName + " RENAME COLUMN " + QuoteIdentifier(dialect, oldName) + " TO " + QuoteIdentifier(dialect, newName) + ";\n")
 	}
-	return filenames, bufs, warnings
 }
 
 func (alterTable *sqliteAlterTable) copyTable(buf *bytes.Buffer) {
@@ -387,7 +655,7 @@ func (alterTable *sqliteAlterTable) copyTable(buf *bytes.Buffer) {
 	tableNew := *alterTable.destTable
 	tableNew.TableName = alterTable.destTable.TableName + "_new"
 	tableNew.SQL = strings.Replace(alterTable.destTable.SQL, "CREATE TABLE "+tableName, "CREATE TABLE "+tableNameNew, 1)
-	writeCreateTable(dialect, buf, currentSchema, defaultCollation, &tableNew, true)
+	writeCreateTable(dialect, buf, currentSchema, defaultCollation, &tableNew, true, false)
 
 	// INSERT INTO "${TableName}_new" SELECT ... FROM "$TableName".
 	isDestColumn := make(map[string]bool)
@@ -432,7 +700,7 @@ func (alterTable *sqliteAlterTable) copyTable(buf *bytes.Buffer) {
 		if buf.Len() > 0 {
 			buf.WriteString("\n")
 		}
-		writeCreateIndex(dialect, buf, "", destIndex, false)
+		writeCreateIndex(dialect, buf, "", destIndex, false, false)
 	}
 
 	// CREATE TRIGGER.
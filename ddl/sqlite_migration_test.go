@@ -22,6 +22,10 @@ func Test_sqliteMigration(t *testing.T) {
 		{"testdata/sqlite_create_schema", true},
 		{"testdata/sqlite_misc", true},
 		{"testdata/sqlite_ignore", true},
+		{"testdata/sqlite_rename", true},
+		{"testdata/sqlite_check", true},
+		{"testdata/sqlite_index", false},
+		{"testdata/sqlite_options", true},
 	}
 	newCatalog := func(t *testing.T, filename string) *Catalog {
 		file, err := os.Open(filename)
@@ -8,10 +8,24 @@ import (
 
 type sqlserverMigration struct {
 	versionNums      VersionNums
+	edition          string
 	currentSchema    string
 	defaultCollation string
 	warnings         []string
 
+	// splitBy splits the DROP TABLE and CREATE TABLE statements into one file
+	// per table ("table") or one file per step ("step").
+	splitBy string
+
+	// onlineIndexes creates (and drops) the indexes and PRIMARY KEY/UNIQUE
+	// constraints of existing tables WITH (ONLINE = ON), plus RESUMABLE = ON
+	// if the version supports it. Indexes can also opt in individually with
+	// Index.Online and Index.Resumable.
+	onlineIndexes bool
+
+	// 0. Rename the tables, columns, indexes and constraints.
+	renames []renameOperation
+
 	// 1. Drop the foreign keys.
 	dropFkeys [][]*Constraint
 
@@ -19,9 +33,13 @@ type sqlserverMigration struct {
 	dropSchemas []*Schema
 
 	// 3. Execute all CREATE SCHEMA + DROP TABLE + CREATE TABLE in one transaction.
-	createSchemas []string
-	dropTables    []*Table
-	createTables  []*Table
+	// The sequences are created (or altered) after the schemas but before the
+	// tables, since column defaults may refer to them.
+	createSchemas   []string
+	createSequences []*Sequence
+	alterSequences  [][2]*Sequence
+	dropTables      []*Table
+	createTables    []*Table
 
 	// 4. Execute each ALTER TABLE.
 	alterTables []sqlserverAlterTable
@@ -32,6 +50,9 @@ type sqlserverMigration struct {
 
 	// 6. Add foreign keys for existing tables.
 	addFkeys [][]*Constraint
+
+	// 7. Drop the sequences once nothing depends on them.
+	dropSequences []*Sequence
 }
 
 type sqlserverAlterTable struct {
@@ -50,17 +71,27 @@ type sqlserverAlterTable struct {
 	// Create indexes individually outside a transaction.
 	createIndexes []*Index
 
-	// Add PRIMARY KEY and UNIQUE constraints individually outside a transaction.
+	// Add PRIMARY KEY, UNIQUE and CHECK constraints individually outside a
+	// transaction. CHECK constraints are added WITH NOCHECK and then
+	// validated in a separate step.
 	addConstraints []*Constraint
+
+	// Add, update or drop the MS_Description extended property of the table
+	// and its columns. Each pair is made up of the src and dest objects (the
+	// src object is nil if the column is being added).
+	commentTable   [2]*Table
+	commentColumns [][2]*Column
 }
 
 func newSQLServerMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) sqlserverMigration {
 	const dialect = DialectSQLServer
 	m := sqlserverMigration{
 		versionNums:      srcCatalog.VersionNums,
+		edition:          srcCatalog.Edition,
 		currentSchema:    srcCatalog.CurrentSchema,
 		defaultCollation: srcCatalog.DefaultCollation,
 	}
+	srcCatalog, m.renames, m.warnings = resolveRenames(dialect, srcCatalog, destCatalog, dropObjects)
 	srcCache, destCache := NewCatalogCache(srcCatalog), NewCatalogCache(destCatalog)
 	dropFkeysPos := make(map[[4]string]int)    // Track tablesID position in m.dropFkeys.
 	addFastFkeysPos := make(map[[4]string]int) // Track tablesID position in m.dropFastFkeys.
@@ -84,6 +115,48 @@ func newSQLServerMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) s
 		return tablesID
 	}
 
+	for i := range destCatalog.Schemas {
+		destSchema := &destCatalog.Schemas[i]
+		if destSchema.Ignore {
+			continue
+		}
+		srcSchema := srcCache.GetSchema(srcCatalog, destSchema.SchemaName)
+		for j := range destSchema.Sequences {
+			destSequence := &destSchema.Sequences[j]
+			if destSequence.Ignore {
+				continue
+			}
+			srcSequence := srcCache.GetSequence(srcSchema, destSequence.SequenceName)
+			if srcSequence == nil {
+				// CREATE SEQUENCE.
+				m.createSequences = append(m.createSequences, destSequence)
+				continue
+			}
+			// ALTER SEQUENCE.
+			if forward, _, isDifferent := diffSequences(srcSequence, destSequence); isDifferent {
+				if forward.dataType != "" || forward.startValue != 0 {
+					m.warnings = append(m.warnings, fmt.Sprintf("%s: changing the data type or start value of an existing sequence is not supported, you will have to recreate the sequence manually", m.sequenceName(destSequence)))
+					forward.dataType, forward.startValue = "", 0
+				}
+				if forward != (sequenceOptions{}) {
+					m.alterSequences = append(m.alterSequences, [2]*Sequence{srcSequence, destSequence})
+				}
+			}
+		}
+		if dropObjects && srcSchema != nil {
+			for j := range srcSchema.Sequences {
+				srcSequence := &srcSchema.Sequences[j]
+				if srcSequence.Ignore {
+					continue
+				}
+				if destCache.GetSequence(destSchema, srcSequence.SequenceName) == nil {
+					// DROP SEQUENCE.
+					m.dropSequences = append(m.dropSequences, srcSequence)
+				}
+			}
+		}
+	}
+
 	if dropObjects {
 		for i := range srcCatalog.Schemas {
 			srcSchema := &srcCatalog.Schemas[i]
@@ -217,6 +290,10 @@ func newSQLServerMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) s
 			}
 			droppedIndex := make(map[*Index]bool)
 			droppedConstraint := make(map[*Constraint]bool)
+			if srcTable.Comment != destTable.Comment {
+				// COMMENT.
+				alterTable.commentTable = [2]*Table{srcTable, destTable}
+			}
 
 			if dropObjects {
 				for k := range srcTable.Constraints {
@@ -227,8 +304,8 @@ func newSQLServerMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) s
 					destConstraint := destCache.GetConstraint(destTable, srcConstraint.ConstraintName)
 					if destConstraint == nil {
 						switch srcConstraint.ConstraintType {
-						case PRIMARY_KEY, UNIQUE:
-							// DROP PRIMARY KEY, DROP UNIQUE.
+						case PRIMARY_KEY, UNIQUE, CHECK:
+							// DROP PRIMARY KEY, DROP UNIQUE, DROP CHECK.
 							alterTable.dropConstraints = append(alterTable.dropConstraints, srcConstraint)
 						case FOREIGN_KEY:
 							// DROP FOREIGN KEY.
@@ -279,8 +356,16 @@ func newSQLServerMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) s
 				if srcColumn == nil {
 					// ADD COLUMN.
 					alterTable.addColumns = append(alterTable.addColumns, destColumn)
+					if destColumn.Comment != "" {
+						// COMMENT.
+						alterTable.commentColumns = append(alterTable.commentColumns, [2]*Column{nil, destColumn})
+					}
 					continue
 				}
+				if srcColumn.Comment != destColumn.Comment {
+					// COMMENT.
+					alterTable.commentColumns = append(alterTable.commentColumns, [2]*Column{srcColumn, destColumn})
+				}
 				if srcColumn.ColumnIdentity == "" && destColumn.ColumnIdentity != "" {
 					tableName := QuoteIdentifier(dialect, destTable.TableName)
 					if destSchema.SchemaName != "" && destSchema.SchemaName != m.currentSchema {
@@ -288,6 +373,17 @@ func newSQLServerMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) s
 					}
 					columnName := QuoteIdentifier(dialect, destColumn.ColumnName)
 					m.warnings = append(m.warnings, fmt.Sprintf("%s: column %s: identity cannot be added to an existing column, skipping", tableName, columnName))
+				} else if srcColumn.ColumnIdentity != "" && destColumn.ColumnIdentity != "" {
+					srcStart, srcIncrement := identityOptions(srcColumn)
+					destStart, destIncrement := identityOptions(destColumn)
+					if srcStart != destStart || srcIncrement != destIncrement {
+						tableName := QuoteIdentifier(dialect, destTable.TableName)
+						if destSchema.SchemaName != "" && destSchema.SchemaName != m.currentSchema {
+							tableName = QuoteIdentifier(dialect, destSchema.SchemaName) + "." + tableName
+						}
+						columnName := QuoteIdentifier(dialect, destColumn.ColumnName)
+						m.warnings = append(m.warnings, fmt.Sprintf("%s: column %s: changing the identity seed or increment of an existing column requires rebuilding the table, skipping (use DBCC CHECKIDENT to reseed the current identity value instead)", tableName, columnName))
+					}
 				}
 				columnsAreDifferent := func() bool {
 					srcType, srcArg1, srcArg2 := normalizeColumnType(dialect, srcColumn.ColumnType)
@@ -331,6 +427,11 @@ func newSQLServerMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) s
 				if srcIndex == nil {
 					// CREATE INDEX.
 					alterTable.createIndexes = append(alterTable.createIndexes, destIndex)
+				} else if indexesAreDifferent(dialect, srcIndex, destIndex) {
+					// DROP INDEX + CREATE INDEX.
+					alterTable.dropIndexes = append(alterTable.dropIndexes, srcIndex)
+					alterTable.createIndexes = append(alterTable.createIndexes, destIndex)
+					droppedIndex[srcIndex] = true
 				}
 			}
 
@@ -345,8 +446,8 @@ func newSQLServerMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) s
 				}
 				if srcConstraint == nil {
 					switch destConstraint.ConstraintType {
-					case PRIMARY_KEY, UNIQUE:
-						// ADD PRIMARY KEY | ADD UNIQUE.
+					case PRIMARY_KEY, UNIQUE, CHECK:
+						// ADD PRIMARY KEY | ADD UNIQUE | ADD CHECK.
 						alterTable.addConstraints = append(alterTable.addConstraints, destConstraint)
 					case FOREIGN_KEY:
 						// ADD FOREIGN KEY.
@@ -410,7 +511,7 @@ func newSQLServerMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) s
 						continue
 					}
 					switch constraint.ConstraintType {
-					case PRIMARY_KEY, UNIQUE:
+					case PRIMARY_KEY, UNIQUE, CHECK:
 						alterTable.dropConstraints = append(alterTable.dropConstraints, constraint)
 						alterTable.addConstraints = append(alterTable.addConstraints, constraint)
 					case FOREIGN_KEY:
@@ -436,7 +537,9 @@ func newSQLServerMigration(srcCatalog, destCatalog *Catalog, dropObjects bool) s
 				len(alterTable.addColumns) > 0 ||
 				len(alterTable.alterColumns) > 0 ||
 				len(alterTable.createIndexes) > 0 ||
-				len(alterTable.addConstraints) > 0 {
+				len(alterTable.addConstraints) > 0 ||
+				alterTable.commentTable[1] != nil ||
+				len(alterTable.commentColumns) > 0 {
 				m.alterTables = append(m.alterTables, alterTable)
 			}
 		}
@@ -462,6 +565,19 @@ func (m *sqlserverMigration) sql(prefix string) (filenames []string, bufs []*byt
 		return b.String()
 	}
 
+	// RENAME.
+	if len(m.renames) > 0 {
+		n++
+		// ${prefix}_${n}_renames.tx.sql
+		filenames = append(filenames, fmt.Sprintf("%s_%02d_renames.tx.sql", prefix, n))
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		for _, rename := range m.renames {
+			m.writeRename(buf, rename.objectType, rename.tableSchema, rename.tableName, rename.oldName, rename.newName)
+		}
+	}
+
 	// DROP FOREIGN KEY.
 	for _, fkeys := range m.dropFkeys {
 		n++
@@ -510,6 +626,13 @@ func (m *sqlserverMigration) sql(prefix string) (filenames []string, bufs []*byt
 			}
 			buf.WriteString("DROP TABLE " + schemaPrefix + QuoteIdentifier(dialect, table.TableName) + ";\n")
 		}
+		// DROP SEQUENCE.
+		for _, sequence := range schema.Sequences {
+			if buf.Len() > 0 {
+				buf.WriteString("\n")
+			}
+			buf.WriteString("DROP SEQUENCE " + schemaPrefix + QuoteIdentifier(dialect, sequence.SequenceName) + ";\n")
+		}
 		// DROP PROCEDURE and DROP FUNCTION.
 		for _, routine := range schema.Routines {
 			if buf.Len() > 0 {
@@ -526,6 +649,11 @@ func (m *sqlserverMigration) sql(prefix string) (filenames []string, bufs []*byt
 			buf.WriteString("\n")
 		}
 		buf.WriteString("DROP SCHEMA " + QuoteIdentifier(dialect, schema.SchemaName) + ";\n")
+		// ${prefix}_${n}_drop_${schema}.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		undobuf.WriteString("IF SCHEMA_ID('" + EscapeQuote(schema.SchemaName, '\'') + "') IS NULL EXEC('CREATE SCHEMA " + EscapeQuote(QuoteIdentifier(dialect, schema.SchemaName), '\'') + "');\n")
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
 	}
 
 	// CREATE SCHEMA.
@@ -542,17 +670,59 @@ func (m *sqlserverMigration) sql(prefix string) (filenames []string, bufs []*byt
 			}
 			buf.WriteString("IF SCHEMA_ID('" + EscapeQuote(schemaName, '\'') + "') IS NULL EXEC('CREATE SCHEMA " + EscapeQuote(QuoteIdentifier(dialect, schemaName), '\'') + "');\n")
 		}
+		// ${prefix}_${n}_create_schemas.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		for i := len(m.createSchemas) - 1; i >= 0; i-- {
+			if undobuf.Len() > 0 {
+				undobuf.WriteString("\n")
+			}
+			undobuf.WriteString("IF SCHEMA_ID('" + EscapeQuote(m.createSchemas[i], '\'') + "') IS NOT NULL DROP SCHEMA " + QuoteIdentifier(dialect, m.createSchemas[i]) + ";\n")
+		}
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
+	}
+
+	// CREATE SEQUENCE + ALTER SEQUENCE.
+	if len(m.createSequences) > 0 || len(m.alterSequences) > 0 {
+		n++
+		// ${prefix}_${n}_sequences.sql
+		filenames = append(filenames, fmt.Sprintf("%s_%02d_sequences.sql", prefix, n))
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		for _, sequence := range m.createSequences {
+			writeCreateSequence(dialect, buf, m.currentSchema, sequence, false)
+		}
+		for _, sequences := range m.alterSequences {
+			forward, _, _ := diffSequences(sequences[0], sequences[1])
+			// SQL Server cannot ALTER SEQUENCE the data type or start value.
+			forward.dataType, forward.startValue = "", 0
+			writeAlterSequence(dialect, buf, m.currentSchema, sequences[1], forward)
+		}
+		// ${prefix}_${n}_sequences.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		for i := len(m.alterSequences) - 1; i >= 0; i-- {
+			_, backward, _ := diffSequences(m.alterSequences[i][0], m.alterSequences[i][1])
+			backward.dataType, backward.startValue = "", 0
+			writeAlterSequence(dialect, undobuf, m.currentSchema, m.alterSequences[i][0], backward)
+		}
+		for i := len(m.createSequences) - 1; i >= 0; i-- {
+			sequenceName := m.sequenceName(m.createSequences[i])
+			undobuf.WriteString("IF OBJECT_ID('" + EscapeQuote(sequenceName, '\'') + "', 'SO') IS NOT NULL DROP SEQUENCE " + sequenceName + ";\n")
+		}
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
 	}
 
 	// DROP TABLE + CREATE TABLE.
-	if len(m.dropTables) > 0 || len(m.createTables) > 0 {
+	for _, group := range groupTables(m.splitBy, m.currentSchema, m.dropTables, m.createTables) {
 		n++
-		// ${prefix}_${n}_tables.sql
-		filenames = append(filenames, fmt.Sprintf("%s_%02d_tables.sql", prefix, n))
+		// ${prefix}_${n}_${group}.sql
+		filenames = append(filenames, fmt.Sprintf("%s_%02d_%s.sql", prefix, n, group.name))
 		buf := bufpool.Get().(*bytes.Buffer)
 		buf.Reset()
 		bufs = append(bufs, buf)
-		for _, table := range m.dropTables {
+		for _, table := range group.dropTables {
 			if buf.Len() > 0 {
 				buf.WriteString("\n")
 			}
@@ -562,18 +732,27 @@ func (m *sqlserverMigration) sql(prefix string) (filenames []string, bufs []*byt
 			}
 			buf.WriteString("DROP TABLE " + tableName + ";\n")
 		}
-		for _, table := range m.createTables {
-			if buf.Len() > 0 {
-				buf.WriteString("\n")
+		for _, table := range group.createTables {
+			m.writeTable(buf, table, false)
+		}
+		// ${prefix}_${n}_${group}.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		for i := len(group.createTables) - 1; i >= 0; i-- {
+			table := group.createTables[i]
+			if undobuf.Len() > 0 {
+				undobuf.WriteString("\n")
 			}
-			writeCreateTable(dialect, buf, m.currentSchema, m.defaultCollation, table, true)
-			for _, index := range table.Indexes {
-				if buf.Len() > 0 {
-					buf.WriteString("\n")
-				}
-				writeCreateIndex(dialect, buf, m.currentSchema, &index, false)
+			tableName := QuoteIdentifier(dialect, table.TableName)
+			if table.TableSchema != "" && table.TableSchema != m.currentSchema {
+				tableName = QuoteIdentifier(dialect, table.TableSchema) + "." + tableName
 			}
+			undobuf.WriteString("IF OBJECT_ID('" + EscapeQuote(tableName, '\'') + "', 'U') IS NOT NULL DROP TABLE " + tableName + ";\n")
+		}
+		for _, table := range group.dropTables {
+			m.writeTable(undobuf, table, true)
 		}
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
 	}
 
 	// ALTER TABLE.
@@ -607,7 +786,15 @@ func (m *sqlserverMigration) sql(prefix string) (filenames []string, bufs []*byt
 			if index.TableSchema != "" && index.TableSchema != m.currentSchema {
 				tableName = QuoteIdentifier(dialect, index.TableSchema) + "." + tableName
 			}
-			buf.WriteString("DROP INDEX " + indexName + " ON " + tableName + ";\n")
+			buf.WriteString("DROP INDEX " + indexName + " ON " + tableName)
+			// Only clustered indexes can be dropped online, dropping a
+			// nonclustered index is a metadata-only operation anyway.
+			if strings.EqualFold(index.IndexType, "CLUSTERED") {
+				with, _, onlineWarnings := m.onlineOptions(tableName, "index", index.IndexName, m.onlineIndexes, false)
+				warnings = append(warnings, onlineWarnings...)
+				buf.WriteString(with)
+			}
+			buf.WriteString(";\n")
 		}
 
 		// DROP CONSTRAINT.
@@ -616,7 +803,13 @@ func (m *sqlserverMigration) sql(prefix string) (filenames []string, bufs []*byt
 				buf.WriteString("\n")
 			}
 			constraintName := QuoteIdentifier(dialect, constraint.ConstraintName)
-			buf.WriteString("ALTER TABLE " + tableName + " DROP CONSTRAINT " + constraintName + ";\n")
+			buf.WriteString("ALTER TABLE " + tableName + " DROP CONSTRAINT " + constraintName)
+			if constraint.IsClustered {
+				with, _, onlineWarnings := m.onlineOptions(tableName, "constraint", constraint.ConstraintName, m.onlineIndexes, false)
+				warnings = append(warnings, onlineWarnings...)
+				buf.WriteString(with)
+			}
+			buf.WriteString(";\n")
 		}
 
 		// DROP COLUMN.
@@ -689,26 +882,7 @@ func (m *sqlserverMigration) sql(prefix string) (filenames []string, bufs []*byt
 			// Do we need to remove DEFAULT? Or do we need to add/change it?
 			if srcDefault != destDefault {
 				if srcDefault != "" {
-					if buf.Len() > 0 {
-						buf.WriteString("\n")
-					}
-					// https://stackoverflow.com/a/49393082/3030828
-					buf.WriteString(`BEGIN
-    DECLARE @name NVARCHAR(255);
-    SELECT
-        @name = default_constraints.name
-    FROM
-        sys.default_constraints
-        JOIN sys.tables ON tables.object_id = default_constraints.parent_object_id
-        JOIN sys.columns ON columns.column_id = default_constraints.parent_column_id
-    WHERE
-        SCHEMA_NAME(tables.schema_id) = '` + EscapeQuote(alterTable.tableSchema, '\'') + `'
-        AND tables.name = '` + EscapeQuote(alterTable.tableName, '\'') + `'
-        AND columns.name = '` + EscapeQuote(destColumn.ColumnName, '\'') + `'
-    ;
-    EXEC('ALTER TABLE ` + EscapeQuote(tableName, '\'') + ` DROP CONSTRAINT ' + @name);
-END;
-`)
+					writeDropDefault(buf, tableName, alterTable.tableSchema, alterTable.tableName, destColumn.ColumnName)
 				}
 				if destDefault != "" {
 					// ADD
@@ -720,28 +894,89 @@ END;
 			}
 		}
 
+		// COMMENT.
+		if srcTable, destTable := alterTable.commentTable[0], alterTable.commentTable[1]; destTable != nil {
+			writeExtendedProperty(buf, alterTable.tableSchema, alterTable.tableName, "", srcTable.Comment, destTable.Comment)
+		}
+		for _, columns := range alterTable.commentColumns {
+			srcColumn, destColumn := columns[0], columns[1]
+			var srcComment string
+			if srcColumn != nil {
+				srcComment = srcColumn.Comment
+			}
+			writeExtendedProperty(buf, alterTable.tableSchema, alterTable.tableName, destColumn.ColumnName, srcComment, destColumn.Comment)
+		}
+
 		// CREATE INDEX.
 		for _, index := range alterTable.createIndexes {
 			n++
-			// ${prefix}_${n}_create_${index}.tx.sql
-			filenames = append(filenames, fmt.Sprintf("%s_%02d_create_%s.tx.sql", prefix, n, index.IndexName))
+			with, resumable, onlineWarnings := m.onlineOptions(tableName, "index", index.IndexName, m.onlineIndexes || index.Online, m.onlineIndexes || index.Resumable)
+			warnings = append(warnings, onlineWarnings...)
+			if resumable {
+				// ${prefix}_${n}_create_${index}.txoff.sql
+				filenames = append(filenames, fmt.Sprintf("%s_%02d_create_%s.txoff.sql", prefix, n, index.IndexName))
+			} else {
+				// ${prefix}_${n}_create_${index}.tx.sql
+				filenames = append(filenames, fmt.Sprintf("%s_%02d_create_%s.tx.sql", prefix, n, index.IndexName))
+			}
 			buf := bufpool.Get().(*bytes.Buffer)
 			buf.Reset()
 			bufs = append(bufs, buf)
-			writeCreateIndex(dialect, buf, m.currentSchema, index, true)
+			if with != "" && index.SQL == "" {
+				buf.WriteString("CREATE ")
+				writeIndexDefinition(dialect, buf, m.currentSchema, index, false, false, false)
+				buf.WriteString(with + ";\n")
+			} else {
+				writeCreateIndex(dialect, buf, m.currentSchema, index, true, false)
+			}
+			// ${prefix}_${n}_create_${index}.undo.sql
+			undobuf := bufpool.Get().(*bytes.Buffer)
+			undobuf.Reset()
+			undobuf.WriteString("IF INDEXPROPERTY(OBJECT_ID('" + EscapeQuote(tableName, '\'') + "'), '" + EscapeQuote(index.IndexName, '\'') + "', 'IndexID') IS NOT NULL DROP INDEX " + QuoteIdentifier(dialect, index.IndexName) + " ON " + tableName + ";\n")
+			filenames, bufs = appendUndo(filenames, bufs, undobuf)
 		}
 
 		// ADD CONSTRAINT.
 		for _, constraint := range alterTable.addConstraints {
 			n++
-			// ${prefix}_${n}_add_${constraint}.tx.sql
-			filenames = append(filenames, fmt.Sprintf("%s_%02d_add_%s.tx.sql", prefix, n, constraint.ConstraintName))
+			var with string
+			var resumable bool
+			if constraint.ConstraintType == PRIMARY_KEY || constraint.ConstraintType == UNIQUE {
+				var onlineWarnings []string
+				with, resumable, onlineWarnings = m.onlineOptions(tableName, "constraint", constraint.ConstraintName, m.onlineIndexes, m.onlineIndexes)
+				warnings = append(warnings, onlineWarnings...)
+			}
+			if resumable {
+				// ${prefix}_${n}_add_${constraint}.txoff.sql
+				filenames = append(filenames, fmt.Sprintf("%s_%02d_add_%s.txoff.sql", prefix, n, constraint.ConstraintName))
+			} else {
+				// ${prefix}_${n}_add_${constraint}.tx.sql
+				filenames = append(filenames, fmt.Sprintf("%s_%02d_add_%s.tx.sql", prefix, n, constraint.ConstraintName))
+			}
 			buf := bufpool.Get().(*bytes.Buffer)
 			buf.Reset()
 			bufs = append(bufs, buf)
-			buf.WriteString("ALTER TABLE " + tableName + " ADD ")
+			if constraint.ConstraintType == CHECK {
+				buf.WriteString("ALTER TABLE " + tableName + " WITH NOCHECK ADD ")
+			} else {
+				buf.WriteString("ALTER TABLE " + tableName + " ADD ")
+			}
 			writeConstraintDefinition(dialect, buf, m.currentSchema, constraint)
-			buf.WriteString(";\n")
+			buf.WriteString(with + ";\n")
+			// ${prefix}_${n}_add_${constraint}.undo.sql
+			undobuf := bufpool.Get().(*bytes.Buffer)
+			undobuf.Reset()
+			undobuf.WriteString("IF EXISTS (SELECT 1 FROM sys.objects WHERE name = '" + EscapeQuote(constraint.ConstraintName, '\'') + "' AND parent_object_id = OBJECT_ID('" + EscapeQuote(tableName, '\'') + "')) ALTER TABLE " + tableName + " DROP CONSTRAINT " + QuoteIdentifier(dialect, constraint.ConstraintName) + ";\n")
+			filenames, bufs = appendUndo(filenames, bufs, undobuf)
+			if constraint.ConstraintType == CHECK {
+				n++
+				// ${prefix}_${n}_validate_${constraint}.tx.sql
+				filenames = append(filenames, fmt.Sprintf("%s_%02d_validate_%s.tx.sql", prefix, n, constraint.ConstraintName))
+				buf := bufpool.Get().(*bytes.Buffer)
+				buf.Reset()
+				bufs = append(bufs, buf)
+				buf.WriteString("ALTER TABLE " + tableName + " WITH CHECK CHECK CONSTRAINT " + QuoteIdentifier(dialect, constraint.ConstraintName) + ";\n")
+			}
 		}
 	}
 
@@ -754,18 +989,7 @@ END;
 		buf := bufpool.Get().(*bytes.Buffer)
 		buf.Reset()
 		bufs = append(bufs, buf)
-		for _, fkey := range fkeys {
-			if buf.Len() > 0 {
-				buf.WriteString("\n")
-			}
-			buf.WriteString("ALTER TABLE ")
-			if fkey.TableSchema != "" && fkey.TableSchema != m.currentSchema {
-				buf.WriteString(QuoteIdentifier(dialect, fkey.TableSchema) + ".")
-			}
-			buf.WriteString(QuoteIdentifier(dialect, fkey.TableName) + " ADD ")
-			writeConstraintDefinition(dialect, buf, m.currentSchema, fkey)
-			buf.WriteString(";\n")
-		}
+		m.writeAddForeignKeys(buf, fkeys)
 	}
 
 	// ADD FOREIGN KEY (existing tables).
@@ -777,19 +1001,229 @@ END;
 		buf := bufpool.Get().(*bytes.Buffer)
 		buf.Reset()
 		bufs = append(bufs, buf)
-		for _, fkey := range fkeys {
-			if buf.Len() > 0 {
-				buf.WriteString("\n")
-			}
-			buf.WriteString("ALTER TABLE ")
-			if fkey.TableSchema != "" && fkey.TableSchema != m.currentSchema {
-				buf.WriteString(QuoteIdentifier(dialect, fkey.TableSchema) + ".")
-			}
-			buf.WriteString(QuoteIdentifier(dialect, fkey.TableName) + " ADD ")
-			writeConstraintDefinition(dialect, buf, m.currentSchema, fkey)
-			buf.WriteString(";\n")
+		m.writeAddForeignKeys(buf, fkeys)
+	}
+
+	// DROP SEQUENCE.
+	if len(m.dropSequences) > 0 {
+		n++
+		// ${prefix}_${n}_drop_sequences.sql
+		filenames = append(filenames, fmt.Sprintf("%s_%02d_drop_sequences.sql", prefix, n))
+		buf := bufpool.Get().(*bytes.Buffer)
+		buf.Reset()
+		bufs = append(bufs, buf)
+		for _, sequence := range m.dropSequences {
+			buf.WriteString("DROP SEQUENCE " + m.sequenceName(sequence) + ";\n")
+		}
+		// ${prefix}_${n}_drop_sequences.undo.sql
+		undobuf := bufpool.Get().(*bytes.Buffer)
+		undobuf.Reset()
+		for i := len(m.dropSequences) - 1; i >= 0; i-- {
+			undobuf.WriteString("IF OBJECT_ID('" + EscapeQuote(m.sequenceName(m.dropSequences[i]), '\'') + "', 'SO') IS NULL ")
+			writeCreateSequence(dialect, undobuf, m.currentSchema, m.dropSequences[i], false)
 		}
+		filenames, bufs = appendUndo(filenames, bufs, undobuf)
 	}
 
 	return filenames, bufs, warnings
 }
+
+// sequenceName returns the quoted name of a sequence, schema-qualified if the
+// sequence is not in the current schema.
+func (m *sqlserverMigration) sequenceName(sequence *Sequence) string {
+	const dialect = DialectSQLServer
+	sequenceName := QuoteIdentifier(dialect, sequence.SequenceName)
+	if sequence.SequenceSchema != "" && sequence.SequenceSchema != m.currentSchema {
+		sequenceName = QuoteIdentifier(dialect, sequence.SequenceSchema) + "." + sequenceName
+	}
+	return sequenceName
+}
+
+// onlineOptions returns the WITH clause that creates (or drops) an index or a
+// PRIMARY KEY/UNIQUE constraint online, if online is true and the edition
+// supports online index operations. Otherwise it returns an empty string and
+// a warning that the index will be built offline. If resumable is also true
+// and the version supports it, RESUMABLE = ON is added as well and isResumable
+// is true. Resumable operations cannot run inside a transaction.
+func (m *sqlserverMigration) onlineOptions(tableName, objectType, name string, online, resumable bool) (with string, isResumable bool, warnings []string) {
+	if !online && !resumable {
+		return "", false, nil
+	}
+	if !sqlserverSupportsOnline(m.edition) {
+		warnings = append(warnings, fmt.Sprintf("%s: %s %q will be built offline (blocking writes to the table) because SQL Server %s does not support online index operations", tableName, objectType, name, m.edition))
+		return "", false, warnings
+	}
+	// Resumable index creation is available from SQL Server 2019 (15.x)
+	// onwards, resumable constraints from SQL Server 2022 (16.x) onwards.
+	// Azure SQL reports its own version numbers and supports both.
+	minVersion := 15
+	if objectType == "constraint" {
+		minVersion = 16
+	}
+	if resumable && m.versionNums.LowerThan(minVersion) && !strings.Contains(m.edition, "Azure") {
+		if online {
+			warnings = append(warnings, fmt.Sprintf("%s: %s %q will be built online but cannot be resumed because RESUMABLE requires SQL Server %d.x", tableName, objectType, name, minVersion))
+		}
+		resumable = false
+	}
+	if resumable {
+		return " WITH (ONLINE = ON, RESUMABLE = ON)", true, warnings
+	}
+	return " WITH (ONLINE = ON)", false, warnings
+}
+
+// sqlserverSupportsOnline reports if a SQL Server edition supports online
+// index operations. An unknown edition is assumed to support them.
+func sqlserverSupportsOnline(edition string) bool {
+	if edition == "" {
+		return true
+	}
+	for _, s := range []string{"Enterprise", "Developer", "Evaluation", "Azure"} {
+		if strings.Contains(edition, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeRename writes the sp_rename statement that renames a table, column,
+// index or constraint from oldName to newName.
+func (m *sqlserverMigration) writeRename(buf *bytes.Buffer, objectType, tableSchema, tableName, oldName, newName string) {
+	const dialect = DialectSQLServer
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	if tableSchema == "" {
+		tableSchema = m.currentSchema
+	}
+	if tableSchema == "" {
+		tableSchema = "dbo"
+	}
+	schemaName := QuoteIdentifier(dialect, tableSchema)
+	tableName = QuoteIdentifier(dialect, tableName)
+	oldName = QuoteIdentifier(dialect, oldName)
+	newName = "N'" + EscapeQuote(newName, '\'') + "'"
+	switch objectType {
+	case "TABLE":
+		buf.WriteString("EXEC sp_rename N'" + EscapeQuote(schemaName+"."+oldName, '\'') + "', " + newName + ";\n")
+	case "COLUMN":
+		buf.WriteString("EXEC sp_rename N'" + EscapeQuote(schemaName+"."+tableName+"."+oldName, '\'') + "', " + newName + ", N'COLUMN';\n")
+	case "INDEX":
+		buf.WriteString("EXEC sp_rename N'" + EscapeQuote(schemaName+"."+tableName+"."+oldName, '\'') + "', " + newName + ", N'INDEX';\n")
+	case "CONSTRAINT":
+		buf.WriteString("EXEC sp_rename N'" + EscapeQuote(schemaName+"."+oldName, '\'') + "', " + newName + ", N'OBJECT';\n")
+	}
+}
+
+// writeTable writes the CREATE TABLE statement for a table, followed by its
+// CREATE INDEX statements and the extended properties of the table and its
+// columns. If ifNotExists is true the statements are wrapped in an IF block
+// that skips them if the table already exists (used by undo migrations).
+func (m *sqlserverMigration) writeTable(buf *bytes.Buffer, table *Table, ifNotExists bool) {
+	const dialect = DialectSQLServer
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	if ifNotExists {
+		tableName := QuoteIdentifier(dialect, table.TableName)
+		if table.TableSchema != "" && table.TableSchema != m.currentSchema {
+			tableName = QuoteIdentifier(dialect, table.TableSchema) + "." + tableName
+		}
+		buf.WriteString("IF OBJECT_ID('" + EscapeQuote(tableName, '\'') + "', 'U') IS NULL\nBEGIN\n")
+		defer buf.WriteString("END;\n")
+	}
+	writeCreateTable(dialect, buf, m.currentSchema, m.defaultCollation, table, true, false)
+	for _, index := range table.Indexes {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		writeCreateIndex(dialect, buf, m.currentSchema, &index, false, false)
+	}
+	// COMMENT.
+	tableSchema := table.TableSchema
+	if tableSchema == "" {
+		tableSchema = m.currentSchema
+	}
+	if tableSchema == "" {
+		tableSchema = "dbo"
+	}
+	if table.Comment != "" {
+		writeExtendedProperty(buf, tableSchema, table.TableName, "", "", table.Comment)
+	}
+	for _, column := range table.Columns {
+		if column.Ignore || column.Comment == "" {
+			continue
+		}
+		writeExtendedProperty(buf, tableSchema, table.TableName, column.ColumnName, "", column.Comment)
+	}
+}
+
+// writeAddForeignKeys writes the ALTER TABLE ADD statements for a list of
+// foreign keys.
+func (m *sqlserverMigration) writeAddForeignKeys(buf *bytes.Buffer, fkeys []*Constraint) {
+	const dialect = DialectSQLServer
+	for _, fkey := range fkeys {
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("ALTER TABLE ")
+		if fkey.TableSchema != "" && fkey.TableSchema != m.currentSchema {
+			buf.WriteString(QuoteIdentifier(dialect, fkey.TableSchema) + ".")
+		}
+		buf.WriteString(QuoteIdentifier(dialect, fkey.TableName) + " ADD ")
+		writeConstraintDefinition(dialect, buf, m.currentSchema, fkey)
+		buf.WriteString(";\n")
+	}
+}
+
+// writeDropDefault writes the statement that drops the DEFAULT constraint of a
+// column. DEFAULT constraints are usually given system-generated names, so the
+// name has to be looked up first.
+func writeDropDefault(buf *bytes.Buffer, qualifiedTableName, tableSchema, tableName, columnName string) {
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	// https://stackoverflow.com/a/49393082/3030828
+	buf.WriteString(`BEGIN
+    DECLARE @name NVARCHAR(255);
+    SELECT
+        @name = default_constraints.name
+    FROM
+        sys.default_constraints
+        JOIN sys.tables ON tables.object_id = default_constraints.parent_object_id
+        JOIN sys.columns ON columns.column_id = default_constraints.parent_column_id
+    WHERE
+        SCHEMA_NAME(tables.schema_id) = '` + EscapeQuote(tableSchema, '\'') + `'
+        AND tables.name = '` + EscapeQuote(tableName, '\'') + `'
+        AND columns.name = '` + EscapeQuote(columnName, '\'') + `'
+    ;
+    EXEC('ALTER TABLE ` + EscapeQuote(qualifiedTableName, '\'') + ` DROP CONSTRAINT ' + @name);
+END;
+`)
+}
+
+// writeExtendedProperty writes the statement that adds, updates or drops the
+// MS_Description extended property (SQL Server's equivalent of a comment) of a
+// table or column. If columnName is empty, the extended property of the table
+// is targeted.
+func writeExtendedProperty(buf *bytes.Buffer, tableSchema, tableName, columnName, srcComment, destComment string) {
+	if srcComment == destComment {
+		return
+	}
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	switch {
+	case srcComment == "":
+		buf.WriteString("EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'" + EscapeQuote(destComment, '\'') + "', ")
+	case destComment == "":
+		buf.WriteString("EXEC sp_dropextendedproperty @name = N'MS_Description', ")
+	default:
+		buf.WriteString("EXEC sp_updateextendedproperty @name = N'MS_Description', @value = N'" + EscapeQuote(destComment, '\'') + "', ")
+	}
+	buf.WriteString("@level0type = N'SCHEMA', @level0name = N'" + EscapeQuote(tableSchema, '\'') + "', @level1type = N'TABLE', @level1name = N'" + EscapeQuote(tableName, '\'') + "'")
+	if columnName != "" {
+		buf.WriteString(", @level2type = N'COLUMN', @level2name = N'" + EscapeQuote(columnName, '\'') + "'")
+	}
+	buf.WriteString(";\n")
+}
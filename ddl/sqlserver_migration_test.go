@@ -23,6 +23,12 @@ func Test_sqlserverMigration(t *testing.T) {
 		{"testdata/sqlserver_add", false},
 		{"testdata/sqlserver_alter", false},
 		{"testdata/sqlserver_ignore", true},
+		{"testdata/sqlserver_comment", false},
+		{"testdata/sqlserver_rename", true},
+		{"testdata/sqlserver_check", true},
+		{"testdata/sqlserver_index", false},
+		{"testdata/sqlserver_sequence", true},
+		{"testdata/sqlserver_identity", false},
 	}
 	newCatalog := func(t *testing.T, filename string) *Catalog {
 		file, err := os.Open(filename)
@@ -78,3 +84,126 @@ func Test_sqlserverMigration(t *testing.T) {
 		})
 	}
 }
+
+func Test_sqlserverMigration_online(t *testing.T) {
+	type TT struct {
+		description   string
+		versionNums   VersionNums
+		edition       string
+		onlineIndexes bool
+		resumable     bool
+		wantFilenames []string
+		wantContents  []string
+		wantWarnings  []string
+	}
+	srcCatalog := &Catalog{
+		Dialect:       "sqlserver",
+		CurrentSchema: "dbo",
+		Schemas: []Schema{{
+			SchemaName: "dbo",
+			Tables: []Table{{
+				TableSchema: "dbo",
+				TableName:   "actor",
+				Columns: []Column{
+					{TableSchema: "dbo", TableName: "actor", ColumnName: "actor_id", ColumnType: "INT"},
+					{TableSchema: "dbo", TableName: "actor", ColumnName: "name", ColumnType: "NVARCHAR(100)"},
+				},
+			}},
+		}},
+	}
+	newDestCatalog := func(resumable bool) *Catalog {
+		return &Catalog{
+			Dialect:       "sqlserver",
+			CurrentSchema: "dbo",
+			Schemas: []Schema{{
+				SchemaName: "dbo",
+				Tables: []Table{{
+					TableSchema: "dbo",
+					TableName:   "actor",
+					Columns: []Column{
+						{TableSchema: "dbo", TableName: "actor", ColumnName: "actor_id", ColumnType: "INT"},
+						{TableSchema: "dbo", TableName: "actor", ColumnName: "name", ColumnType: "NVARCHAR(100)"},
+					},
+					Indexes: []Index{{
+						TableSchema: "dbo",
+						TableName:   "actor",
+						IndexName:   "actor_name_idx",
+						Columns:     []string{"name"},
+						Online:      resumable,
+						Resumable:   resumable,
+					}},
+				}},
+			}},
+		}
+	}
+	tests := []TT{{
+		description:   "flag not set",
+		versionNums:   VersionNums{16},
+		edition:       "Enterprise Edition (64-bit)",
+		wantFilenames: []string{"online_02_create_actor_name_idx.tx.sql"},
+		wantContents:  []string{"CREATE INDEX actor_name_idx ON actor (name);\n"},
+	}, {
+		description:   "SQL Server 2019 Enterprise",
+		versionNums:   VersionNums{15, 0, 2000, 5},
+		edition:       "Enterprise Edition (64-bit)",
+		onlineIndexes: true,
+		wantFilenames: []string{"online_02_create_actor_name_idx.txoff.sql"},
+		wantContents:  []string{"CREATE INDEX actor_name_idx ON actor (name) WITH (ONLINE = ON, RESUMABLE = ON);\n"},
+	}, {
+		description:   "SQL Server 2017 Developer",
+		versionNums:   VersionNums{14, 0, 1000, 169},
+		edition:       "Developer Edition (64-bit)",
+		onlineIndexes: true,
+		wantFilenames: []string{"online_02_create_actor_name_idx.tx.sql"},
+		wantContents:  []string{"CREATE INDEX actor_name_idx ON actor (name) WITH (ONLINE = ON);\n"},
+		wantWarnings: []string{
+			`actor: index "actor_name_idx" will be built online but cannot be resumed because RESUMABLE requires SQL Server 15.x`,
+		},
+	}, {
+		description:   "SQL Server 2019 Standard",
+		versionNums:   VersionNums{15, 0, 2000, 5},
+		edition:       "Standard Edition (64-bit)",
+		onlineIndexes: true,
+		wantFilenames: []string{"online_02_create_actor_name_idx.tx.sql"},
+		wantContents:  []string{"CREATE INDEX actor_name_idx ON actor (name);\n"},
+		wantWarnings: []string{
+			`actor: index "actor_name_idx" will be built offline (blocking writes to the table) because SQL Server Standard Edition (64-bit) does not support online index operations`,
+		},
+	}, {
+		description:   "Azure SQL resumable submodifier",
+		versionNums:   VersionNums{12, 0, 2000, 8},
+		edition:       "SQL Azure",
+		resumable:     true,
+		wantFilenames: []string{"online_02_create_actor_name_idx.txoff.sql"},
+		wantContents:  []string{"CREATE INDEX actor_name_idx ON actor (name) WITH (ONLINE = ON, RESUMABLE = ON);\n"},
+	}}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.description, func(t *testing.T) {
+			t.Parallel()
+			src := *srcCatalog
+			src.VersionNums = tt.versionNums
+			src.Edition = tt.edition
+			m := newSQLServerMigration(&src, newDestCatalog(tt.resumable), false)
+			m.onlineIndexes = tt.onlineIndexes
+			filenames, bufs, warnings := m.sql("online")
+			var gotFilenames, gotContents []string
+			for i, filename := range filenames {
+				if strings.HasSuffix(filename, ".undo.sql") || bufs[i].Len() == 0 {
+					continue
+				}
+				gotFilenames = append(gotFilenames, filename)
+				gotContents = append(gotContents, bufs[i].String())
+			}
+			if diff := testutil.Diff(gotFilenames, tt.wantFilenames); diff != "" {
+				t.Error(testutil.Callers(), diff)
+			}
+			if diff := testutil.Diff(gotContents, tt.wantContents); diff != "" {
+				t.Error(testutil.Callers(), diff)
+			}
+			if diff := testutil.Diff(warnings, tt.wantWarnings); diff != "" {
+				t.Error(testutil.Callers(), diff)
+			}
+		})
+	}
+}
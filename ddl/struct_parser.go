@@ -1,7 +1,6 @@
 package ddl
 
 import (
-	"bytes"
 	"fmt"
 	"go/ast"
 	"go/parser"
@@ -178,7 +177,7 @@ func (p *StructParser) WriteCatalog(catalog *Catalog) error {
 				fieldName:  structField.Name,
 			}
 			if (structField.Name == "" && structField.Type == "sq.TableStruct") || (structField.Name == "_" && structField.Type == "struct{}") {
-				p.parseTableModifiers(table, loc, structField.Modifiers)
+				p.parseTableModifiers(catalog, table, loc, structField.Modifiers)
 				continue
 			}
 			columnName := strings.ToLower(structField.Name)
@@ -196,6 +195,8 @@ func (p *StructParser) WriteCatalog(catalog *Catalog) error {
 					columnType = "TEXT[]"
 				case DialectSQLServer:
 					columnType, characterLength = "NVARCHAR(MAX)", "MAX"
+				case DialectOracle:
+					columnType = "CLOB"
 				default:
 					columnType, characterLength = "VARCHAR(255)", "255"
 				}
@@ -209,6 +210,8 @@ func (p *StructParser) WriteCatalog(catalog *Catalog) error {
 					columnType = "MEDIUMBLOB"
 				case DialectSQLServer:
 					columnType, characterLength = "VARBINARY(MAX)", "MAX"
+				case DialectOracle:
+					columnType = "BLOB"
 				default:
 					columnType = "BINARY"
 				}
@@ -216,6 +219,8 @@ func (p *StructParser) WriteCatalog(catalog *Catalog) error {
 				switch p.dialect {
 				case DialectSQLServer:
 					columnType = "BIT"
+				case DialectOracle:
+					columnType = "NUMBER(1)"
 				default:
 					columnType = "BOOLEAN"
 				}
@@ -225,6 +230,8 @@ func (p *StructParser) WriteCatalog(catalog *Catalog) error {
 					columnType = "TEXT"
 				case DialectSQLServer:
 					columnType, characterLength = "NVARCHAR(255)", "255"
+				case DialectOracle:
+					columnType, characterLength = "VARCHAR2(255)", "255"
 				default:
 					columnType, characterLength = "VARCHAR(255)", "255"
 				}
@@ -236,17 +243,26 @@ func (p *StructParser) WriteCatalog(catalog *Catalog) error {
 					columnType = "JSONB"
 				case DialectSQLServer:
 					columnType, characterLength = "NVARCHAR(MAX)", "MAX"
+				case DialectOracle:
+					columnType = "CLOB"
 				default:
 					columnType = "VARCHAR(255)"
 				}
 			case "sq.NumberField":
-				columnType = "INT"
+				switch p.dialect {
+				case DialectOracle:
+					columnType = "NUMBER(19)"
+				default:
+					columnType = "INT"
+				}
 			case "sq.StringField":
 				switch p.dialect {
 				case DialectSQLite, DialectPostgres:
 					columnType = "TEXT"
 				case DialectSQLServer:
 					columnType, characterLength = "NVARCHAR(255)", "255"
+				case DialectOracle:
+					columnType, characterLength = "VARCHAR2(255)", "255"
 				default:
 					columnType, characterLength = "VARCHAR(255)", "255"
 				}
@@ -256,6 +272,8 @@ func (p *StructParser) WriteCatalog(catalog *Catalog) error {
 					columnType = "TIMESTAMPTZ"
 				case DialectSQLServer:
 					columnType = "DATETIMEOFFSET"
+				case DialectOracle:
+					columnType = "TIMESTAMP WITH TIME ZONE"
 				default:
 					columnType = "DATETIME"
 				}
@@ -263,6 +281,8 @@ func (p *StructParser) WriteCatalog(catalog *Catalog) error {
 				switch p.dialect {
 				case DialectSQLite, DialectPostgres:
 					columnType = "UUID"
+				case DialectOracle:
+					columnType = "RAW(16)"
 				default:
 					columnType = "BINARY(16)"
 				}
@@ -276,6 +296,17 @@ func (p *StructParser) WriteCatalog(catalog *Catalog) error {
 			p.parseColumnModifiers(table, columnName, columnType, loc, structField.Modifiers)
 		}
 
+		// Validate column existence for sequences owned by the table.
+		for _, sequence := range schema.Sequences {
+			if sequence.Ignore || sequence.OwnedByTable != table.TableName {
+				continue
+			}
+			if p.cache.GetColumn(table, sequence.OwnedByColumn) == nil {
+				loc := p.locations[[2]string{schema.SchemaName, sequence.SequenceName}]
+				p.report(loc, "ownedby: "+sequence.OwnedByColumn+" does not exist in the table")
+			}
+		}
+
 		// Validate column existence for PRIMARY KEY and UNIQUE constraints.
 		for _, constraint := range table.Constraints {
 			if constraint.Ignore {
@@ -332,6 +363,13 @@ func (p *StructParser) WriteCatalog(catalog *Catalog) error {
 					p.report(loc, strings.Join(index.Columns, ",")+": "+columnName+" does not exist in the table")
 				}
 			}
+			for _, columnName := range index.IncludeColumns {
+				column := p.cache.GetColumn(table, columnName)
+				if column == nil {
+					loc := p.locations[[2]string{table.TableSchema, index.IndexName}]
+					p.report(loc, "include="+strings.Join(index.IncludeColumns, ",")+": "+columnName+" does not exist in the table")
+				}
+			}
 		}
 
 		// Set PRIMARY KEY columns to NOT NULL.
@@ -411,11 +449,26 @@ func (p *StructParser) parseIndexModifier(table *Table, columnNames []string, lo
 		p.report(loc, "no column provided")
 	}
 	indexName := GenerateName(INDEX, table.TableName, columnNames)
+	for i := range m.Submodifiers {
+		submodifier := &m.Submodifiers[i]
+		if submodifier.Name == "name" && submodifier.RawValue != "" && !submodifier.ExcludesDialect(p.dialect) {
+			indexName = submodifier.RawValue
+		}
+	}
 	p.locations[[2]string{table.TableSchema, indexName}] = loc
 	index := p.cache.GetOrCreateIndex(table, indexName, columnNames)
 	index.TableSchema = table.TableSchema
 	index.TableName = table.TableName
 	index.Ignore = m.ExcludesDialect(p.dialect)
+	columnPosition := func(columnName string) int {
+		for j, name := range index.Columns {
+			if name == columnName {
+				return j
+			}
+		}
+		p.report(loc, strings.Join(index.Columns, ",")+": "+columnName+" is not an indexed column")
+		return -1
+	}
 	for i := range m.Submodifiers {
 		submodifier := &m.Submodifiers[i]
 		if submodifier.ExcludesDialect(p.dialect) {
@@ -429,6 +482,78 @@ func (p *StructParser) parseIndexModifier(table *Table, columnNames []string, lo
 				continue
 			}
 			index.IndexType = submodifier.RawValue
+		case "name":
+			if submodifier.RawValue == "" {
+				p.report(loc, "no index name provided")
+			}
+		case "desc":
+			index.Descending = make([]bool, len(index.Columns))
+			if submodifier.RawValue == "" {
+				for j := range index.Descending {
+					index.Descending[j] = true
+				}
+				continue
+			}
+			for _, columnName := range strings.Split(submodifier.RawValue, ",") {
+				if j := columnPosition(columnName); j >= 0 {
+					index.Descending[j] = true
+				}
+			}
+		case "opclass":
+			if submodifier.RawValue == "" {
+				p.report(loc, "no opclass provided")
+				continue
+			}
+			if p.dialect != DialectPostgres {
+				continue
+			}
+			index.Opclasses = make([]string, len(index.Columns))
+			entries := strings.Split(submodifier.RawValue, ",")
+			for _, entry := range entries {
+				columnName, opclass, found := strings.Cut(entry, ":")
+				if !found {
+					if len(entries) > 1 {
+						p.report(loc, "opclass: "+strconv.Quote(entry)+" must be in the form column:opclass")
+						continue
+					}
+					for j := range index.Opclasses {
+						index.Opclasses[j] = entry
+					}
+					continue
+				}
+				if j := columnPosition(columnName); j >= 0 {
+					index.Opclasses[j] = opclass
+				}
+			}
+		case "include":
+			if submodifier.RawValue == "" {
+				p.report(loc, "no include columns provided")
+				continue
+			}
+			if p.dialect != DialectPostgres && p.dialect != DialectSQLServer {
+				continue
+			}
+			index.IncludeColumns = strings.Split(submodifier.RawValue, ",")
+		case "where":
+			if submodifier.RawValue == "" {
+				p.report(loc, "no index predicate provided")
+				continue
+			}
+			if p.dialect != DialectSQLite && p.dialect != DialectPostgres && p.dialect != DialectSQLServer {
+				continue
+			}
+			index.Predicate = submodifier.RawValue
+		case "online":
+			if p.dialect != DialectSQLServer {
+				continue
+			}
+			index.Online = true
+		case "resumable":
+			if p.dialect != DialectSQLServer {
+				continue
+			}
+			index.Online = true
+			index.Resumable = true
 		default:
 			p.report(loc, "unknown modifier "+strconv.Quote(submodifier.Name))
 		}
@@ -483,6 +608,188 @@ func (p *StructParser) parsePrimaryKeyUniqueModifier(table *Table, columnNames [
 	}
 }
 
+func (p *StructParser) parseCheckModifier(table *Table, columnNames []string, loc location, m *Modifier) {
+	err := m.ParseRawValue()
+	if err != nil {
+		p.report(loc, err.Error())
+		return
+	}
+	if m.Value == "" {
+		p.report(loc, "no check expression provided")
+		return
+	}
+	var constraintName string
+	for i := range m.Submodifiers {
+		submodifier := &m.Submodifiers[i]
+		if submodifier.ExcludesDialect(p.dialect) {
+			continue
+		}
+		switch submodifier.Name {
+		case "name":
+			constraintName = submodifier.RawValue
+		default:
+			p.report(loc, "unknown modifier "+strconv.Quote(submodifier.Name))
+		}
+	}
+	if constraintName == "" {
+		if len(columnNames) == 0 {
+			p.report(loc, "table-level check constraint requires a name")
+			return
+		}
+		constraintName = GenerateName(CHECK, table.TableName, columnNames)
+	}
+	p.locations[[2]string{table.TableSchema, constraintName}] = loc
+	constraint := p.cache.GetOrCreateConstraint(table, constraintName, CHECK, columnNames)
+	constraint.TableSchema = table.TableSchema
+	constraint.TableName = table.TableName
+	constraint.CheckExpr = m.Value
+	constraint.Ignore = m.ExcludesDialect(p.dialect)
+}
+
+func (p *StructParser) parseExcludeModifier(table *Table, loc location, m *Modifier) {
+	// An exclude modifier has no value, only submodifiers.
+	submodifiers, err := NewModifiers(m.RawValue)
+	if err != nil {
+		p.report(loc, err.Error())
+		return
+	}
+	var constraintName, indexType, predicate string
+	var isDeferrable, isInitiallyDeferred bool
+	var columnNames, operators []string
+	for i := range submodifiers {
+		submodifier := &submodifiers[i]
+		if submodifier.ExcludesDialect(p.dialect) {
+			continue
+		}
+		switch submodifier.Name {
+		case "cols":
+			if submodifier.RawValue == "" {
+				p.report(loc, "no exclusion columns provided")
+				return
+			}
+			for _, item := range strings.Split(submodifier.RawValue, ",") {
+				i := strings.IndexByte(item, ':')
+				if i <= 0 || i == len(item)-1 {
+					p.report(loc, strconv.Quote(submodifier.RawValue)+": must be in the form column:operator")
+					return
+				}
+				columnNames = append(columnNames, item[:i])
+				operators = append(operators, item[i+1:])
+			}
+		case "using":
+			indexType = submodifier.RawValue
+		case "where":
+			if submodifier.RawValue == "" {
+				p.report(loc, "no exclusion predicate provided")
+				return
+			}
+			predicate = submodifier.RawValue
+		case "name":
+			if submodifier.RawValue == "" {
+				p.report(loc, "no constraint name provided")
+				return
+			}
+			constraintName = submodifier.RawValue
+		case "deferrable":
+			isDeferrable = true
+		case "deferred":
+			isDeferrable = true
+			isInitiallyDeferred = true
+		default:
+			p.report(loc, "unknown modifier "+strconv.Quote(submodifier.Name))
+		}
+	}
+	if len(columnNames) == 0 {
+		p.report(loc, "no exclusion columns provided")
+		return
+	}
+	if p.dialect != DialectPostgres {
+		return
+	}
+	if constraintName == "" {
+		constraintName = GenerateName(EXCLUDE, table.TableName, columnNames)
+	}
+	p.locations[[2]string{table.TableSchema, constraintName}] = loc
+	constraint := p.cache.GetOrCreateConstraint(table, constraintName, EXCLUDE, columnNames)
+	constraint.TableSchema = table.TableSchema
+	constraint.TableName = table.TableName
+	constraint.ExclusionOperators = operators
+	constraint.ExclusionIndexType = indexType
+	constraint.ExclusionPredicate = predicate
+	constraint.IsDeferrable = isDeferrable
+	constraint.IsInitiallyDeferred = isInitiallyDeferred
+	constraint.Ignore = m.ExcludesDialect(p.dialect)
+}
+
+func (p *StructParser) parsePartitionModifier(table *Table, loc location, m *Modifier) {
+	// A partition modifier has no value, only submodifiers.
+	submodifiers, err := NewModifiers(m.RawValue)
+	if err != nil {
+		p.report(loc, err.Error())
+		return
+	}
+	var partitionStrategy, partitionKey, partitionName, partitionBound string
+	for i := range submodifiers {
+		submodifier := &submodifiers[i]
+		if submodifier.ExcludesDialect(p.dialect) {
+			continue
+		}
+		switch submodifier.Name {
+		case "by":
+			if submodifier.RawValue == "" {
+				p.report(loc, "no partition strategy provided")
+				return
+			}
+			partitionStrategy = strings.ToUpper(submodifier.RawValue)
+		case "key":
+			partitionKey = submodifier.RawValue
+		case "name":
+			if submodifier.RawValue == "" {
+				p.report(loc, "no partition name provided")
+				return
+			}
+			partitionName = submodifier.RawValue
+		case "bound":
+			partitionBound = submodifier.RawValue
+		default:
+			p.report(loc, "unknown modifier "+strconv.Quote(submodifier.Name))
+		}
+	}
+	if partitionStrategy == "" && partitionName == "" {
+		p.report(loc, "either a partition strategy (by) or a partition name (name) must be provided")
+		return
+	}
+	if partitionStrategy != "" && partitionKey == "" && partitionStrategy != "KEY" && partitionStrategy != "LINEAR KEY" {
+		p.report(loc, "no partition key provided")
+		return
+	}
+	if partitionName != "" && partitionBound == "" && p.dialect == DialectPostgres {
+		p.report(loc, partitionName+": no partition bound provided")
+		return
+	}
+	if (p.dialect != DialectPostgres && p.dialect != DialectMySQL) || m.ExcludesDialect(p.dialect) {
+		return
+	}
+	if partitionStrategy != "" {
+		table.PartitionStrategy = partitionStrategy
+		table.PartitionKey = partitionKey
+	}
+	if partitionName != "" {
+		for _, partition := range table.Partitions {
+			if partition.PartitionName == partitionName {
+				p.report(loc, "partition "+partitionName+" already exists")
+				return
+			}
+		}
+		table.Partitions = append(table.Partitions, Partition{
+			TableSchema:    table.TableSchema,
+			TableName:      table.TableName,
+			PartitionName:  partitionName,
+			PartitionBound: partitionBound,
+		})
+	}
+}
+
 func (p *StructParser) parseForeignKeyModifier(table *Table, loc location, m *Modifier) {
 	err := m.ParseRawValue()
 	if err != nil {
@@ -528,7 +835,7 @@ func (p *StructParser) parseForeignKeyModifier(table *Table, loc location, m *Mo
 			case "cascade":
 				constraint.UpdateRule = CASCADE
 			case "restrict":
-				if p.dialect == DialectSQLServer {
+				if p.dialect == DialectSQLServer || p.dialect == DialectOracle {
 					constraint.UpdateRule = NO_ACTION
 				} else {
 					constraint.UpdateRule = RESTRICT
@@ -550,7 +857,7 @@ func (p *StructParser) parseForeignKeyModifier(table *Table, loc location, m *Mo
 			case "cascade":
 				constraint.DeleteRule = CASCADE
 			case "restrict":
-				if p.dialect == DialectSQLServer {
+				if p.dialect == DialectSQLServer || p.dialect == DialectOracle {
 					constraint.DeleteRule = NO_ACTION
 				} else {
 					constraint.DeleteRule = RESTRICT
@@ -626,7 +933,7 @@ func (p *StructParser) parseReferencesModifier(table *Table, columnName string,
 			case "cascade":
 				constraint.UpdateRule = CASCADE
 			case "restrict":
-				if p.dialect == DialectSQLServer {
+				if p.dialect == DialectSQLServer || p.dialect == DialectOracle {
 					constraint.UpdateRule = NO_ACTION
 				} else {
 					constraint.UpdateRule = RESTRICT
@@ -648,7 +955,7 @@ func (p *StructParser) parseReferencesModifier(table *Table, columnName string,
 			case "cascade":
 				constraint.DeleteRule = CASCADE
 			case "restrict":
-				if p.dialect == DialectSQLServer {
+				if p.dialect == DialectSQLServer || p.dialect == DialectOracle {
 					constraint.DeleteRule = NO_ACTION
 				} else {
 					constraint.DeleteRule = RESTRICT
@@ -704,14 +1011,14 @@ func (p *StructParser) parseColumnModifiers(table *Table, columnName, columnType
 			column.ColumnType = modifier.RawValue
 			normalizedType, arg1, arg2 := normalizeColumnType(p.dialect, column.ColumnType)
 			switch normalizedType {
-			case "VARBINARY", "BINARY", "NVARCHAR", "VARCHAR", "CHAR":
+			case "VARBINARY", "BINARY", "NVARCHAR", "VARCHAR", "CHAR", "VARCHAR2", "NVARCHAR2", "RAW":
 				if arg1 != "" {
 					column.CharacterLength = arg1
 				} else if column.CharacterLength != "" {
 					column.ColumnType = column.ColumnType + "(" + column.CharacterLength + ")"
 				}
 				column.NumericPrecision, column.NumericScale = "", ""
-			case "NUMERIC":
+			case "NUMERIC", "NUMBER":
 				if arg1 != "" {
 					column.NumericPrecision = arg1
 				}
@@ -730,6 +1037,8 @@ func (p *StructParser) parseColumnModifiers(table *Table, columnName, columnType
 					column.ColumnType = "VARCHAR(" + column.CharacterLength + ")"
 				case DialectSQLServer:
 					column.ColumnType = "NVARCHAR(" + column.CharacterLength + ")"
+				case DialectOracle:
+					column.ColumnType = "VARCHAR2(" + column.CharacterLength + ")"
 				}
 			}
 		case "auto_increment":
@@ -745,18 +1054,22 @@ func (p *StructParser) parseColumnModifiers(table *Table, columnName, columnType
 			column.IsAutoincrement = true
 		case "identity":
 			switch p.dialect {
-			case DialectPostgres:
+			case DialectPostgres, DialectOracle:
 				column.ColumnIdentity = DEFAULT_IDENTITY
 			case DialectSQLServer:
 				column.ColumnIdentity = IDENTITY
 			}
+			loc.keys = []string{modifier.Name}
+			p.parseIdentityModifier(column, loc, modifier)
 		case "alwaysidentity":
 			switch p.dialect {
-			case DialectPostgres:
+			case DialectPostgres, DialectOracle:
 				column.ColumnIdentity = ALWAYS_IDENTITY
 			case DialectSQLServer:
 				column.ColumnIdentity = IDENTITY
 			}
+			loc.keys = []string{modifier.Name}
+			p.parseIdentityModifier(column, loc, modifier)
 		case "notnull":
 			column.IsNotNull = true
 		case "onupdatecurrenttimestamp":
@@ -772,7 +1085,7 @@ func (p *StructParser) parseColumnModifiers(table *Table, columnName, columnType
 				continue
 			}
 			column.ColumnDefault = modifier.RawValue
-			if p.dialect == DialectSQLServer {
+			if p.dialect == DialectSQLServer || p.dialect == DialectOracle {
 				if strings.EqualFold(column.ColumnDefault, "TRUE") {
 					column.ColumnDefault = "1"
 				} else if strings.EqualFold(column.ColumnDefault, "FALSE") {
@@ -781,6 +1094,10 @@ func (p *StructParser) parseColumnModifiers(table *Table, columnName, columnType
 			}
 		case "generated":
 			column.IsGenerated = true
+		case "comment":
+			column.Comment = unquoteComment(modifier.RawValue)
+		case "renamedfrom":
+			column.RenamedFrom = modifier.RawValue
 		case "dialect":
 			if modifier.RawValue == "" {
 				loc.keys = []string{modifier.Name}
@@ -801,6 +1118,9 @@ func (p *StructParser) parseColumnModifiers(table *Table, columnName, columnType
 		case "primarykey", "unique":
 			loc.keys = []string{modifier.Name}
 			p.parsePrimaryKeyUniqueModifier(table, []string{columnName}, loc, modifier)
+		case "check":
+			loc.keys = []string{modifier.Name}
+			p.parseCheckModifier(table, []string{columnName}, loc, modifier)
 		case "foreignkey":
 			loc.keys = []string{modifier.Name}
 			p.parseForeignKeyModifier(table, loc, modifier)
@@ -813,8 +1133,44 @@ func (p *StructParser) parseColumnModifiers(table *Table, columnName, columnType
 	}
 }
 
-func (p *StructParser) parseTableModifiers(table *Table, loc location, modifiers []Modifier) {
-	var dialects []string
+// parseIdentityModifier parses the start and increment submodifiers of an
+// identity or alwaysidentity modifier.
+func (p *StructParser) parseIdentityModifier(column *Column, loc location, m *Modifier) {
+	// An identity modifier has no value, only submodifiers.
+	submodifiers, err := NewModifiers(m.RawValue)
+	if err != nil {
+		p.report(loc, err.Error())
+		return
+	}
+	for i := range submodifiers {
+		submodifier := &submodifiers[i]
+		if submodifier.ExcludesDialect(p.dialect) {
+			continue
+		}
+		switch submodifier.Name {
+		case "start", "increment":
+			num, err := strconv.ParseInt(submodifier.RawValue, 10, 64)
+			if err != nil {
+				p.report(loc, submodifier.Name+": "+strconv.Quote(submodifier.RawValue)+" is not an integer")
+				continue
+			}
+			if submodifier.Name == "start" {
+				column.IdentityStart = num
+			} else {
+				column.IdentityIncrement = num
+			}
+		default:
+			p.report(loc, "unknown modifier "+strconv.Quote(submodifier.Name))
+		}
+	}
+	if column.ColumnIdentity == "" {
+		column.IdentityStart, column.IdentityIncrement = 0, 0
+	}
+}
+
+func (p *StructParser) parseTableModifiers(catalog *Catalog, table *Table, loc location, modifiers []Modifier) {
+	var dialects, extensions []string
+	var sequences []Sequence
 	for i := range modifiers {
 		modifier := &modifiers[i]
 		if len(modifier.Dialects) == 0 {
@@ -841,36 +1197,189 @@ func (p *StructParser) parseTableModifiers(table *Table, loc location, modifiers
 		case "primarykey", "unique":
 			loc.keys = []string{modifier.Name}
 			p.parsePrimaryKeyUniqueModifier(table, nil, loc, modifier)
+		case "check":
+			loc.keys = []string{modifier.Name}
+			p.parseCheckModifier(table, nil, loc, modifier)
+		case "exclude":
+			loc.keys = []string{modifier.Name}
+			p.parseExcludeModifier(table, loc, modifier)
 		case "foreignkey":
 			loc.keys = []string{modifier.Name}
 			p.parseForeignKeyModifier(table, loc, modifier)
+		case "partition":
+			loc.keys = []string{modifier.Name}
+			p.parsePartitionModifier(table, loc, modifier)
 		case "virtual":
 			if p.dialect != DialectSQLite {
 				continue
 			}
 			table.IsVirtual = true
+		case "engine", "charset":
+			if modifier.RawValue == "" {
+				loc.keys = []string{modifier.Name}
+				p.report(loc, modifier.Name+" value cannot be blank")
+				continue
+			}
+			if p.dialect != DialectMySQL || modifier.ExcludesDialect(p.dialect) {
+				continue
+			}
+			if modifier.Name == "engine" {
+				table.Engine = modifier.RawValue
+			} else {
+				table.Charset = modifier.RawValue
+			}
+		case "with":
+			if modifier.RawValue == "" {
+				loc.keys = []string{modifier.Name}
+				p.report(loc, "with value cannot be blank")
+				continue
+			}
+			if p.dialect != DialectPostgres || modifier.ExcludesDialect(p.dialect) {
+				continue
+			}
+			table.StorageParams = strings.Split(modifier.RawValue, ",")
+		case "unlogged":
+			if p.dialect != DialectPostgres || modifier.ExcludesDialect(p.dialect) {
+				continue
+			}
+			table.IsUnlogged = true
+		case "strict", "withoutrowid":
+			if p.dialect != DialectSQLite || modifier.ExcludesDialect(p.dialect) {
+				continue
+			}
+			if modifier.Name == "strict" {
+				table.IsStrict = true
+			} else {
+				table.IsWithoutRowid = true
+			}
+		case "comment":
+			if modifier.ExcludesDialect(p.dialect) {
+				continue
+			}
+			table.Comment = unquoteComment(modifier.RawValue)
+		case "renamedfrom":
+			if modifier.ExcludesDialect(p.dialect) {
+				continue
+			}
+			table.RenamedFrom = modifier.RawValue
+		case "extension":
+			if modifier.RawValue == "" {
+				loc.keys = []string{modifier.Name}
+				p.report(loc, "extension value cannot be blank")
+				continue
+			}
+			if p.dialect != DialectPostgres || modifier.ExcludesDialect(p.dialect) {
+				continue
+			}
+			extensions = append(extensions, strings.Split(modifier.RawValue, ",")...)
+		case "sequence":
+			loc.keys = []string{modifier.Name}
+			sequence, ok := p.parseSequenceModifier(table, loc, modifier)
+			if ok {
+				sequences = append(sequences, sequence)
+			}
 		default:
 			p.report(loc, "unknown modifier "+strconv.Quote(modifier.Name))
 		}
 	}
+	if table.Ignore {
+		return
+	}
+	for _, extension := range extensions {
+		p.cache.AddExtension(catalog, extension)
+	}
+	if len(sequences) > 0 {
+		schema := p.cache.GetOrCreateSchema(catalog, table.TableSchema)
+		for _, sequence := range sequences {
+			if p.cache.GetSequence(schema, sequence.SequenceName) != nil {
+				p.report(loc, "sequence "+sequence.SequenceName+" already exists")
+				continue
+			}
+			p.locations[[2]string{schema.SchemaName, sequence.SequenceName}] = loc
+			p.cache.AddOrUpdateSequence(schema, sequence)
+		}
+	}
 }
 
-func (p *StructParser) report(loc location, msg string) {
-	p.parserDiagnostics.locs = append(p.parserDiagnostics.locs, loc)
-	p.parserDiagnostics.msgs = append(p.parserDiagnostics.msgs, msg)
+// parseSequenceModifier parses a sequence modifier. The sequence is created in
+// the same schema as the table it is declared on. The boolean result reports
+// whether the sequence applies to the current dialect.
+func (p *StructParser) parseSequenceModifier(table *Table, loc location, m *Modifier) (sequence Sequence, ok bool) {
+	err := m.ParseRawValue()
+	if err != nil {
+		p.report(loc, err.Error())
+		return sequence, false
+	}
+	if m.Value == "" {
+		p.report(loc, "no sequence name provided")
+		return sequence, false
+	}
+	sequence.SequenceSchema = table.TableSchema
+	sequence.SequenceName = m.Value
+	parseInt := func(submodifier *Modifier) int64 {
+		num, err := strconv.ParseInt(submodifier.RawValue, 10, 64)
+		if err != nil {
+			p.report(loc, submodifier.Name+": "+strconv.Quote(submodifier.RawValue)+" is not an integer")
+		}
+		return num
+	}
+	for i := range m.Submodifiers {
+		submodifier := &m.Submodifiers[i]
+		if submodifier.ExcludesDialect(p.dialect) {
+			continue
+		}
+		switch submodifier.Name {
+		case "type":
+			sequence.DataType = strings.ToUpper(submodifier.RawValue)
+		case "start":
+			sequence.StartValue = parseInt(submodifier)
+		case "increment":
+			sequence.IncrementBy = parseInt(submodifier)
+		case "minvalue":
+			sequence.MinValue = parseInt(submodifier)
+		case "maxvalue":
+			sequence.MaxValue = parseInt(submodifier)
+		case "cycle":
+			sequence.IsCycle = true
+		case "ownedby":
+			if submodifier.RawValue == "" {
+				p.report(loc, "ownedby value cannot be blank")
+				continue
+			}
+			sequence.OwnedByTable = table.TableName
+			sequence.OwnedByColumn = submodifier.RawValue
+		default:
+			p.report(loc, "unknown modifier "+strconv.Quote(submodifier.Name))
+		}
+	}
+	switch p.dialect {
+	case DialectPostgres, DialectSQLServer, DialectOracle:
+	default:
+		return sequence, false
+	}
+	if m.ExcludesDialect(p.dialect) {
+		return sequence, false
+	}
+	if p.dialect != DialectPostgres {
+		sequence.OwnedByTable, sequence.OwnedByColumn = "", ""
+	}
+	return sequence, true
 }
 
-type location struct {
-	pos        token.Pos
-	structName string
-	fieldName  string
-	keys       []string
+// unquoteComment returns the comment represented by a comment modifier value.
+// The value may optionally be written as an SQL string literal, in which case
+// the surrounding single quotes are removed and any escaped single quotes are
+// unescaped.
+func unquoteComment(value string) string {
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+	return value
 }
 
-type parserDiagnostics struct {
-	fset *token.FileSet
-	locs []location
-	msgs []string
+func (p *StructParser) report(loc location, msg string) {
+	p.parserDiagnostics.locs = append(p.parserDiagnostics.locs, loc)
+	p.parserDiagnostics.msgs = append(p.parserDiagnostics.msgs, msg)
 }
 
 // Diagnostics returns the errors encountered after calling WriteCatalog in a
@@ -914,43 +1423,6 @@ func (p *StructParser) Error() error {
 	return nil
 }
 
-// Error implements the error interface.
-func (d *parserDiagnostics) Error() string {
-	buf := bufpool.Get().(*bytes.Buffer)
-	buf.Reset()
-	defer bufpool.Put(buf)
-	for i, msg := range d.msgs {
-		if i > 0 {
-			buf.WriteString("\n")
-		}
-		if i >= len(d.locs) {
-			buf.WriteString(msg)
-			continue
-		}
-		loc := d.locs[i]
-		if d.fset != nil {
-			pos := d.fset.Position(loc.pos)
-			if pos.IsValid() {
-				buf.WriteString(pos.String() + ": ")
-			}
-		}
-		if loc.structName != "" && loc.fieldName != "" {
-			buf.WriteString(loc.structName + "." + loc.fieldName + ": ")
-		}
-		if len(loc.keys) > 0 {
-			for j, key := range loc.keys {
-				if j > 0 {
-					buf.WriteByte('.')
-				}
-				buf.WriteString(key)
-			}
-			buf.WriteString(": ")
-		}
-		buf.WriteString(msg)
-	}
-	return buf.String()
-}
-
 // Analyzer is an &analysis.Analyzer which can be used in a custom linter.
 var Analyzer = &analysis.Analyzer{
 	Name:     "ddl",
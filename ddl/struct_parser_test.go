@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"testing/fstest"
 
 	"github.com/bokwoon95/sqddl/internal/testutil"
 )
@@ -37,3 +38,51 @@ func TestStructParser(t *testing.T) {
 		t.Error(testutil.Callers(), diff)
 	}
 }
+
+func TestStructParserRoundTrip(t *testing.T) {
+	newCatalog := func(t *testing.T, p *StructParser) *Catalog {
+		catalog := &Catalog{Dialect: DialectPostgres}
+		err := p.WriteCatalog(catalog)
+		if err != nil {
+			t.Fatal(testutil.Callers(), err)
+		}
+		return catalog
+	}
+	file, err := os.Open("testdata/struct_parser/tables.go.txt")
+	if err != nil {
+		t.Fatal(testutil.Callers(), err)
+	}
+	defer file.Close()
+	p := NewStructParser(nil)
+	err = p.ParseFile(file)
+	if err != nil {
+		t.Fatal(testutil.Callers(), err)
+	}
+	wantCatalog := newCatalog(t, p)
+	var tableStructs TableStructs
+	err = tableStructs.ReadCatalog(wantCatalog)
+	if err != nil {
+		t.Fatal(testutil.Callers(), err)
+	}
+	text, err := tableStructs.MarshalText()
+	if err != nil {
+		t.Fatal(testutil.Callers(), err)
+	}
+	fsys := fstest.MapFS{
+		"tables.go.txt": &fstest.MapFile{Data: append([]byte("package tables\n\n"), text...)},
+	}
+	file2, err := fsys.Open("tables.go.txt")
+	if err != nil {
+		t.Fatal(testutil.Callers(), err)
+	}
+	defer file2.Close()
+	p = NewStructParser(nil)
+	err = p.ParseFile(file2)
+	if err != nil {
+		t.Fatal(testutil.Callers(), err, "\n"+string(text))
+	}
+	gotCatalog := newCatalog(t, p)
+	if diff := testutil.Diff(gotCatalog, wantCatalog); diff != "" {
+		t.Error(testutil.Callers(), diff, "\n"+string(text))
+	}
+}
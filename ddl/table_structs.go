@@ -43,7 +43,8 @@ type StructField struct {
 // TableStructs, each TableStruct corresponding to a table in the database. You
 // may narrow down the list of tables by filling in the Schemas,
 // ExcludeSchemas, Tables and ExcludeTables fields of the Filter struct. The
-// Filter.ObjectTypes field will always be set to []string{"TABLES"}.
+// Filter.ObjectTypes field will always be set to []string{"SEQUENCES",
+// "TABLES"}.
 func NewTableStructs(dialect string, db *sql.DB, filter Filter) (TableStructs, error) {
 	var tableStructs TableStructs
 	var catalog Catalog
@@ -52,7 +53,7 @@ func NewTableStructs(dialect string, db *sql.DB, filter Filter) (TableStructs, e
 		Dialect: dialect,
 		DB:      db,
 	}
-	dbi.ObjectTypes = []string{"TABLES"}
+	dbi.ObjectTypes = []string{"SEQUENCES", "TABLES"}
 	err := dbi.WriteCatalog(&catalog)
 	if err != nil {
 		return nil, err
@@ -70,6 +71,19 @@ func (s *TableStructs) ReadCatalog(catalog *Catalog) error {
 	buf.Reset()
 	defer bufpool.Put(buf)
 	for _, schema := range catalog.Schemas {
+		// Sequences owned by a table are declared on that table, the rest are
+		// declared on the first table of the schema.
+		sequenceModifiers := make(map[string][]Modifier)
+		for _, sequence := range schema.Sequences {
+			if sequence.Ignore || strings.ContainsAny(sequence.SequenceName, " `{}") {
+				continue
+			}
+			tableName := sequence.OwnedByTable
+			if tableName == "" && len(schema.Tables) > 0 {
+				tableName = schema.Tables[0].TableName
+			}
+			sequenceModifiers[tableName] = append(sequenceModifiers[tableName], sequenceModifier(catalog.Dialect, sequence))
+		}
 		for _, table := range schema.Tables {
 			tableStruct := TableStruct{
 				Name:   strings.ToUpper(strings.ReplaceAll(table.TableName, " ", "_")),
@@ -87,11 +101,63 @@ func (s *TableStructs) ReadCatalog(catalog *Catalog) error {
 			if catalog.Dialect == DialectSQLite && isVirtualTable(&table) {
 				firstField.Modifiers = append(firstField.Modifiers, Modifier{Name: "virtual"})
 			}
+			// Table options. The MySQL engine and charset are left out if
+			// they are the database defaults.
+			switch catalog.Dialect {
+			case DialectSQLite:
+				if table.IsStrict {
+					firstField.Modifiers = append(firstField.Modifiers, Modifier{Name: "strict"})
+				}
+				if table.IsWithoutRowid {
+					firstField.Modifiers = append(firstField.Modifiers, Modifier{Name: "withoutrowid"})
+				}
+			case DialectPostgres:
+				if table.IsUnlogged {
+					firstField.Modifiers = append(firstField.Modifiers, Modifier{Name: "unlogged"})
+				}
+				if len(table.StorageParams) > 0 && !strings.ContainsAny(strings.Join(table.StorageParams, ""), " `{}") {
+					firstField.Modifiers = append(firstField.Modifiers, Modifier{Name: "with", RawValue: strings.Join(table.StorageParams, ",")})
+				}
+			case DialectMySQL:
+				if table.Engine != "" && !strings.EqualFold(table.Engine, "InnoDB") {
+					firstField.Modifiers = append(firstField.Modifiers, Modifier{Name: "engine", RawValue: table.Engine})
+				}
+				if table.Charset != "" && !strings.HasPrefix(catalog.DefaultCollation, table.Charset+"_") {
+					firstField.Modifiers = append(firstField.Modifiers, Modifier{Name: "charset", RawValue: table.Charset})
+				}
+			}
+			firstField.Modifiers = append(firstField.Modifiers, sequenceModifiers[table.TableName]...)
+			// partition
+			var partitionFields []StructField
+			if isTaggablePartitioning(&table) {
+				firstField.Modifiers = append(firstField.Modifiers, Modifier{
+					Name: "partition",
+					RawValue: Modifiers{
+						{Name: "by", RawValue: table.PartitionStrategy},
+						{Name: "key", RawValue: table.PartitionKey},
+					}.String(),
+				})
+				for _, partition := range table.Partitions {
+					if partition.Ignore {
+						continue
+					}
+					submodifiers := Modifiers{{Name: "name", RawValue: partition.PartitionName}}
+					if partition.PartitionBound != "" {
+						submodifiers = append(submodifiers, Modifier{Name: "bound", RawValue: partition.PartitionBound})
+					}
+					partitionFields = append(partitionFields, StructField{
+						Name:      "_",
+						Type:      "struct{}",
+						Modifiers: Modifiers{{Name: "partition", RawValue: submodifiers.String()}},
+					})
+				}
+			}
 			constraintModifierList := make([]*Modifier, 0, len(table.Constraints))
 			indexModifierList := make([]*Modifier, 0, len(table.Indexes))
 			var primarykeyModifier *Modifier
 			uniqueModifiers := make(map[string]*Modifier)
 			foreignkeyModifiers := make(map[string]*Modifier)
+			checkModifiers := make(map[string]*Modifier)
 			indexModifiers := make(map[string]*Modifier)
 			addedModifier := make(map[*Modifier]bool)
 			for _, constraint := range table.Constraints {
@@ -145,6 +211,48 @@ func (s *TableStructs) ReadCatalog(catalog *Catalog) error {
 							Value: strings.ToLower(strings.ReplaceAll(constraint.DeleteRule, " ", "")),
 						})
 					}
+				case CHECK:
+					// Check expressions containing backticks or braces cannot
+					// be represented in a struct tag.
+					if constraint.CheckExpr == "" || strings.ContainsAny(constraint.CheckExpr, "`{}") {
+						continue
+					}
+					m.Name = "check"
+					m.Value = constraint.CheckExpr
+					// A check constraint on a single column with the default
+					// name can be placed on the column itself.
+					if len(constraint.Columns) == 1 && constraint.ConstraintName == GenerateName(CHECK, table.TableName, constraint.Columns) {
+						checkModifiers[columnNames] = m
+					} else {
+						m.Submodifiers = append(m.Submodifiers, Modifier{Name: "name", RawValue: constraint.ConstraintName})
+					}
+				case EXCLUDE:
+					if catalog.Dialect != DialectPostgres || !isTaggableExclusion(constraint) {
+						continue
+					}
+					m.Name = "exclude"
+					m.Value = ""
+					// using
+					if constraint.ExclusionIndexType != "" && !strings.EqualFold(constraint.ExclusionIndexType, "BTREE") {
+						m.Submodifiers = append(m.Submodifiers, Modifier{Name: "using", RawValue: constraint.ExclusionIndexType})
+					}
+					// cols
+					buf.Reset()
+					for j, column := range constraint.Columns {
+						if j > 0 {
+							buf.WriteString(",")
+						}
+						buf.WriteString(column + ":" + constraint.ExclusionOperators[j])
+					}
+					m.Submodifiers = append(m.Submodifiers, Modifier{Name: "cols", RawValue: buf.String()})
+					// where
+					if constraint.ExclusionPredicate != "" {
+						m.Submodifiers = append(m.Submodifiers, Modifier{Name: "where", RawValue: constraint.ExclusionPredicate})
+					}
+					// name
+					if constraint.ConstraintName != GenerateName(EXCLUDE, table.TableName, constraint.Columns) {
+						m.Submodifiers = append(m.Submodifiers, Modifier{Name: "name", RawValue: constraint.ConstraintName})
+					}
 				default:
 					continue
 				}
@@ -156,10 +264,16 @@ func (s *TableStructs) ReadCatalog(catalog *Catalog) error {
 						m.Submodifiers = append(m.Submodifiers, Modifier{Name: "deferrable"})
 					}
 				}
+				// The exclude modifier has no value, so its submodifiers are
+				// written out as its raw value instead.
+				if m.Name == "exclude" {
+					m.RawValue = Modifiers(m.Submodifiers).String()
+					m.Submodifiers = nil
+				}
 				constraintModifierList = append(constraintModifierList, m)
 			}
 			for _, index := range table.Indexes {
-				if index.Ignore || !isSimpleIndex(index) {
+				if index.Ignore || !isTaggableIndex(index) {
 					continue
 				}
 				columnNames := strings.Join(index.Columns, ",")
@@ -173,6 +287,52 @@ func (s *TableStructs) ReadCatalog(catalog *Catalog) error {
 				if index.IndexType != "" && !strings.EqualFold(index.IndexType, "BTREE") {
 					m.Submodifiers = append(m.Submodifiers, Modifier{Name: "using", RawValue: index.IndexType})
 				}
+				// desc
+				var descendingColumns []string
+				for j, column := range index.Columns {
+					if j < len(index.Descending) && index.Descending[j] {
+						descendingColumns = append(descendingColumns, column)
+					}
+				}
+				if len(descendingColumns) == len(index.Columns) {
+					m.Submodifiers = append(m.Submodifiers, Modifier{Name: "desc"})
+				} else if len(descendingColumns) > 0 {
+					m.Submodifiers = append(m.Submodifiers, Modifier{Name: "desc", RawValue: strings.Join(descendingColumns, ",")})
+				}
+				// opclass
+				if catalog.Dialect == DialectPostgres {
+					// If every column uses the same opclass it is written once,
+					// otherwise it is written as column:opclass pairs.
+					var opclasses []string
+					sameOpclass := true
+					for j, column := range index.Columns {
+						if j >= len(index.Opclasses) || isDefaultOpclass(index.Opclasses[j]) {
+							sameOpclass = false
+							continue
+						}
+						if index.Opclasses[j] != index.Opclasses[0] {
+							sameOpclass = false
+						}
+						opclasses = append(opclasses, column+":"+index.Opclasses[j])
+					}
+					if sameOpclass {
+						m.Submodifiers = append(m.Submodifiers, Modifier{Name: "opclass", RawValue: index.Opclasses[0]})
+					} else if len(opclasses) > 0 {
+						m.Submodifiers = append(m.Submodifiers, Modifier{Name: "opclass", RawValue: strings.Join(opclasses, ",")})
+					}
+				}
+				// include
+				if len(index.IncludeColumns) > 0 {
+					m.Submodifiers = append(m.Submodifiers, Modifier{Name: "include", RawValue: strings.Join(index.IncludeColumns, ",")})
+				}
+				// where
+				if index.Predicate != "" {
+					m.Submodifiers = append(m.Submodifiers, Modifier{Name: "where", RawValue: index.Predicate})
+				}
+				// name
+				if index.IndexName != GenerateName(INDEX, table.TableName, index.Columns) {
+					m.Submodifiers = append(m.Submodifiers, Modifier{Name: "name", RawValue: index.IndexName})
+				}
 				// foreignkey.index
 				if foreignkeyModifier := foreignkeyModifiers[columnNames]; foreignkeyModifier != nil {
 					addedModifier[m] = true
@@ -299,6 +459,11 @@ func (s *TableStructs) ReadCatalog(catalog *Catalog) error {
 					structField.Modifiers[i].Value = structField.Modifiers[i].Submodifiers[0].RawValue
 					structField.Modifiers[i].Submodifiers = structField.Modifiers[i].Submodifiers[1:]
 				}
+				// check
+				if checkModifier := checkModifiers[column.ColumnName]; checkModifier != nil {
+					addedModifier[checkModifier] = true
+					structField.Modifiers = append(structField.Modifiers, *checkModifier)
+				}
 				// autoincrement
 				if column.IsAutoincrement {
 					switch catalog.Dialect {
@@ -310,16 +475,27 @@ func (s *TableStructs) ReadCatalog(catalog *Catalog) error {
 				}
 				// identity
 				if column.ColumnIdentity != "" {
+					var identityOptions Modifiers
+					if column.IdentityStart != 0 {
+						identityOptions = append(identityOptions, Modifier{Name: "start", RawValue: strconv.FormatInt(column.IdentityStart, 10)})
+					}
+					if column.IdentityIncrement != 0 {
+						identityOptions = append(identityOptions, Modifier{Name: "increment", RawValue: strconv.FormatInt(column.IdentityIncrement, 10)})
+					}
+					var rawValue string
+					if len(identityOptions) > 0 {
+						rawValue = identityOptions.String()
+					}
 					switch catalog.Dialect {
 					case DialectPostgres:
 						if column.ColumnIdentity == DEFAULT_IDENTITY {
-							structField.Modifiers = append(structField.Modifiers, Modifier{Name: "identity"})
+							structField.Modifiers = append(structField.Modifiers, Modifier{Name: "identity", RawValue: rawValue})
 						} else if column.ColumnIdentity == ALWAYS_IDENTITY {
-							structField.Modifiers = append(structField.Modifiers, Modifier{Name: "alwaysidentity"})
+							structField.Modifiers = append(structField.Modifiers, Modifier{Name: "alwaysidentity", RawValue: rawValue})
 						}
 					case DialectSQLServer:
 						if column.ColumnIdentity == IDENTITY {
-							structField.Modifiers = append(structField.Modifiers, Modifier{Name: "identity"})
+							structField.Modifiers = append(structField.Modifiers, Modifier{Name: "identity", RawValue: rawValue})
 						}
 					}
 				}
@@ -347,11 +523,19 @@ func (s *TableStructs) ReadCatalog(catalog *Catalog) error {
 				if column.IsGenerated || column.GeneratedExpr != "" {
 					structField.Modifiers = append(structField.Modifiers, Modifier{Name: "generated"})
 				}
+				// comment
+				if isTaggableComment(column.Comment) {
+					structField.Modifiers = append(structField.Modifiers, Modifier{Name: "comment", RawValue: column.Comment})
+				}
 				tableStruct.Fields = append(tableStruct.Fields, structField)
 			}
 			if primarykeyModifier != nil && !addedModifier[primarykeyModifier] {
 				addedModifier[primarykeyModifier] = true
-				tableStruct.Fields[0].Modifiers = Modifiers{*primarykeyModifier}
+				tableStruct.Fields[0].Modifiers = append(tableStruct.Fields[0].Modifiers, *primarykeyModifier)
+			}
+			// comment
+			if isTaggableComment(table.Comment) {
+				tableStruct.Fields[0].Modifiers = append(tableStruct.Fields[0].Modifiers, Modifier{Name: "comment", RawValue: table.Comment})
 			}
 			for _, constraintModifier := range constraintModifierList {
 				if addedModifier[constraintModifier] {
@@ -373,6 +557,7 @@ func (s *TableStructs) ReadCatalog(catalog *Catalog) error {
 					Modifiers: Modifiers{*indexModifier},
 				})
 			}
+			tableStruct.Fields = append(tableStruct.Fields, partitionFields...)
 			*s = append(*s, tableStruct)
 		}
 	}
@@ -480,41 +665,122 @@ func needsQuoting(identifier string) bool {
 	return false
 }
 
-// We only consider simple indexes for table structs because complex indexes
-// involving predicates or included columns are harder to diff.
-func isSimpleIndex(index Index) bool {
-	if len(index.Columns) == 0 {
-		return false
+// isTaggableComment reports whether a comment can be written as a comment
+// modifier value. Comments containing backticks cannot appear inside a struct
+// tag and comments containing braces may not survive brace quoting, so they
+// are skipped.
+func isTaggableComment(comment string) bool {
+	return comment != "" && !strings.ContainsAny(comment, "`{}")
+}
+
+// sequenceModifier returns the sequence modifier for a sequence. Options that
+// are equal to the database defaults are omitted.
+func sequenceModifier(dialect string, sequence Sequence) Modifier {
+	var submodifiers Modifiers
+	var maxValue int64
+	switch sequence.DataType {
+	case "", "BIGINT":
+		maxValue = 9223372036854775807
+	case "INTEGER", "INT":
+		maxValue = 2147483647
+		submodifiers = append(submodifiers, Modifier{Name: "type", RawValue: sequence.DataType})
+	case "SMALLINT":
+		maxValue = 32767
+		submodifiers = append(submodifiers, Modifier{Name: "type", RawValue: sequence.DataType})
+	default:
+		submodifiers = append(submodifiers, Modifier{Name: "type", RawValue: sequence.DataType})
 	}
-	if len(index.IncludeColumns) > 0 {
-		return false
+	minValue := int64(1)
+	if dialect == DialectSQLServer && sequence.DataType != "" {
+		// SQL Server sequences start from the minimum value of their data
+		// type by default.
+		minValue = -maxValue - 1
+	}
+	if sequence.StartValue != 0 && sequence.StartValue != sequence.MinValue {
+		submodifiers = append(submodifiers, Modifier{Name: "start", RawValue: strconv.FormatInt(sequence.StartValue, 10)})
+	}
+	if sequence.IncrementBy != 0 && sequence.IncrementBy != 1 {
+		submodifiers = append(submodifiers, Modifier{Name: "increment", RawValue: strconv.FormatInt(sequence.IncrementBy, 10)})
+	}
+	if sequence.MinValue != 0 && sequence.MinValue != minValue {
+		submodifiers = append(submodifiers, Modifier{Name: "minvalue", RawValue: strconv.FormatInt(sequence.MinValue, 10)})
+	}
+	if sequence.MaxValue != 0 && sequence.MaxValue != maxValue {
+		submodifiers = append(submodifiers, Modifier{Name: "maxvalue", RawValue: strconv.FormatInt(sequence.MaxValue, 10)})
 	}
-	if index.Predicate != "" {
+	if sequence.IsCycle {
+		submodifiers = append(submodifiers, Modifier{Name: "cycle"})
+	}
+	if sequence.OwnedByColumn != "" {
+		submodifiers = append(submodifiers, Modifier{Name: "ownedby", RawValue: sequence.OwnedByColumn})
+	}
+	return Modifier{
+		Name:         "sequence",
+		Value:        sequence.SequenceName,
+		Submodifiers: submodifiers,
+	}
+}
+
+// isTaggablePartitioning reports if the partitioning of a table can be
+// represented by partition modifiers. Partition keys or bounds containing
+// backticks or braces cannot be represented in a struct tag.
+func isTaggablePartitioning(table *Table) bool {
+	if table.PartitionStrategy == "" || strings.ContainsAny(table.PartitionStrategy+table.PartitionKey, "`{}") {
 		return false
 	}
-	for _, isDescending := range index.Descending {
-		if isDescending {
+	for _, partition := range table.Partitions {
+		if strings.ContainsAny(partition.PartitionName, " `{}") || strings.ContainsAny(partition.PartitionBound, "`{}") {
 			return false
 		}
 	}
-	for _, opclass := range index.Opclasses {
-		if strings.Count(opclass, "_") > 1 {
+	return true
+}
+
+// isTaggableExclusion reports if an EXCLUDE constraint can be represented by
+// an exclude modifier. Exclusion constraints on expressions are skipped.
+func isTaggableExclusion(constraint Constraint) bool {
+	if len(constraint.Columns) == 0 || len(constraint.Columns) != len(constraint.ExclusionOperators) {
+		return false
+	}
+	for i, column := range constraint.Columns {
+		if column == "" || strings.HasPrefix(column, "(") || strings.ContainsAny(column, " ,:{}`") {
+			return false
+		}
+		if operator := constraint.ExclusionOperators[i]; operator == "" || strings.ContainsAny(operator, " ,{}`") {
 			return false
 		}
 	}
+	return !strings.ContainsAny(constraint.ExclusionPredicate, "`{}")
+}
+
+// isTaggableIndex reports if an index can be represented by an index
+// modifier. Indexes on expressions are skipped, as are indexes whose SQL
+// mentions a predicate, descending columns or included columns that were not
+// reported by the database.
+func isTaggableIndex(index Index) bool {
+	if len(index.Columns) == 0 {
+		return false
+	}
 	for _, column := range index.Columns {
-		if strings.HasPrefix(column, "(") {
+		if column == "" || strings.HasPrefix(column, "(") || strings.ContainsAny(column, " ,{}`") {
 			return false
 		}
 	}
+	if strings.ContainsAny(index.Predicate, "`{}") {
+		return false
+	}
+	hasDescending := false
+	for _, isDescending := range index.Descending {
+		hasDescending = hasDescending || isDescending
+	}
 	upperSQL := strings.ToUpper(index.SQL)
-	if strings.Contains(upperSQL, " WHERE ") {
+	if strings.Contains(upperSQL, " WHERE ") && index.Predicate == "" {
 		return false
 	}
-	if strings.Contains(upperSQL, " DESC") {
+	if strings.Contains(upperSQL, " DESC") && !hasDescending {
 		return false
 	}
-	if strings.Contains(upperSQL, " INCLUDE ") {
+	if strings.Contains(upperSQL, " INCLUDE ") && len(index.IncludeColumns) == 0 {
 		return false
 	}
 	return true
@@ -138,7 +138,7 @@ func (cmd *TablesCmd) Run() error {
 			ExcludeTables:  append(cmd.ExcludeTables, cmd.HistoryTable),
 		},
 	}
-	dbi.ObjectTypes = []string{"TABLES"}
+	dbi.ObjectTypes = []string{"SEQUENCES", "TABLES"}
 	err := dbi.WriteCatalog(&catalog)
 	if err != nil {
 		return err
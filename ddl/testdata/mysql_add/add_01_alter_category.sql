@@ -4,4 +4,6 @@ ALTER TABLE category
     ,MODIFY COLUMN category VARCHAR(255)
     ,ADD PRIMARY KEY (category_id)
     ,ADD CONSTRAINT category_category_key UNIQUE (category)
+    ,ALGORITHM=INPLACE
+    ,LOCK=NONE
 ;
@@ -0,0 +1,23 @@
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'category' AND constraint_name = 'category_category_key'), 'ALTER TABLE category DROP CONSTRAINT category_category_key', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'category' AND constraint_name = 'PRIMARY'), 'ALTER TABLE category DROP CONSTRAINT `PRIMARY`', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+ALTER TABLE category
+    MODIFY COLUMN category VARCHAR(255) NOT NULL
+;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'category' AND column_name = 'category_id'), 'ALTER TABLE category DROP COLUMN category_id', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'category' AND constraint_name = 'PRIMARY'), 'ALTER TABLE category ADD PRIMARY KEY (category)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
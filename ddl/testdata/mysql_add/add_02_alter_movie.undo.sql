@@ -0,0 +1,24 @@
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'movie' AND constraint_name = 'movie_title_key'), 'ALTER TABLE movie DROP CONSTRAINT movie_title_key', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'movie' AND constraint_name = 'PRIMARY'), 'ALTER TABLE movie DROP CONSTRAINT `PRIMARY`', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'movie' AND index_name = 'movie_subcategory_idx'), 'ALTER TABLE movie DROP INDEX movie_subcategory_idx', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'movie' AND index_name = 'movie_category_idx'), 'ALTER TABLE movie DROP INDEX movie_category_idx', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'movie' AND column_name = 'metadata'), 'ALTER TABLE movie DROP COLUMN metadata', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
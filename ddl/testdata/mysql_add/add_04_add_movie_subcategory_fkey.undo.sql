@@ -0,0 +1,4 @@
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'movie' AND constraint_name = 'movie_subcategory_fkey'), 'ALTER TABLE movie DROP CONSTRAINT movie_subcategory_fkey', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
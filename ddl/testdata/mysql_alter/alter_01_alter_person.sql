@@ -1,9 +1,25 @@
 ALTER TABLE person
     MODIFY COLUMN person_id INT NOT NULL
-    ,MODIFY COLUMN name VARCHAR(1000)
-    ,MODIFY COLUMN email VARCHAR(200) NOT NULL COLLATE latin1_bin
-    ,MODIFY COLUMN password VARCHAR(255)
-    ,MODIFY COLUMN bio VARCHAR(255) DEFAULT 'lorem ipsum'
+;
+
+ALTER TABLE person
+    MODIFY COLUMN name VARCHAR(1000)
+    ,ALGORITHM=INPLACE
+    ,LOCK=NONE
+;
+
+ALTER TABLE person
+    MODIFY COLUMN email VARCHAR(200) NOT NULL COLLATE latin1_bin
+    ,MODIFY COLUMN nickname VARCHAR(50)
+;
+
+ALTER TABLE person
+    MODIFY COLUMN password VARCHAR(255)
+    ,ALGORITHM=INSTANT
+;
+
+ALTER TABLE person
+    MODIFY COLUMN bio VARCHAR(255) DEFAULT 'lorem ipsum'
     ,MODIFY COLUMN notes VARCHAR(1000)
     ,MODIFY COLUMN height_meters NUMERIC(3,2)
     ,MODIFY COLUMN weight_kilos NUMERIC(3,2)
@@ -0,0 +1,12 @@
+ALTER TABLE person
+    MODIFY COLUMN salary_dollars DECIMAL(5,2)
+    ,MODIFY COLUMN weight_kilos NUMERIC(5,2)
+    ,MODIFY COLUMN height_meters NUMERIC(3,1)
+    ,MODIFY COLUMN notes VARCHAR(255)
+    ,MODIFY COLUMN bio VARCHAR(1000)
+    ,MODIFY COLUMN password VARCHAR(255) DEFAULT 'password'
+    ,MODIFY COLUMN nickname VARCHAR(100)
+    ,MODIFY COLUMN email VARCHAR(255)
+    ,MODIFY COLUMN name VARCHAR(256)
+    ,MODIFY COLUMN person_id VARCHAR(255) NOT NULL
+;
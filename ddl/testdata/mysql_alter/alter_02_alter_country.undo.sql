@@ -0,0 +1,3 @@
+ALTER TABLE country
+    MODIFY COLUMN country VARCHAR(255)
+;
@@ -0,0 +1,11 @@
+ALTER TABLE product
+    DROP CONSTRAINT product_price_positive
+    ,DROP CONSTRAINT product_quantity_check
+    ,ALGORITHM=INPLACE
+    ,LOCK=NONE
+;
+
+ALTER TABLE product
+    ADD CONSTRAINT product_discount_below_price CHECK (discount < price)
+    ,ADD CONSTRAINT product_price_check CHECK (price > 0)
+;
@@ -0,0 +1,6 @@
+CREATE TABLE store (
+    store_id INT NOT NULL
+    ,address VARCHAR(255) COMMENT 'Street address'
+
+    ,PRIMARY KEY (store_id)
+) COMMENT='Physical store locations';
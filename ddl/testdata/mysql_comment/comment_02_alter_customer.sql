@@ -0,0 +1,12 @@
+ALTER TABLE customer
+    ADD COLUMN phone VARCHAR(255) COMMENT 'Contact phone number'
+    ,ALGORITHM=INSTANT
+;
+
+ALTER TABLE customer
+    MODIFY COLUMN customer_id INT NOT NULL COMMENT 'Surrogate key'
+    ,MODIFY COLUMN notes VARCHAR(255)
+    ,COMMENT = 'Stores the customer''s details'
+    ,ALGORITHM=INPLACE
+    ,LOCK=NONE
+;
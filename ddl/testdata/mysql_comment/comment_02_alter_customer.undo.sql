@@ -0,0 +1,10 @@
+ALTER TABLE customer
+    MODIFY COLUMN notes VARCHAR(255) COMMENT 'Free-form notes'
+    ,MODIFY COLUMN customer_id INT NOT NULL
+    ,COMMENT = 'Stores customer details'
+;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'customer' AND column_name = 'phone'), 'ALTER TABLE customer DROP COLUMN phone', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1,4 @@
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'movie' AND constraint_name = 'movie_subcategory_fkey'), 'ALTER TABLE movie ADD CONSTRAINT movie_subcategory_fkey FOREIGN KEY (subcategory) REFERENCES sakila.category (category)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -1,8 +1,22 @@
 ALTER TABLE movie
     DROP CONSTRAINT `PRIMARY`
-    ,DROP CONSTRAINT movie_title_key
+;
+
+ALTER TABLE movie
+    DROP CONSTRAINT movie_title_key
     ,DROP INDEX movie_category_idx
     ,DROP INDEX movie_subcategory_idx
-    ,DROP COLUMN metadata
-    ,MODIFY COLUMN movie_id INT
+    ,ALGORITHM=INPLACE
+    ,LOCK=NONE
+;
+
+ALTER TABLE movie
+    DROP COLUMN metadata
+    ,ALGORITHM=INSTANT
+;
+
+ALTER TABLE movie
+    MODIFY COLUMN movie_id INT
+    ,ALGORITHM=INPLACE
+    ,LOCK=NONE
 ;
@@ -0,0 +1,28 @@
+ALTER TABLE movie
+    MODIFY COLUMN movie_id INT NOT NULL
+;
+
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'movie' AND column_name = 'metadata'), 'ALTER TABLE movie ADD COLUMN metadata JSON', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'movie' AND index_name = 'movie_category_idx'), 'ALTER TABLE movie ADD INDEX movie_category_idx (category)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'movie' AND index_name = 'movie_subcategory_idx'), 'ALTER TABLE movie ADD INDEX movie_subcategory_idx (subcategory)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'movie' AND constraint_name = 'PRIMARY'), 'ALTER TABLE movie ADD PRIMARY KEY (movie_id)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'movie' AND constraint_name = 'movie_title_key'), 'ALTER TABLE movie ADD CONSTRAINT movie_title_key UNIQUE (title)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1,9 @@
+ALTER TABLE product
+    DROP INDEX product_lookup_idx
+    ,DROP INDEX product_price_idx
+    ,ADD INDEX product_lookup_idx (name, sku DESC)
+    ,ADD UNIQUE INDEX product_sku_idx (sku)
+    ,ADD INDEX product_price_idx (price DESC)
+    ,ALGORITHM=INPLACE
+    ,LOCK=NONE
+;
@@ -0,0 +1,5 @@
+CREATE TABLE store (
+    store_id INT NOT NULL
+
+    ,PRIMARY KEY (store_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,9 @@
+ALTER TABLE customer
+    ENGINE = InnoDB
+;
+
+ALTER TABLE customer
+    DEFAULT CHARSET = utf8mb4
+    ,ALGORITHM=INPLACE
+    ,LOCK=NONE
+;
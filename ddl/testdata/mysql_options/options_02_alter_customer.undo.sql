@@ -0,0 +1,4 @@
+ALTER TABLE customer
+    ENGINE = MyISAM
+    ,DEFAULT CHARSET = latin1
+;
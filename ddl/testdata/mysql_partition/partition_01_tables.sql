@@ -0,0 +1,7 @@
+CREATE TABLE orders (
+    order_id INT NOT NULL
+    ,region_id INT NOT NULL
+) PARTITION BY LIST (region_id) (
+    PARTITION p_apac VALUES IN (1, 2)
+    ,PARTITION p_emea VALUES IN (3, 4)
+);
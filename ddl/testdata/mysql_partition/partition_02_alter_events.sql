@@ -0,0 +1,6 @@
+ALTER TABLE events DROP PARTITION p2025;
+
+ALTER TABLE events ADD PARTITION (
+    PARTITION p2027 VALUES LESS THAN (2028)
+    ,PARTITION pmax VALUES LESS THAN MAXVALUE
+);
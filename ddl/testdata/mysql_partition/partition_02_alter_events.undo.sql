@@ -0,0 +1,16 @@
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.partitions WHERE table_schema = DATABASE() AND table_name = 'events' AND partition_name = 'pmax'), 'ALTER TABLE events DROP PARTITION pmax', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.partitions WHERE table_schema = DATABASE() AND table_name = 'events' AND partition_name = 'p2027'), 'ALTER TABLE events DROP PARTITION p2027', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.partitions WHERE table_schema = DATABASE() AND table_name = 'events' AND partition_name = 'p2025'), 'ALTER TABLE events ADD PARTITION (
+    PARTITION p2025 VALUES LESS THAN (2026)
+)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
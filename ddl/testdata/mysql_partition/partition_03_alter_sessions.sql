@@ -0,0 +1 @@
+ALTER TABLE sessions REMOVE PARTITIONING;
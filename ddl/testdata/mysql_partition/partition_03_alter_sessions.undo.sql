@@ -0,0 +1,4 @@
+ALTER TABLE sessions PARTITION BY HASH (session_id) (
+    PARTITION p0
+    ,PARTITION p1
+);
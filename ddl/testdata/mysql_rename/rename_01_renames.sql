@@ -0,0 +1,15 @@
+RENAME TABLE actor TO performer;
+
+ALTER TABLE performer RENAME COLUMN actor_id TO performer_id;
+
+ALTER TABLE performer RENAME COLUMN first_name TO given_name;
+
+ALTER TABLE film RENAME COLUMN title TO name;
+
+ALTER TABLE film_actor RENAME COLUMN actor_id TO performer_id;
+
+ALTER TABLE performer RENAME INDEX actor_first_name_idx TO performer_given_name_idx;
+
+ALTER TABLE film RENAME INDEX film_title_key TO film_name_key;
+
+ALTER TABLE film_actor RENAME INDEX film_actor_actor_id_idx TO film_actor_performer_id_idx;
@@ -0,0 +1,39 @@
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'film_actor' AND index_name = 'film_actor_performer_id_idx'), 'ALTER TABLE film_actor RENAME INDEX film_actor_performer_id_idx TO film_actor_actor_id_idx', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'film' AND index_name = 'film_name_key'), 'ALTER TABLE film RENAME INDEX film_name_key TO film_title_key', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'performer' AND index_name = 'performer_given_name_idx'), 'ALTER TABLE performer RENAME INDEX performer_given_name_idx TO actor_first_name_idx', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'film_actor' AND column_name = 'performer_id'), 'ALTER TABLE film_actor RENAME COLUMN performer_id TO actor_id', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'film' AND column_name = 'name'), 'ALTER TABLE film RENAME COLUMN name TO title', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'performer' AND column_name = 'given_name'), 'ALTER TABLE performer RENAME COLUMN given_name TO first_name', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'performer' AND column_name = 'performer_id'), 'ALTER TABLE performer RENAME COLUMN performer_id TO actor_id', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = 'performer'), 'RENAME TABLE performer TO actor', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1 @@
+ALTER TABLE film_actor DROP CONSTRAINT film_actor_actor_id_fkey;
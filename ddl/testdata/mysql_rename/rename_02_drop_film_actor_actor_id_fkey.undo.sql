@@ -0,0 +1,4 @@
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'film_actor' AND constraint_name = 'film_actor_actor_id_fkey'), 'ALTER TABLE film_actor ADD CONSTRAINT film_actor_actor_id_fkey FOREIGN KEY (performer_id) REFERENCES sakila.performer (performer_id) ON UPDATE CASCADE', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1,5 @@
+ALTER TABLE film
+    DROP COLUMN description
+    ,ADD COLUMN synopsis VARCHAR(255)
+    ,ALGORITHM=INSTANT
+;
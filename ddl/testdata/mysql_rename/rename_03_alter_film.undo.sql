@@ -0,0 +1,9 @@
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'film' AND column_name = 'synopsis'), 'ALTER TABLE film DROP COLUMN synopsis', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'film' AND column_name = 'description'), 'ALTER TABLE film ADD COLUMN description VARCHAR(255)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1 @@
+ALTER TABLE film_actor ADD CONSTRAINT film_actor_performer_id_fkey FOREIGN KEY (performer_id) REFERENCES sakila.performer (performer_id) ON UPDATE CASCADE;
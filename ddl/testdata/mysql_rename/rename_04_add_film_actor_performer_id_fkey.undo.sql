@@ -0,0 +1,4 @@
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'film_actor' AND constraint_name = 'film_actor_performer_id_fkey'), 'ALTER TABLE film_actor DROP CONSTRAINT film_actor_performer_id_fkey', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
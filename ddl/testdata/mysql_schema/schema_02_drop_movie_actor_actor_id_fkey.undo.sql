@@ -0,0 +1,4 @@
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = 'foo' AND table_name = 'movie_actor' AND constraint_name = 'movie_actor_actor_id_fkey'), 'ALTER TABLE foo.movie_actor ADD CONSTRAINT movie_actor_actor_id_fkey FOREIGN KEY (actor_id) REFERENCES sakila.actor (actor_id)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
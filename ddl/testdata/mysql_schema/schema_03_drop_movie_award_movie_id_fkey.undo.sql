@@ -0,0 +1,4 @@
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = 'foo' AND table_name = 'movie_award' AND constraint_name = 'movie_award_movie_id_fkey'), 'ALTER TABLE foo.movie_award ADD CONSTRAINT movie_award_movie_id_fkey FOREIGN KEY (movie_id) REFERENCES foo.movie (movie_id)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
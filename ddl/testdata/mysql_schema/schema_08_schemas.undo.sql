@@ -1 +1,3 @@
 DROP SCHEMA IF EXISTS bar;
+
+CREATE SCHEMA IF NOT EXISTS foo;
@@ -1,5 +1,5 @@
-DROP TABLE IF EXISTS bar.movie;
+DROP TABLE IF EXISTS bar.movie_award;
 
 DROP TABLE IF EXISTS bar.movie_actor;
 
-DROP TABLE IF EXISTS bar.movie_award;
+DROP TABLE IF EXISTS bar.movie;
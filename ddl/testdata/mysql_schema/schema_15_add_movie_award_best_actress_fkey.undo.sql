@@ -0,0 +1,4 @@
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = 'bar' AND table_name = 'movie_award' AND constraint_name = 'movie_award_best_actress_fkey'), 'ALTER TABLE bar.movie_award DROP CONSTRAINT movie_award_best_actress_fkey', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
@@ -0,0 +1,4 @@
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'movie_award' AND constraint_name = 'movie_award_best_actor_fkey'), 'ALTER TABLE movie_award ADD CONSTRAINT movie_award_best_actor_fkey FOREIGN KEY (best_actor) REFERENCES sakila.actor (actor_id)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
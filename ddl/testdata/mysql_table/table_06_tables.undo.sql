@@ -1,5 +1,34 @@
-DROP TABLE IF EXISTS actors;
+DROP TABLE IF EXISTS movie_awards;
 
 DROP TABLE IF EXISTS movies;
 
-DROP TABLE IF EXISTS movie_awards;
+DROP TABLE IF EXISTS actors;
+
+CREATE TABLE IF NOT EXISTS actor (
+    actor_id INT NOT NULL
+    ,name VARCHAR(255)
+
+    ,PRIMARY KEY (actor_id)
+);
+
+CREATE TABLE IF NOT EXISTS movie (
+    movie_id INT NOT NULL
+    ,title VARCHAR(255)
+    ,synopsis VARCHAR(255)
+
+    ,PRIMARY KEY (movie_id)
+);
+
+
+SET @stmt = IF(NOT EXISTS (SELECT 1 FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'movie' AND index_name = 'movie_title_idx'), 'CREATE INDEX movie_title_idx ON movie (title)', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+CREATE TABLE IF NOT EXISTS movie_award (
+    movie_id INT
+    ,best_actor INT
+    ,best_supporting_actor INT
+    ,best_actress INT
+    ,best_supporting_actress INT
+);
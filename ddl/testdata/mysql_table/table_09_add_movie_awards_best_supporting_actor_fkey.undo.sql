@@ -0,0 +1,4 @@
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.table_constraints WHERE table_schema = DATABASE() AND table_name = 'movie_awards' AND constraint_name = 'movie_awards_best_supporting_actor_fkey'), 'ALTER TABLE movie_awards DROP CONSTRAINT movie_awards_best_supporting_actor_fkey', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
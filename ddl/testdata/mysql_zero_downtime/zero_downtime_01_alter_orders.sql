@@ -0,0 +1,9 @@
+ALTER TABLE orders
+    MODIFY COLUMN order_id BIGINT NOT NULL
+;
+
+ALTER TABLE orders
+    MODIFY COLUMN reference VARCHAR(100)
+    ,ALGORITHM=INPLACE
+    ,LOCK=NONE
+;
@@ -0,0 +1,4 @@
+ALTER TABLE orders
+    MODIFY COLUMN reference VARCHAR(20)
+    ,MODIFY COLUMN order_id INT NOT NULL
+;
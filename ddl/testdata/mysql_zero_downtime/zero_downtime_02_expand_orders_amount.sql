@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN amount_new VARCHAR(255);
@@ -0,0 +1,3 @@
+CREATE TRIGGER orders_amount_sync_insert BEFORE INSERT ON orders FOR EACH ROW SET NEW.amount_new = COALESCE(NEW.amount, '');
+
+CREATE TRIGGER orders_amount_sync_update BEFORE UPDATE ON orders FOR EACH ROW SET NEW.amount_new = COALESCE(NEW.amount, '');
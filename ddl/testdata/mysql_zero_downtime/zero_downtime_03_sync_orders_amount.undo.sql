@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS orders_amount_sync_insert;
+
+DROP TRIGGER IF EXISTS orders_amount_sync_update;
@@ -0,0 +1,10 @@
+CREATE PROCEDURE orders_amount_backfill()
+BEGIN
+    REPEAT
+        UPDATE orders SET amount_new = COALESCE(amount, '') WHERE amount_new IS NULL LIMIT 1000;
+    UNTIL ROW_COUNT() = 0 END REPEAT;
+END;
+
+CALL orders_amount_backfill();
+
+DROP PROCEDURE orders_amount_backfill;
@@ -0,0 +1,12 @@
+ALTER TABLE orders
+    MODIFY COLUMN amount_new VARCHAR(255) NOT NULL DEFAULT ''
+;
+
+DROP TRIGGER IF EXISTS orders_amount_sync_insert;
+
+DROP TRIGGER IF EXISTS orders_amount_sync_update;
+
+ALTER TABLE orders
+    CHANGE COLUMN amount amount_old INT
+    ,CHANGE COLUMN amount_new amount VARCHAR(255) NOT NULL DEFAULT ''
+;
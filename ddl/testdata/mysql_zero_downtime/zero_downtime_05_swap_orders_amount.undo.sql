@@ -0,0 +1,18 @@
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'orders' AND column_name = 'amount_old'), 'ALTER TABLE orders
+    CHANGE COLUMN amount amount_new VARCHAR(255) NOT NULL DEFAULT ''''
+    ,CHANGE COLUMN amount_old amount INT', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+DROP TRIGGER IF EXISTS orders_amount_sync_insert;
+
+DROP TRIGGER IF EXISTS orders_amount_sync_update;
+
+CREATE TRIGGER orders_amount_sync_insert BEFORE INSERT ON orders FOR EACH ROW SET NEW.amount_new = COALESCE(NEW.amount, '');
+
+CREATE TRIGGER orders_amount_sync_update BEFORE UPDATE ON orders FOR EACH ROW SET NEW.amount_new = COALESCE(NEW.amount, '');
+
+ALTER TABLE orders
+    MODIFY COLUMN amount_new VARCHAR(255)
+;
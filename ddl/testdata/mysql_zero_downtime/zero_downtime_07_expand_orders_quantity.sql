@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN quantity_new BIGINT;
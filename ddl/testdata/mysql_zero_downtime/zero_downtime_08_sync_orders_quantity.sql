@@ -0,0 +1,3 @@
+CREATE TRIGGER orders_quantity_sync_insert BEFORE INSERT ON orders FOR EACH ROW SET NEW.quantity_new = NEW.quantity;
+
+CREATE TRIGGER orders_quantity_sync_update BEFORE UPDATE ON orders FOR EACH ROW SET NEW.quantity_new = NEW.quantity;
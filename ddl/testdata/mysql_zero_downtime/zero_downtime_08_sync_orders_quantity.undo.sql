@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS orders_quantity_sync_insert;
+
+DROP TRIGGER IF EXISTS orders_quantity_sync_update;
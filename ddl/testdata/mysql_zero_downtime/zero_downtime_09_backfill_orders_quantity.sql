@@ -0,0 +1,10 @@
+CREATE PROCEDURE orders_quantity_backfill()
+BEGIN
+    REPEAT
+        UPDATE orders SET quantity_new = quantity WHERE quantity_new IS NULL AND quantity IS NOT NULL LIMIT 1000;
+    UNTIL ROW_COUNT() = 0 END REPEAT;
+END;
+
+CALL orders_quantity_backfill();
+
+DROP PROCEDURE orders_quantity_backfill;
@@ -0,0 +1,13 @@
+ALTER TABLE orders
+    MODIFY COLUMN quantity INT
+    ,MODIFY COLUMN quantity_new BIGINT NOT NULL
+;
+
+DROP TRIGGER IF EXISTS orders_quantity_sync_insert;
+
+DROP TRIGGER IF EXISTS orders_quantity_sync_update;
+
+ALTER TABLE orders
+    CHANGE COLUMN quantity quantity_old INT
+    ,CHANGE COLUMN quantity_new quantity BIGINT NOT NULL
+;
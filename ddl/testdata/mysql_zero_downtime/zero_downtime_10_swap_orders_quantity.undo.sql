@@ -0,0 +1,19 @@
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'orders' AND column_name = 'quantity_old'), 'ALTER TABLE orders
+    CHANGE COLUMN quantity quantity_new BIGINT NOT NULL
+    ,CHANGE COLUMN quantity_old quantity INT', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+DROP TRIGGER IF EXISTS orders_quantity_sync_insert;
+
+DROP TRIGGER IF EXISTS orders_quantity_sync_update;
+
+CREATE TRIGGER orders_quantity_sync_insert BEFORE INSERT ON orders FOR EACH ROW SET NEW.quantity_new = NEW.quantity;
+
+CREATE TRIGGER orders_quantity_sync_update BEFORE UPDATE ON orders FOR EACH ROW SET NEW.quantity_new = NEW.quantity;
+
+ALTER TABLE orders
+    MODIFY COLUMN quantity_new BIGINT
+    ,MODIFY COLUMN quantity INT NOT NULL
+;
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN priority_new BIGINT;
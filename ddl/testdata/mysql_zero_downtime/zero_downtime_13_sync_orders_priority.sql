@@ -0,0 +1,3 @@
+CREATE TRIGGER orders_priority_sync_insert BEFORE INSERT ON orders FOR EACH ROW SET NEW.priority_new = NEW.priority;
+
+CREATE TRIGGER orders_priority_sync_update BEFORE UPDATE ON orders FOR EACH ROW SET NEW.priority_new = NEW.priority;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS orders_priority_sync_insert;
+
+DROP TRIGGER IF EXISTS orders_priority_sync_update;
@@ -0,0 +1,10 @@
+CREATE PROCEDURE orders_priority_backfill()
+BEGIN
+    REPEAT
+        UPDATE orders SET priority_new = priority WHERE priority_new IS NULL AND priority IS NOT NULL LIMIT 1000;
+    UNTIL ROW_COUNT() = 0 END REPEAT;
+END;
+
+CALL orders_priority_backfill();
+
+DROP PROCEDURE orders_priority_backfill;
@@ -0,0 +1,12 @@
+ALTER TABLE orders
+    MODIFY COLUMN priority_new BIGINT NOT NULL
+;
+
+DROP TRIGGER IF EXISTS orders_priority_sync_insert;
+
+DROP TRIGGER IF EXISTS orders_priority_sync_update;
+
+ALTER TABLE orders
+    CHANGE COLUMN priority priority_old INT
+    ,CHANGE COLUMN priority_new priority BIGINT NOT NULL
+;
@@ -0,0 +1,18 @@
+SET @stmt = IF(EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = 'orders' AND column_name = 'priority_old'), 'ALTER TABLE orders
+    CHANGE COLUMN priority priority_new BIGINT NOT NULL
+    ,CHANGE COLUMN priority_old priority INT', 'DO 0');
+PREPARE stmt FROM @stmt;
+EXECUTE stmt;
+DEALLOCATE PREPARE stmt;
+
+DROP TRIGGER IF EXISTS orders_priority_sync_insert;
+
+DROP TRIGGER IF EXISTS orders_priority_sync_update;
+
+CREATE TRIGGER orders_priority_sync_insert BEFORE INSERT ON orders FOR EACH ROW SET NEW.priority_new = NEW.priority;
+
+CREATE TRIGGER orders_priority_sync_update BEFORE UPDATE ON orders FOR EACH ROW SET NEW.priority_new = NEW.priority;
+
+ALTER TABLE orders
+    MODIFY COLUMN priority_new BIGINT
+;
@@ -0,0 +1,7 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE category DROP CONSTRAINT category_category_pkey';
+    EXECUTE IMMEDIATE 'ALTER TABLE category ADD (category_id NUMBER(19) GENERATED BY DEFAULT AS IDENTITY NOT NULL)';
+    EXECUTE IMMEDIATE 'ALTER TABLE category MODIFY (category NULL)';
+    EXECUTE IMMEDIATE 'ALTER TABLE category ADD CONSTRAINT category_category_id_pkey PRIMARY KEY (category_id)';
+    EXECUTE IMMEDIATE 'ALTER TABLE category ADD CONSTRAINT category_category_key UNIQUE (category)';
+END;
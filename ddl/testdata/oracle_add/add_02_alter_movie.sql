@@ -0,0 +1,7 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE movie ADD (metadata CLOB)';
+    EXECUTE IMMEDIATE 'CREATE INDEX movie_category_idx ON movie (category)';
+    EXECUTE IMMEDIATE 'CREATE INDEX movie_subcategory_idx ON movie (subcategory)';
+    EXECUTE IMMEDIATE 'ALTER TABLE movie ADD CONSTRAINT movie_movie_id_pkey PRIMARY KEY (movie_id)';
+    EXECUTE IMMEDIATE 'ALTER TABLE movie ADD CONSTRAINT movie_title_key UNIQUE (title)';
+END;
@@ -0,0 +1,3 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE movie ADD CONSTRAINT movie_category_fkey FOREIGN KEY (category) REFERENCES category (category)';
+END;
@@ -0,0 +1,3 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE movie ADD CONSTRAINT movie_subcategory_fkey FOREIGN KEY (subcategory) REFERENCES category (category)';
+END;
@@ -0,0 +1,10 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE person MODIFY (email NOT NULL)';
+    EXECUTE IMMEDIATE 'ALTER TABLE person MODIFY (password DEFAULT NULL)';
+    EXECUTE IMMEDIATE 'ALTER TABLE person MODIFY (bio VARCHAR2(255) DEFAULT ''lorem ipsum'')';
+    EXECUTE IMMEDIATE 'ALTER TABLE person MODIFY (notes VARCHAR2(1000))';
+    EXECUTE IMMEDIATE 'ALTER TABLE person MODIFY (height_meters NUMBER(3,2))';
+    EXECUTE IMMEDIATE 'ALTER TABLE person MODIFY (weight_kilos NUMBER(3,2))';
+    EXECUTE IMMEDIATE 'ALTER TABLE person MODIFY (salary_dollars NUMERIC(10,2))';
+    EXECUTE IMMEDIATE 'ALTER TABLE person MODIFY (is_active DEFAULT 1 NOT NULL)';
+END;
@@ -0,0 +1,19 @@
+DECLARE
+    PROCEDURE exec_ddl(stmt VARCHAR2) IS
+    BEGIN
+        EXECUTE IMMEDIATE stmt;
+    EXCEPTION WHEN OTHERS THEN
+        IF SQLCODE NOT IN (-904, -942, -955, -1408, -1418, -1430, -1442, -1451, -2260, -2261, -2264, -2275, -2289, -2443, -23292) THEN
+            RAISE;
+        END IF;
+    END;
+BEGIN
+    exec_ddl('ALTER TABLE person MODIFY (is_active DEFAULT NULL NULL)');
+    exec_ddl('ALTER TABLE person MODIFY (salary_dollars DECIMAL(5,2))');
+    exec_ddl('ALTER TABLE person MODIFY (weight_kilos NUMBER(5,2))');
+    exec_ddl('ALTER TABLE person MODIFY (height_meters NUMBER(3,1))');
+    exec_ddl('ALTER TABLE person MODIFY (notes VARCHAR2(255))');
+    exec_ddl('ALTER TABLE person MODIFY (bio VARCHAR2(1000) DEFAULT NULL)');
+    exec_ddl('ALTER TABLE person MODIFY (password DEFAULT ''password'')');
+    exec_ddl('ALTER TABLE person MODIFY (email NULL)');
+END;
@@ -0,0 +1,4 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE country MODIFY (country_id GENERATED ALWAYS AS IDENTITY)';
+    EXECUTE IMMEDIATE 'ALTER TABLE country MODIFY (country VARCHAR2(100))';
+END;
@@ -0,0 +1,9 @@
+BEGIN
+    EXECUTE IMMEDIATE 'CREATE TABLE inventory (
+    inventory_id NUMBER(19) NOT NULL
+    ,stock NUMBER(19) NOT NULL
+
+    ,CONSTRAINT inventory_inventory_id_pkey PRIMARY KEY (inventory_id)
+    ,CONSTRAINT inventory_stock_check CHECK (stock >= 0)
+)';
+END;
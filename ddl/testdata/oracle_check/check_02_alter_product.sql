@@ -0,0 +1,6 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE product DROP CONSTRAINT product_price_positive';
+    EXECUTE IMMEDIATE 'ALTER TABLE product DROP CONSTRAINT product_quantity_check';
+    EXECUTE IMMEDIATE 'ALTER TABLE product ADD CONSTRAINT product_discount_below_price CHECK (discount < price)';
+    EXECUTE IMMEDIATE 'ALTER TABLE product ADD CONSTRAINT product_price_check CHECK (price > 0)';
+END;
@@ -0,0 +1,15 @@
+DECLARE
+    PROCEDURE exec_ddl(stmt VARCHAR2) IS
+    BEGIN
+        EXECUTE IMMEDIATE stmt;
+    EXCEPTION WHEN OTHERS THEN
+        IF SQLCODE NOT IN (-904, -942, -955, -1408, -1418, -1430, -1442, -1451, -2260, -2261, -2264, -2275, -2289, -2443, -23292) THEN
+            RAISE;
+        END IF;
+    END;
+BEGIN
+    exec_ddl('ALTER TABLE product DROP CONSTRAINT product_price_check');
+    exec_ddl('ALTER TABLE product DROP CONSTRAINT product_discount_below_price');
+    exec_ddl('ALTER TABLE product ADD CONSTRAINT product_price_positive CHECK (price > 0)');
+    exec_ddl('ALTER TABLE product ADD CONSTRAINT product_quantity_check CHECK (quantity >= 0)');
+END;
@@ -0,0 +1,10 @@
+BEGIN
+    EXECUTE IMMEDIATE 'CREATE TABLE store (
+    store_id NUMBER(19) NOT NULL
+    ,address VARCHAR2(255)
+
+    ,CONSTRAINT store_store_id_pkey PRIMARY KEY (store_id)
+)';
+    EXECUTE IMMEDIATE 'COMMENT ON TABLE store IS ''Physical store locations''';
+    EXECUTE IMMEDIATE 'COMMENT ON COLUMN store.address IS ''Street address''';
+END;
@@ -0,0 +1,7 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE customer ADD (phone VARCHAR2(255))';
+    EXECUTE IMMEDIATE 'COMMENT ON TABLE customer IS ''Stores the customer''s details''';
+    EXECUTE IMMEDIATE 'COMMENT ON COLUMN customer.customer_id IS ''Surrogate key''';
+    EXECUTE IMMEDIATE 'COMMENT ON COLUMN customer.notes IS ''''';
+    EXECUTE IMMEDIATE 'COMMENT ON COLUMN customer.phone IS ''Contact phone number''';
+END;
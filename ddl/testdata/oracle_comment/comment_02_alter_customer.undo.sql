@@ -0,0 +1,15 @@
+DECLARE
+    PROCEDURE exec_ddl(stmt VARCHAR2) IS
+    BEGIN
+        EXECUTE IMMEDIATE stmt;
+    EXCEPTION WHEN OTHERS THEN
+        IF SQLCODE NOT IN (-904, -942, -955, -1408, -1418, -1430, -1442, -1451, -2260, -2261, -2264, -2275, -2289, -2443, -23292) THEN
+            RAISE;
+        END IF;
+    END;
+BEGIN
+    exec_ddl('COMMENT ON COLUMN customer.notes IS ''Free-form notes''');
+    exec_ddl('COMMENT ON COLUMN customer.customer_id IS ''''');
+    exec_ddl('COMMENT ON TABLE customer IS ''Stores customer details''');
+    exec_ddl('ALTER TABLE customer DROP COLUMN phone');
+END;
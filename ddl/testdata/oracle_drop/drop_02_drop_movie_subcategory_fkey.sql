@@ -0,0 +1,3 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE movie DROP CONSTRAINT movie_subcategory_fkey';
+END;
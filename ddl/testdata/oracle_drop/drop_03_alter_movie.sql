@@ -0,0 +1,8 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE movie DROP CONSTRAINT movie_movie_id_pkey';
+    EXECUTE IMMEDIATE 'ALTER TABLE movie DROP CONSTRAINT movie_title_key';
+    EXECUTE IMMEDIATE 'DROP INDEX movie_category_idx';
+    EXECUTE IMMEDIATE 'DROP INDEX movie_subcategory_idx';
+    EXECUTE IMMEDIATE 'ALTER TABLE movie DROP COLUMN metadata';
+    EXECUTE IMMEDIATE 'ALTER TABLE movie MODIFY (movie_id NULL)';
+END;
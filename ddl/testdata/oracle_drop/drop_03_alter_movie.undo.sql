@@ -0,0 +1,17 @@
+DECLARE
+    PROCEDURE exec_ddl(stmt VARCHAR2) IS
+    BEGIN
+        EXECUTE IMMEDIATE stmt;
+    EXCEPTION WHEN OTHERS THEN
+        IF SQLCODE NOT IN (-904, -942, -955, -1408, -1418, -1430, -1442, -1451, -2260, -2261, -2264, -2275, -2289, -2443, -23292) THEN
+            RAISE;
+        END IF;
+    END;
+BEGIN
+    exec_ddl('ALTER TABLE movie MODIFY (movie_id NOT NULL)');
+    exec_ddl('ALTER TABLE movie ADD (metadata CLOB)');
+    exec_ddl('CREATE INDEX movie_category_idx ON movie (category)');
+    exec_ddl('CREATE INDEX movie_subcategory_idx ON movie (subcategory)');
+    exec_ddl('ALTER TABLE movie ADD CONSTRAINT movie_movie_id_pkey PRIMARY KEY (movie_id)');
+    exec_ddl('ALTER TABLE movie ADD CONSTRAINT movie_title_key UNIQUE (title)');
+END;
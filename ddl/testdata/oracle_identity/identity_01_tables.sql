@@ -0,0 +1,7 @@
+BEGIN
+    EXECUTE IMMEDIATE 'CREATE TABLE invoice (
+    invoice_id NUMBER(19) GENERATED BY DEFAULT AS IDENTITY (START WITH 5000 INCREMENT BY 1) NOT NULL
+
+    ,CONSTRAINT invoice_invoice_id_pkey PRIMARY KEY (invoice_id)
+)';
+END;
@@ -0,0 +1,4 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE orders MODIFY (order_id GENERATED BY DEFAULT AS IDENTITY (START WITH 1000 INCREMENT BY 1))';
+    EXECUTE IMMEDIATE 'ALTER TABLE orders MODIFY (order_number GENERATED ALWAYS AS IDENTITY (START WITH 100 INCREMENT BY 10))';
+END;
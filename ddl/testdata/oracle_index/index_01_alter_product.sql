@@ -0,0 +1,7 @@
+BEGIN
+    EXECUTE IMMEDIATE 'DROP INDEX product_lookup_idx';
+    EXECUTE IMMEDIATE 'DROP INDEX product_price_idx';
+    EXECUTE IMMEDIATE 'CREATE INDEX product_lookup_idx ON product (name, sku DESC)';
+    EXECUTE IMMEDIATE 'CREATE UNIQUE INDEX product_sku_idx ON product (sku)';
+    EXECUTE IMMEDIATE 'CREATE INDEX product_price_idx ON product (price DESC)';
+END;
@@ -0,0 +1,16 @@
+DECLARE
+    PROCEDURE exec_ddl(stmt VARCHAR2) IS
+    BEGIN
+        EXECUTE IMMEDIATE stmt;
+    EXCEPTION WHEN OTHERS THEN
+        IF SQLCODE NOT IN (-904, -942, -955, -1408, -1418, -1430, -1442, -1451, -2260, -2261, -2264, -2275, -2289, -2443, -23292) THEN
+            RAISE;
+        END IF;
+    END;
+BEGIN
+    exec_ddl('DROP INDEX product_price_idx');
+    exec_ddl('DROP INDEX product_sku_idx');
+    exec_ddl('DROP INDEX product_lookup_idx');
+    exec_ddl('CREATE INDEX product_lookup_idx ON product (name, sku)');
+    exec_ddl('CREATE INDEX product_price_idx ON product (price)');
+END;
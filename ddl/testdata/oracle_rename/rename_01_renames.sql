@@ -0,0 +1,12 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE actor RENAME TO performer';
+    EXECUTE IMMEDIATE 'ALTER TABLE performer RENAME COLUMN actor_id TO performer_id';
+    EXECUTE IMMEDIATE 'ALTER TABLE performer RENAME COLUMN first_name TO given_name';
+    EXECUTE IMMEDIATE 'ALTER TABLE film RENAME COLUMN title TO name';
+    EXECUTE IMMEDIATE 'ALTER TABLE film_actor RENAME COLUMN actor_id TO performer_id';
+    EXECUTE IMMEDIATE 'ALTER INDEX actor_first_name_idx RENAME TO performer_given_name_idx';
+    EXECUTE IMMEDIATE 'ALTER TABLE performer RENAME CONSTRAINT actor_actor_id_pkey TO performer_performer_id_pkey';
+    EXECUTE IMMEDIATE 'ALTER TABLE film RENAME CONSTRAINT film_title_key TO film_name_key';
+    EXECUTE IMMEDIATE 'ALTER INDEX film_actor_actor_id_idx RENAME TO film_actor_performer_id_idx';
+    EXECUTE IMMEDIATE 'ALTER TABLE film_actor RENAME CONSTRAINT film_actor_actor_id_fkey TO film_actor_performer_id_fkey';
+END;
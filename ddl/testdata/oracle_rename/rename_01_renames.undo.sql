@@ -0,0 +1,21 @@
+DECLARE
+    PROCEDURE exec_ddl(stmt VARCHAR2) IS
+    BEGIN
+        EXECUTE IMMEDIATE stmt;
+    EXCEPTION WHEN OTHERS THEN
+        IF SQLCODE NOT IN (-904, -942, -955, -1408, -1418, -1430, -1442, -1451, -2260, -2261, -2264, -2275, -2289, -2443, -23292) THEN
+            RAISE;
+        END IF;
+    END;
+BEGIN
+    exec_ddl('ALTER TABLE film_actor RENAME CONSTRAINT film_actor_performer_id_fkey TO film_actor_actor_id_fkey');
+    exec_ddl('ALTER INDEX film_actor_performer_id_idx RENAME TO film_actor_actor_id_idx');
+    exec_ddl('ALTER TABLE film RENAME CONSTRAINT film_name_key TO film_title_key');
+    exec_ddl('ALTER TABLE performer RENAME CONSTRAINT performer_performer_id_pkey TO actor_actor_id_pkey');
+    exec_ddl('ALTER INDEX performer_given_name_idx RENAME TO actor_first_name_idx');
+    exec_ddl('ALTER TABLE film_actor RENAME COLUMN performer_id TO actor_id');
+    exec_ddl('ALTER TABLE film RENAME COLUMN name TO title');
+    exec_ddl('ALTER TABLE performer RENAME COLUMN given_name TO first_name');
+    exec_ddl('ALTER TABLE performer RENAME COLUMN performer_id TO actor_id');
+    exec_ddl('ALTER TABLE performer RENAME TO actor');
+END;
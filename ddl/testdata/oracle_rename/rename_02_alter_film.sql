@@ -0,0 +1,4 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE film DROP COLUMN description';
+    EXECUTE IMMEDIATE 'ALTER TABLE film ADD (synopsis VARCHAR2(255))';
+END;
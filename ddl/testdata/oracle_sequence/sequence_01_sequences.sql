@@ -0,0 +1,4 @@
+BEGIN
+    EXECUTE IMMEDIATE 'CREATE SEQUENCE ticket_number_seq START WITH 1';
+    EXECUTE IMMEDIATE 'ALTER SEQUENCE invoice_number_seq INCREMENT BY 10 MAXVALUE 999999 CYCLE';
+END;
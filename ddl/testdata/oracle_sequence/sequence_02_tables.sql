@@ -0,0 +1,8 @@
+BEGIN
+    EXECUTE IMMEDIATE 'CREATE TABLE ticket (
+    ticket_id NUMBER(19) GENERATED BY DEFAULT AS IDENTITY NOT NULL
+    ,ticket_number NUMBER(19) DEFAULT (ticket_number_seq.NEXTVAL) NOT NULL
+
+    ,CONSTRAINT ticket_ticket_id_pkey PRIMARY KEY (ticket_id)
+)';
+END;
@@ -0,0 +1,3 @@
+BEGIN
+    EXECUTE IMMEDIATE 'DROP SEQUENCE legacy_seq';
+END;
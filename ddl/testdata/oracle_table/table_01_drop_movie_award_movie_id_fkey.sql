@@ -0,0 +1,3 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE movie_award DROP CONSTRAINT movie_award_movie_id_fkey';
+END;
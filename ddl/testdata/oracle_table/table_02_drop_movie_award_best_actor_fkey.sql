@@ -0,0 +1,3 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE movie_award DROP CONSTRAINT movie_award_best_actor_fkey';
+END;
@@ -0,0 +1,26 @@
+BEGIN
+    EXECUTE IMMEDIATE 'DROP TABLE actor';
+    EXECUTE IMMEDIATE 'DROP TABLE movie';
+    EXECUTE IMMEDIATE 'DROP TABLE movie_award';
+    EXECUTE IMMEDIATE 'CREATE TABLE actors (
+    actor_id NUMBER(19) GENERATED BY DEFAULT AS IDENTITY NOT NULL
+    ,name VARCHAR2(255)
+
+    ,CONSTRAINT actors_actor_id_pkey PRIMARY KEY (actor_id)
+)';
+    EXECUTE IMMEDIATE 'CREATE TABLE movies (
+    movie_id NUMBER(19) GENERATED BY DEFAULT AS IDENTITY NOT NULL
+    ,title VARCHAR2(255)
+    ,synopsis VARCHAR2(255)
+
+    ,CONSTRAINT movies_movie_id_pkey PRIMARY KEY (movie_id)
+)';
+    EXECUTE IMMEDIATE 'CREATE INDEX movies_title_idx ON movies (title)';
+    EXECUTE IMMEDIATE 'CREATE TABLE movie_awards (
+    movie_id NUMBER(19)
+    ,best_actor NUMBER(19)
+    ,best_supporting_actor NUMBER(19)
+    ,best_actress NUMBER(19)
+    ,best_supporting_actress NUMBER(19)
+)';
+END;
@@ -0,0 +1,35 @@
+DECLARE
+    PROCEDURE exec_ddl(stmt VARCHAR2) IS
+    BEGIN
+        EXECUTE IMMEDIATE stmt;
+    EXCEPTION WHEN OTHERS THEN
+        IF SQLCODE NOT IN (-904, -942, -955, -1408, -1418, -1430, -1442, -1451, -2260, -2261, -2264, -2275, -2289, -2443, -23292) THEN
+            RAISE;
+        END IF;
+    END;
+BEGIN
+    exec_ddl('DROP TABLE movie_awards');
+    exec_ddl('DROP TABLE movies');
+    exec_ddl('DROP TABLE actors');
+    exec_ddl('CREATE TABLE actor (
+    actor_id NUMBER(19) NOT NULL
+    ,name VARCHAR2(255)
+
+    ,CONSTRAINT actor_actor_id_pkey PRIMARY KEY (actor_id)
+)');
+    exec_ddl('CREATE TABLE movie (
+    movie_id NUMBER(19) NOT NULL
+    ,title VARCHAR2(255)
+    ,synopsis VARCHAR2(255)
+
+    ,CONSTRAINT movie_movie_id_pkey PRIMARY KEY (movie_id)
+)');
+    exec_ddl('CREATE INDEX movie_title_idx ON movie (title)');
+    exec_ddl('CREATE TABLE movie_award (
+    movie_id NUMBER(19)
+    ,best_actor NUMBER(19)
+    ,best_supporting_actor NUMBER(19)
+    ,best_actress NUMBER(19)
+    ,best_supporting_actress NUMBER(19)
+)');
+END;
@@ -0,0 +1,3 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE movie_awards ADD CONSTRAINT movie_awards_best_actor_fkey FOREIGN KEY (best_actor) REFERENCES actors (actor_id)';
+END;
@@ -0,0 +1,3 @@
+BEGIN
+    EXECUTE IMMEDIATE 'ALTER TABLE movie_awards ADD CONSTRAINT movie_awards_best_supporting_actress_fkey FOREIGN KEY (best_supporting_actress) REFERENCES actors (actor_id)';
+END;
@@ -0,0 +1,7 @@
+ALTER TABLE product DROP CONSTRAINT IF EXISTS product_price_positive;
+
+ALTER TABLE product DROP CONSTRAINT IF EXISTS product_quantity_check;
+
+ALTER TABLE product ADD CONSTRAINT product_discount_below_price CHECK (discount < price) NOT VALID;
+
+ALTER TABLE product ADD CONSTRAINT product_price_check CHECK (price > 0) NOT VALID;
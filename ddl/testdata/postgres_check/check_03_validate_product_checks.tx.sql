@@ -0,0 +1,3 @@
+ALTER TABLE product VALIDATE CONSTRAINT product_discount_below_price;
+
+ALTER TABLE product VALIDATE CONSTRAINT product_price_check;
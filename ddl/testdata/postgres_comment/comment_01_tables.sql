@@ -0,0 +1,10 @@
+CREATE TABLE store (
+    store_id INT NOT NULL
+    ,address TEXT
+
+    ,CONSTRAINT store_store_id_pkey PRIMARY KEY (store_id)
+);
+
+COMMENT ON TABLE store IS 'Physical store locations';
+
+COMMENT ON COLUMN store.address IS 'Street address';
@@ -0,0 +1,9 @@
+ALTER TABLE customer ADD COLUMN phone TEXT;
+
+COMMENT ON TABLE customer IS 'Stores the customer''s details';
+
+COMMENT ON COLUMN customer.customer_id IS 'Surrogate key';
+
+COMMENT ON COLUMN customer.notes IS NULL;
+
+COMMENT ON COLUMN customer.phone IS 'Contact phone number';
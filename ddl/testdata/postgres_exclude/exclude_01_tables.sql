@@ -0,0 +1,8 @@
+CREATE TABLE maintenance (
+    maintenance_id INT NOT NULL
+    ,room_id INT NOT NULL
+    ,during TSTZRANGE NOT NULL
+
+    ,CONSTRAINT maintenance_room_id_during_excl EXCLUDE USING gist (room_id WITH =, during WITH &&) DEFERRABLE
+    ,CONSTRAINT maintenance_maintenance_id_pkey PRIMARY KEY (maintenance_id)
+);
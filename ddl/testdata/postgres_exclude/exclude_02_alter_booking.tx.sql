@@ -0,0 +1,3 @@
+ALTER TABLE booking DROP CONSTRAINT IF EXISTS booking_room_id_during_excl;
+
+ALTER TABLE booking ADD CONSTRAINT booking_room_id_during_excl EXCLUDE USING gist (room_id WITH =, during WITH &&) WHERE (NOT is_cancelled);
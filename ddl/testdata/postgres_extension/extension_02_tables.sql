@@ -0,0 +1,6 @@
+CREATE TABLE actor (
+    actor_id INT NOT NULL
+    ,name CITEXT
+
+    ,CONSTRAINT actor_actor_id_pkey PRIMARY KEY (actor_id)
+);
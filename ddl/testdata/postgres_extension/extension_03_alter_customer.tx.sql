@@ -0,0 +1,5 @@
+ALTER TABLE customer DROP COLUMN IF EXISTS attributes;
+
+ALTER TABLE customer ADD COLUMN email CITEXT;
+
+ALTER TABLE customer ADD COLUMN token UUID DEFAULT uuid_generate_v4();
@@ -0,0 +1,5 @@
+CREATE TABLE invoice (
+    invoice_id INT NOT NULL GENERATED BY DEFAULT AS IDENTITY (START WITH 5000 INCREMENT BY 1)
+
+    ,CONSTRAINT invoice_invoice_id_pkey PRIMARY KEY (invoice_id)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE orders ALTER COLUMN order_id SET START WITH 1000 RESTART;
+
+ALTER TABLE orders ALTER COLUMN order_number SET INCREMENT BY 10;
+
+ALTER TABLE orders ALTER COLUMN customer_id ADD GENERATED BY DEFAULT AS IDENTITY (START WITH 1 INCREMENT BY 2);
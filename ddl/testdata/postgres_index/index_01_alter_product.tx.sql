@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS product_lookup_idx;
+
+DROP INDEX IF EXISTS product_name_idx;
+
+DROP INDEX IF EXISTS product_price_idx;
@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY product_lookup_idx ON product (name, sku DESC) INCLUDE (price);
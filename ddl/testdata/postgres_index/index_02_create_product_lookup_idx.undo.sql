@@ -0,0 +1 @@
+DROP INDEX IF EXISTS product_lookup_idx;
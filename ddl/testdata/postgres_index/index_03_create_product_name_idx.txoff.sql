@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY product_name_idx ON product (name text_pattern_ops);
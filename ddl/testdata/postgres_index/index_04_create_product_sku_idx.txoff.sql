@@ -0,0 +1 @@
+CREATE UNIQUE INDEX CONCURRENTLY product_sku_idx ON product (sku) WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY product_price_idx ON product (price DESC);
@@ -0,0 +1,6 @@
+CREATE UNLOGGED TABLE cache (
+    cache_key TEXT NOT NULL
+    ,cache_value BYTEA
+
+    ,CONSTRAINT cache_cache_key_pkey PRIMARY KEY (cache_key)
+) WITH (fillfactor=50);
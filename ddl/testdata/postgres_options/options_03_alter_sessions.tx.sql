@@ -0,0 +1,5 @@
+ALTER TABLE sessions SET UNLOGGED;
+
+ALTER TABLE sessions RESET (autovacuum_enabled);
+
+ALTER TABLE sessions SET (fillfactor=90);
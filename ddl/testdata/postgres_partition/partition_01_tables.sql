@@ -0,0 +1,8 @@
+CREATE TABLE orders (
+    order_id INT NOT NULL
+    ,region TEXT NOT NULL
+) PARTITION BY LIST (region);
+
+CREATE TABLE orders_apac PARTITION OF orders FOR VALUES IN ('sg', 'jp');
+
+CREATE TABLE orders_emea PARTITION OF orders FOR VALUES IN ('uk', 'de');
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS events_2025;
+
+CREATE TABLE events_2027 (LIKE events INCLUDING ALL);
+
+ALTER TABLE events ATTACH PARTITION events_2027 FOR VALUES FROM ('2027-01-01') TO ('2028-01-01');
+
+ALTER TABLE events DETACH PARTITION events_2026;
+
+ALTER TABLE events ATTACH PARTITION events_2026 FOR VALUES FROM ('2026-01-01') TO ('2026-07-01');
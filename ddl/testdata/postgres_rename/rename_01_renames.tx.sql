@@ -0,0 +1,19 @@
+ALTER TABLE actor RENAME TO performer;
+
+ALTER TABLE performer RENAME COLUMN actor_id TO performer_id;
+
+ALTER TABLE performer RENAME COLUMN first_name TO given_name;
+
+ALTER TABLE film RENAME COLUMN title TO name;
+
+ALTER TABLE film_actor RENAME COLUMN actor_id TO performer_id;
+
+ALTER INDEX actor_first_name_idx RENAME TO performer_given_name_idx;
+
+ALTER TABLE performer RENAME CONSTRAINT actor_actor_id_pkey TO performer_performer_id_pkey;
+
+ALTER TABLE film RENAME CONSTRAINT film_title_key TO film_name_key;
+
+ALTER INDEX film_actor_actor_id_idx RENAME TO film_actor_performer_id_idx;
+
+ALTER TABLE film_actor RENAME CONSTRAINT film_actor_actor_id_fkey TO film_actor_performer_id_fkey;
@@ -0,0 +1,3 @@
+ALTER TABLE film DROP COLUMN IF EXISTS description;
+
+ALTER TABLE film ADD COLUMN synopsis TEXT;
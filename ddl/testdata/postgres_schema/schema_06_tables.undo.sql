@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS bar.movie_award;
+
+DROP TABLE IF EXISTS bar.movie_actor;
+
+DROP TABLE IF EXISTS bar.movie;
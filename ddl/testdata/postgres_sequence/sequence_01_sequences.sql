@@ -0,0 +1,3 @@
+CREATE SEQUENCE ticket_number_seq AS INTEGER START WITH 1;
+CREATE SEQUENCE shared_seq;
+ALTER SEQUENCE invoice_number_seq INCREMENT BY 10 MAXVALUE 999999 CYCLE;
@@ -0,0 +1,3 @@
+ALTER SEQUENCE invoice_number_seq NO CYCLE;
+DROP SEQUENCE IF EXISTS shared_seq;
+DROP SEQUENCE IF EXISTS ticket_number_seq;
@@ -0,0 +1,2 @@
+ALTER SEQUENCE invoice_number_seq OWNED BY invoice.invoice_number;
+ALTER SEQUENCE ticket_number_seq OWNED BY ticket.ticket_number;
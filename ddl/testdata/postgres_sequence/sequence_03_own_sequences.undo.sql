@@ -0,0 +1,2 @@
+ALTER SEQUENCE ticket_number_seq OWNED BY NONE;
+ALTER SEQUENCE invoice_number_seq OWNED BY NONE;
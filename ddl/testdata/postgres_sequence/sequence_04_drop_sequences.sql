@@ -0,0 +1 @@
+DROP SEQUENCE IF EXISTS legacy_seq;
@@ -0,0 +1 @@
+CREATE SEQUENCE IF NOT EXISTS legacy_seq;
@@ -0,0 +1,30 @@
+DROP TABLE IF EXISTS movie_awards;
+
+DROP TABLE IF EXISTS movies;
+
+DROP TABLE IF EXISTS actors;
+
+CREATE TABLE IF NOT EXISTS actor (
+    actor_id INT NOT NULL
+    ,name TEXT
+
+    ,CONSTRAINT actor_actor_id_pkey PRIMARY KEY (actor_id)
+);
+
+CREATE TABLE IF NOT EXISTS movie (
+    movie_id INT NOT NULL
+    ,title TEXT
+    ,synopsis TEXT
+
+    ,CONSTRAINT movie_movie_id_pkey PRIMARY KEY (movie_id)
+);
+
+CREATE INDEX IF NOT EXISTS movie_title_idx ON movie (title);
+
+CREATE TABLE IF NOT EXISTS movie_award (
+    movie_id INT
+    ,best_actor INT
+    ,best_supporting_actor INT
+    ,best_actress INT
+    ,best_supporting_actress INT
+);
@@ -0,0 +1,3 @@
+ALTER TABLE orders ALTER COLUMN order_id TYPE BIGINT;
+
+ALTER TABLE orders ALTER COLUMN reference TYPE VARCHAR(50);
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN amount_new NUMERIC(12,2);
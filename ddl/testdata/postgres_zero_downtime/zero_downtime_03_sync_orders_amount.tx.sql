@@ -0,0 +1,10 @@
+CREATE OR REPLACE FUNCTION orders_amount_sync() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.amount_new := COALESCE(CAST(NEW.amount AS NUMERIC(12,2)), '0');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER orders_amount_sync BEFORE INSERT OR UPDATE ON orders FOR EACH ROW EXECUTE PROCEDURE orders_amount_sync();
+
+ALTER TABLE orders ADD CONSTRAINT orders_amount_new_not_null_check CHECK (amount_new IS NOT NULL) NOT VALID;
@@ -0,0 +1,13 @@
+DO $$
+DECLARE
+    updated_rows BIGINT;
+BEGIN
+    LOOP
+        UPDATE orders SET amount_new = COALESCE(CAST(amount AS NUMERIC(12,2)), '0')
+        WHERE ctid = ANY(ARRAY(SELECT ctid FROM orders WHERE amount_new IS NULL LIMIT 1000));
+        GET DIAGNOSTICS updated_rows = ROW_COUNT;
+        EXIT WHEN updated_rows = 0;
+        COMMIT;
+    END LOOP;
+END
+$$;
@@ -0,0 +1 @@
+ALTER TABLE orders VALIDATE CONSTRAINT orders_amount_new_not_null_check;
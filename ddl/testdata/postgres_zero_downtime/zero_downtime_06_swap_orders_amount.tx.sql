@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS orders_amount_sync ON orders;
+
+DROP FUNCTION IF EXISTS orders_amount_sync();
+
+ALTER TABLE orders ALTER COLUMN amount_new SET NOT NULL;
+
+ALTER TABLE orders DROP CONSTRAINT orders_amount_new_not_null_check;
+
+ALTER TABLE orders RENAME COLUMN amount TO amount_old;
+
+ALTER TABLE orders RENAME COLUMN amount_new TO amount;
+
+ALTER TABLE orders ALTER COLUMN amount SET DEFAULT '0';
@@ -0,0 +1,10 @@
+CREATE OR REPLACE FUNCTION orders_quantity_sync() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.quantity_new := CAST(NEW.quantity AS BIGINT);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER orders_quantity_sync BEFORE INSERT OR UPDATE ON orders FOR EACH ROW EXECUTE PROCEDURE orders_quantity_sync();
+
+ALTER TABLE orders ADD CONSTRAINT orders_quantity_new_not_null_check CHECK (quantity_new IS NOT NULL) NOT VALID;
@@ -0,0 +1,13 @@
+DO $$
+DECLARE
+    updated_rows BIGINT;
+BEGIN
+    LOOP
+        UPDATE orders SET quantity_new = CAST(quantity AS BIGINT)
+        WHERE ctid = ANY(ARRAY(SELECT ctid FROM orders WHERE quantity_new IS NULL AND quantity IS NOT NULL LIMIT 1000));
+        GET DIAGNOSTICS updated_rows = ROW_COUNT;
+        EXIT WHEN updated_rows = 0;
+        COMMIT;
+    END LOOP;
+END
+$$;
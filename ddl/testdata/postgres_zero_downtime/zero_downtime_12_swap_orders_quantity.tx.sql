@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS orders_quantity_sync ON orders;
+
+DROP FUNCTION IF EXISTS orders_quantity_sync();
+
+ALTER TABLE orders ALTER COLUMN quantity_new SET NOT NULL;
+
+ALTER TABLE orders DROP CONSTRAINT orders_quantity_new_not_null_check;
+
+ALTER TABLE orders ALTER COLUMN quantity DROP NOT NULL;
+
+ALTER TABLE orders RENAME COLUMN quantity TO quantity_old;
+
+ALTER TABLE orders RENAME COLUMN quantity_new TO quantity;
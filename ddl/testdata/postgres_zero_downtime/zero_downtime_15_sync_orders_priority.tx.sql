@@ -0,0 +1,8 @@
+CREATE OR REPLACE FUNCTION orders_priority_sync() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.priority_new := CAST(NEW.priority AS BIGINT);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER orders_priority_sync BEFORE INSERT OR UPDATE ON orders FOR EACH ROW EXECUTE PROCEDURE orders_priority_sync();
@@ -0,0 +1,13 @@
+DO $$
+DECLARE
+    updated_rows BIGINT;
+BEGIN
+    LOOP
+        UPDATE orders SET priority_new = CAST(priority AS BIGINT)
+        WHERE ctid = ANY(ARRAY(SELECT ctid FROM orders WHERE priority_new IS NULL AND priority IS NOT NULL LIMIT 1000));
+        GET DIAGNOSTICS updated_rows = ROW_COUNT;
+        EXIT WHEN updated_rows = 0;
+        COMMIT;
+    END LOOP;
+END
+$$;
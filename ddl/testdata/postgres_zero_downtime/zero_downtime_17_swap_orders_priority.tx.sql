@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS orders_priority_sync ON orders;
+
+DROP FUNCTION IF EXISTS orders_priority_sync();
+
+ALTER TABLE orders ALTER COLUMN priority_new SET NOT NULL;
+
+ALTER TABLE orders RENAME COLUMN priority TO priority_old;
+
+ALTER TABLE orders RENAME COLUMN priority_new TO priority;
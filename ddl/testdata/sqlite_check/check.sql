@@ -0,0 +1,29 @@
+PRAGMA legacy_alter_table = ON;
+
+CREATE TABLE inventory (
+    inventory_id INTEGER PRIMARY KEY
+    ,stock INT NOT NULL
+
+    ,CONSTRAINT inventory_stock_check CHECK (stock >= 0)
+);
+
+CREATE TABLE product_new (
+    product_id INTEGER PRIMARY KEY
+    ,price NUMERIC(10,2)
+    ,discount NUMERIC(10,2)
+    ,quantity INT
+
+    ,CONSTRAINT product_discount_below_price CHECK (discount < price)
+    ,CONSTRAINT product_price_check CHECK (price > 0)
+);
+INSERT INTO product_new
+    (product_id, price, discount, quantity)
+SELECT
+    product_id, price, discount, quantity
+FROM
+    product
+;
+DROP TABLE product;
+ALTER TABLE product_new RENAME TO product;
+
+PRAGMA legacy_alter_table = OFF;
@@ -0,0 +1,24 @@
+PRAGMA legacy_alter_table = ON;
+
+CREATE TABLE product_new (
+    product_id INTEGER PRIMARY KEY
+    ,price NUMERIC(10,2)
+    ,discount NUMERIC(10,2)
+    ,quantity INT
+
+    ,CONSTRAINT product_price_positive CHECK (price > 0)
+    ,CONSTRAINT product_quantity_check CHECK (quantity >= 0)
+);
+INSERT INTO product_new
+    (product_id, price, discount, quantity)
+SELECT
+    product_id, price, discount, quantity
+FROM
+    product
+;
+DROP TABLE product;
+ALTER TABLE product_new RENAME TO product;
+
+DROP TABLE IF EXISTS inventory;
+
+PRAGMA legacy_alter_table = OFF;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS actor (
+    actor_id INTEGER PRIMARY KEY AUTOINCREMENT
+    ,first_name TEXT NOT NULL
+    ,last_name TEXT NOT NULL
+    ,last_update DATETIME NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX IF NOT EXISTS actor_last_name_idx ON actor (last_name);
+
+CREATE TABLE IF NOT EXISTS category (
+    category_id INTEGER PRIMARY KEY
+    ,name TEXT NOT NULL
+    ,last_update DATETIME NOT NULL DEFAULT (unixepoch())
+);
@@ -0,0 +1,9 @@
+DROP INDEX product_lookup_idx;
+
+DROP INDEX product_price_idx;
+
+CREATE INDEX product_lookup_idx ON product (name, sku DESC);
+
+CREATE UNIQUE INDEX product_sku_idx ON product (sku) WHERE deleted_at IS NULL;
+
+CREATE INDEX product_price_idx ON product (price DESC);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS product_price_idx;
+
+DROP INDEX IF EXISTS product_sku_idx;
+
+DROP INDEX IF EXISTS product_lookup_idx;
+
+CREATE INDEX IF NOT EXISTS product_lookup_idx ON product (name, sku);
+
+CREATE INDEX IF NOT EXISTS product_price_idx ON product (price);
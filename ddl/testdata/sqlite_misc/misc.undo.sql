@@ -0,0 +1,44 @@
+PRAGMA legacy_alter_table = ON;
+
+DROP INDEX IF EXISTS post_author_id_idx;
+
+ALTER TABLE post DROP COLUMN author_id;
+
+ALTER TABLE post DROP COLUMN tags;
+
+ALTER TABLE post ADD COLUMN metadata TEXT;
+
+CREATE INDEX IF NOT EXISTS post_metadata_idx ON post (metadata);
+
+CREATE TABLE author_new (
+    author_id INTEGER PRIMARY KEY
+    ,name TEXT
+    ,email TEXT
+    ,metadata TEXT
+);
+INSERT INTO author_new
+    (author_id, name, email)
+SELECT
+    author_id, name, email
+FROM
+    author
+;
+DROP TABLE author;
+ALTER TABLE author_new RENAME TO author;
+
+CREATE INDEX author_metadata_idx ON author (metadata);
+
+DROP TABLE IF EXISTS address;
+
+DROP TABLE IF EXISTS city;
+
+DROP TABLE IF EXISTS country;
+
+CREATE TABLE IF NOT EXISTS residence (
+    residence_id INTEGER PRIMARY KEY
+    ,country TEXT
+    ,city TEXT
+    ,address TEXT
+);
+
+PRAGMA legacy_alter_table = OFF;
@@ -0,0 +1,24 @@
+PRAGMA legacy_alter_table = ON;
+
+CREATE TABLE film_actor (
+    film_id INT NOT NULL
+    ,actor_id INT NOT NULL
+
+    ,CONSTRAINT film_actor_film_id_actor_id_pkey PRIMARY KEY (film_id, actor_id)
+) STRICT, WITHOUT ROWID;
+
+CREATE TABLE actor_new (
+    actor_id INTEGER PRIMARY KEY
+    ,name TEXT NOT NULL
+) STRICT;
+INSERT INTO actor_new
+    (actor_id, name)
+SELECT
+    actor_id, name
+FROM
+    actor
+;
+DROP TABLE actor;
+ALTER TABLE actor_new RENAME TO actor;
+
+PRAGMA legacy_alter_table = OFF;
@@ -0,0 +1,19 @@
+PRAGMA legacy_alter_table = ON;
+
+CREATE TABLE actor_new (
+    actor_id INTEGER PRIMARY KEY
+    ,name TEXT NOT NULL
+);
+INSERT INTO actor_new
+    (actor_id, name)
+SELECT
+    actor_id, name
+FROM
+    actor
+;
+DROP TABLE actor;
+ALTER TABLE actor_new RENAME TO actor;
+
+DROP TABLE IF EXISTS film_actor;
+
+PRAGMA legacy_alter_table = OFF;
@@ -0,0 +1,21 @@
+ALTER TABLE actor RENAME TO performer;
+
+ALTER TABLE performer RENAME COLUMN actor_id TO performer_id;
+
+ALTER TABLE performer RENAME COLUMN first_name TO given_name;
+
+ALTER TABLE film RENAME COLUMN title TO name;
+
+ALTER TABLE film_actor RENAME COLUMN actor_id TO performer_id;
+
+DROP INDEX actor_first_name_idx;
+
+CREATE INDEX performer_given_name_idx ON performer (given_name);
+
+ALTER TABLE film DROP COLUMN description;
+
+ALTER TABLE film ADD COLUMN synopsis TEXT;
+
+DROP INDEX film_actor_actor_id_idx;
+
+CREATE INDEX film_actor_performer_id_idx ON film_actor (performer_id);
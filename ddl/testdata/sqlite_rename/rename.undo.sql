@@ -0,0 +1,21 @@
+DROP INDEX IF EXISTS film_actor_performer_id_idx;
+
+CREATE INDEX IF NOT EXISTS film_actor_actor_id_idx ON film_actor (performer_id);
+
+ALTER TABLE film DROP COLUMN synopsis;
+
+ALTER TABLE film ADD COLUMN description TEXT;
+
+DROP INDEX IF EXISTS performer_given_name_idx;
+
+CREATE INDEX IF NOT EXISTS actor_first_name_idx ON performer (given_name);
+
+ALTER TABLE film_actor RENAME COLUMN performer_id TO actor_id;
+
+ALTER TABLE film RENAME COLUMN name TO title;
+
+ALTER TABLE performer RENAME COLUMN given_name TO first_name;
+
+ALTER TABLE performer RENAME COLUMN performer_id TO actor_id;
+
+ALTER TABLE performer RENAME TO actor;
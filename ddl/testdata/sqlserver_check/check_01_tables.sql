@@ -0,0 +1,7 @@
+CREATE TABLE inventory (
+    inventory_id INT NOT NULL
+    ,stock INT NOT NULL
+
+    ,CONSTRAINT inventory_inventory_id_pkey PRIMARY KEY (inventory_id)
+    ,CONSTRAINT inventory_stock_check CHECK (stock >= 0)
+);
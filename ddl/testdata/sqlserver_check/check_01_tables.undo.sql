@@ -0,0 +1 @@
+IF OBJECT_ID('inventory', 'U') IS NOT NULL DROP TABLE inventory;
@@ -0,0 +1,3 @@
+ALTER TABLE product DROP CONSTRAINT product_price_positive;
+
+ALTER TABLE product DROP CONSTRAINT product_quantity_check;
@@ -0,0 +1 @@
+ALTER TABLE product WITH NOCHECK ADD CONSTRAINT product_discount_below_price CHECK (discount < price);
@@ -0,0 +1 @@
+ALTER TABLE product WITH CHECK CHECK CONSTRAINT product_discount_below_price;
@@ -0,0 +1 @@
+ALTER TABLE product WITH NOCHECK ADD CONSTRAINT product_price_check CHECK (price > 0);
@@ -0,0 +1 @@
+ALTER TABLE product WITH CHECK CHECK CONSTRAINT product_price_check;
@@ -0,0 +1,10 @@
+CREATE TABLE store (
+    store_id INT NOT NULL
+    ,address NVARCHAR(255)
+
+    ,CONSTRAINT store_store_id_pkey PRIMARY KEY (store_id)
+);
+
+EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'Physical store locations', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'store';
+
+EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'Street address', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'store', @level2type = N'COLUMN', @level2name = N'address';
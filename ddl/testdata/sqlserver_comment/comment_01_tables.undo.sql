@@ -0,0 +1 @@
+IF OBJECT_ID('store', 'U') IS NOT NULL DROP TABLE store;
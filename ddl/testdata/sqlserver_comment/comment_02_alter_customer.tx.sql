@@ -0,0 +1,9 @@
+ALTER TABLE customer ADD phone NVARCHAR(255);
+
+EXEC sp_updateextendedproperty @name = N'MS_Description', @value = N'Stores the customer''s details', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'customer';
+
+EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'Surrogate key', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'customer', @level2type = N'COLUMN', @level2name = N'customer_id';
+
+EXEC sp_dropextendedproperty @name = N'MS_Description', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'customer', @level2type = N'COLUMN', @level2name = N'notes';
+
+EXEC sp_addextendedproperty @name = N'MS_Description', @value = N'Contact phone number', @level0type = N'SCHEMA', @level0name = N'dbo', @level1type = N'TABLE', @level1name = N'customer', @level2type = N'COLUMN', @level2name = N'phone';
@@ -0,0 +1,5 @@
+CREATE TABLE invoice (
+    invoice_id INT NOT NULL IDENTITY(5000, 1)
+
+    ,CONSTRAINT invoice_invoice_id_pkey PRIMARY KEY (invoice_id)
+);
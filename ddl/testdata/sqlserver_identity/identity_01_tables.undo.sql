@@ -0,0 +1 @@
+IF OBJECT_ID('invoice', 'U') IS NOT NULL DROP TABLE invoice;
@@ -0,0 +1,3 @@
+DROP INDEX product_lookup_idx ON product;
+
+DROP INDEX product_price_idx ON product;
@@ -0,0 +1 @@
+CREATE INDEX product_price_idx ON product (price DESC);
@@ -0,0 +1,19 @@
+EXEC sp_rename N'dbo.actor', N'performer';
+
+EXEC sp_rename N'dbo.performer.actor_id', N'performer_id', N'COLUMN';
+
+EXEC sp_rename N'dbo.performer.first_name', N'given_name', N'COLUMN';
+
+EXEC sp_rename N'dbo.film.title', N'name', N'COLUMN';
+
+EXEC sp_rename N'dbo.film_actor.actor_id', N'performer_id', N'COLUMN';
+
+EXEC sp_rename N'dbo.performer.actor_first_name_idx', N'performer_given_name_idx', N'INDEX';
+
+EXEC sp_rename N'dbo.actor_actor_id_pkey', N'performer_performer_id_pkey', N'OBJECT';
+
+EXEC sp_rename N'dbo.film_title_key', N'film_name_key', N'OBJECT';
+
+EXEC sp_rename N'dbo.film_actor.film_actor_actor_id_idx', N'film_actor_performer_id_idx', N'INDEX';
+
+EXEC sp_rename N'dbo.film_actor_actor_id_fkey', N'film_actor_performer_id_fkey', N'OBJECT';
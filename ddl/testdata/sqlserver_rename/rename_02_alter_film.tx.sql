@@ -0,0 +1,3 @@
+ALTER TABLE film DROP COLUMN description;
+
+ALTER TABLE film ADD synopsis NVARCHAR(255);
@@ -0,0 +1 @@
+IF SCHEMA_ID('foo') IS NULL EXEC('CREATE SCHEMA foo');
@@ -0,0 +1 @@
+IF SCHEMA_ID('bar') IS NOT NULL DROP SCHEMA bar;
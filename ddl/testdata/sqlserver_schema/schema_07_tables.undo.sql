@@ -0,0 +1,5 @@
+IF OBJECT_ID('bar.movie_award', 'U') IS NOT NULL DROP TABLE bar.movie_award;
+
+IF OBJECT_ID('bar.movie_actor', 'U') IS NOT NULL DROP TABLE bar.movie_actor;
+
+IF OBJECT_ID('bar.movie', 'U') IS NOT NULL DROP TABLE bar.movie;
@@ -0,0 +1,2 @@
+CREATE SEQUENCE ticket_number_seq AS INT START WITH 1;
+ALTER SEQUENCE invoice_number_seq INCREMENT BY 10 MAXVALUE 999999 CYCLE;
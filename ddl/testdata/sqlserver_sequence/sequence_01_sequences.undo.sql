@@ -0,0 +1,2 @@
+ALTER SEQUENCE invoice_number_seq NO CYCLE;
+IF OBJECT_ID('ticket_number_seq', 'SO') IS NOT NULL DROP SEQUENCE ticket_number_seq;
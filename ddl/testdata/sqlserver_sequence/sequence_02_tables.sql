@@ -0,0 +1,6 @@
+CREATE TABLE ticket (
+    ticket_id INT NOT NULL IDENTITY
+    ,ticket_number INT NOT NULL DEFAULT (NEXT VALUE FOR ticket_number_seq)
+
+    ,CONSTRAINT ticket_ticket_id_pkey PRIMARY KEY (ticket_id)
+);
@@ -0,0 +1 @@
+IF OBJECT_ID('ticket', 'U') IS NOT NULL DROP TABLE ticket;
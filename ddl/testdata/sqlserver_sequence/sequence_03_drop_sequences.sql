@@ -0,0 +1 @@
+DROP SEQUENCE legacy_seq;
@@ -0,0 +1 @@
+IF OBJECT_ID('legacy_seq', 'SO') IS NULL CREATE SEQUENCE legacy_seq;
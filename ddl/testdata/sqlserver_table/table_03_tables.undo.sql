@@ -0,0 +1,39 @@
+IF OBJECT_ID('movie_awards', 'U') IS NOT NULL DROP TABLE movie_awards;
+
+IF OBJECT_ID('movies', 'U') IS NOT NULL DROP TABLE movies;
+
+IF OBJECT_ID('actors', 'U') IS NOT NULL DROP TABLE actors;
+
+IF OBJECT_ID('actor', 'U') IS NULL
+BEGIN
+CREATE TABLE actor (
+    actor_id INT NOT NULL
+    ,name NVARCHAR(255)
+
+    ,CONSTRAINT actor_actor_id_pkey PRIMARY KEY (actor_id)
+);
+END;
+
+IF OBJECT_ID('movie', 'U') IS NULL
+BEGIN
+CREATE TABLE movie (
+    movie_id INT NOT NULL
+    ,title NVARCHAR(255)
+    ,synopsis NVARCHAR(255)
+
+    ,CONSTRAINT movie_movie_id_pkey PRIMARY KEY (movie_id)
+);
+
+CREATE INDEX movie_title_idx ON movie (title);
+END;
+
+IF OBJECT_ID('movie_award', 'U') IS NULL
+BEGIN
+CREATE TABLE movie_award (
+    movie_id INT
+    ,best_actor INT
+    ,best_supporting_actor INT
+    ,best_actress INT
+    ,best_supporting_actress INT
+);
+END;
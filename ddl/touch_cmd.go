@@ -237,6 +237,20 @@ func historyTableExists(dialect string, db *sql.DB, tableName string) (bool, err
 		if err != nil {
 			return false, err
 		}
+	case DialectOracle:
+		// Oracle stores unquoted identifiers in uppercase.
+		if tableName == strings.ToLower(tableName) {
+			tableName = strings.ToUpper(tableName)
+		}
+		var count int
+		err := db.QueryRow(`SELECT COUNT(*)`+
+			` FROM user_tables`+
+			` WHERE table_name = :1`, tableName,
+		).Scan(&count)
+		if err != nil {
+			return false, err
+		}
+		exists = count > 0
 	default:
 		return false, fmt.Errorf("unsupported dialect: %q", dialect)
 	}
@@ -254,6 +268,7 @@ func ensureHistoryTableExists(dialect string, db *sql.DB, historyTable string) e
 	pkeyDefinition := "CONSTRAINT sqddl_filename_pkey PRIMARY KEY (filename)"
 	timeType := "DATETIME"
 	boolType := "BOOLEAN"
+	bigintType := "BIGINT"
 	switch dialect {
 	case DialectPostgres:
 		timeType = "TIMESTAMPTZ"
@@ -262,13 +277,18 @@ func ensureHistoryTableExists(dialect string, db *sql.DB, historyTable string) e
 	case DialectSQLServer:
 		timeType = "DATETIMEOFFSET"
 		boolType = "BIT"
+	case DialectOracle:
+		timeType = "TIMESTAMP WITH TIME ZONE"
+		boolType = "NUMBER(1)"
+		bigintType = "NUMBER(19)"
 	}
 	query := "CREATE TABLE " + QuoteIdentifier(dialect, historyTable) + " (" +
 		"\n    filename VARCHAR(255) NOT NULL" +
 		"\n    ,checksum VARCHAR(64)" +
 		"\n    ,started_at " + timeType +
-		"\n    ,time_taken_ns BIGINT" +
+		"\n    ,time_taken_ns " + bigintType +
 		"\n    ,success " + boolType +
+		"\n    ,batch_key VARCHAR(255)" +
 		"\n" +
 		"\n    ," + pkeyDefinition +
 		"\n)"
@@ -279,6 +299,100 @@ func ensureHistoryTableExists(dialect string, db *sql.DB, historyTable string) e
 	return nil
 }
 
+// historyTableHasBatchKey reports whether the history table has the
+// batch_key column.
+func historyTableHasBatchKey(dialect string, db *sql.DB, tableName string) (bool, error) {
+	var exists bool
+	switch dialect {
+	case DialectSQLite:
+		err := db.QueryRow(`SELECT EXISTS`+
+			` (SELECT 1`+
+			` FROM pragma_table_info($1)`+
+			` WHERE name = 'batch_key')`, tableName,
+		).Scan(&exists)
+		if err != nil {
+			return false, err
+		}
+	case DialectPostgres:
+		err := db.QueryRow(`SELECT EXISTS`+
+			` (SELECT 1`+
+			` FROM information_schema.columns`+
+			` WHERE table_schema = current_schema()`+
+			` AND table_name = $1`+
+			` AND column_name = 'batch_key')`, tableName,
+		).Scan(&exists)
+		if err != nil {
+			return false, err
+		}
+	case DialectMySQL:
+		err := db.QueryRow(`SELECT EXISTS`+
+			` (SELECT 1`+
+			` FROM information_schema.columns`+
+			` WHERE table_schema = database()`+
+			` AND table_name = ?`+
+			` AND column_name = 'batch_key')`, tableName,
+		).Scan(&exists)
+		if err != nil {
+			return false, err
+		}
+	case DialectSQLServer:
+		err := db.QueryRow(`IF EXISTS`+
+			` (SELECT 1`+
+			` FROM sys.columns`+
+			` WHERE object_id = OBJECT_ID(QUOTENAME(SCHEMA_NAME()) + '.' + QUOTENAME(@p1))`+
+			` AND name = 'batch_key') SELECT 1 ELSE SELECT 0`, tableName,
+		).Scan(&exists)
+		if err != nil {
+			return false, err
+		}
+	case DialectOracle:
+		// Oracle stores unquoted identifiers in uppercase.
+		if tableName == strings.ToLower(tableName) {
+			tableName = strings.ToUpper(tableName)
+		}
+		var count int
+		err := db.QueryRow(`SELECT COUNT(*)`+
+			` FROM user_tab_columns`+
+			` WHERE table_name = :1`+
+			` AND column_name = 'BATCH_KEY'`, tableName,
+		).Scan(&count)
+		if err != nil {
+			return false, err
+		}
+		exists = count > 0
+	default:
+		return false, fmt.Errorf("unsupported dialect: %q", dialect)
+	}
+	return exists, nil
+}
+
+// ensureHistoryTableBatchKey adds the batch_key column to history tables that
+// were created before batch migrations were supported.
+func ensureHistoryTableBatchKey(dialect string, db *sql.DB, historyTable string) error {
+	exists, err := historyTableHasBatchKey(dialect, db, historyTable)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	tableName := QuoteIdentifier(dialect, historyTable)
+	var query string
+	switch dialect {
+	case DialectSQLServer:
+		query = "ALTER TABLE " + tableName + " ADD batch_key VARCHAR(255)"
+	case DialectOracle:
+		query = "ALTER TABLE " + tableName + " ADD (batch_key VARCHAR(255))"
+	default:
+		query = "ALTER TABLE " + tableName + " ADD COLUMN batch_key VARCHAR(255)"
+	}
+	_, err = db.Exec(query)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func walkDir(fsys fs.FS) (filenames []string, err error) {
 	err = fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -202,6 +202,26 @@ func (cmd *WipeCmd) Run() error {
 		}
 	}
 
+	// DROP SEQUENCE.
+	sequences, err := dbi.GetSequences()
+	if err != nil {
+		return err
+	}
+	for _, sequence := range sequences {
+		if cmd.buf.Len() > 0 {
+			cmd.buf.WriteString("\n")
+		}
+		cmd.buf.WriteString("DROP SEQUENCE IF EXISTS ")
+		if sequence.SequenceSchema != "" && sequence.SequenceSchema != currentSchema {
+			cmd.buf.WriteString(QuoteIdentifier(cmd.Dialect, sequence.SequenceSchema) + ".")
+		}
+		cmd.buf.WriteString(QuoteIdentifier(cmd.Dialect, sequence.SequenceName))
+		if cmd.Dialect == DialectPostgres {
+			cmd.buf.WriteString(" CASCADE")
+		}
+		cmd.buf.WriteString(";\n")
+	}
+
 	if cmd.Dialect == DialectPostgres {
 		// DROP TYPE.
 		enums, err := dbi.GetEnums()
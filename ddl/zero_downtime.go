@@ -0,0 +1,74 @@
+package ddl
+
+import "strings"
+
+// backfillBatchSize is the number of rows updated per batch by the backfill
+// migrations of an expand/contract plan.
+const backfillBatchSize = 1000
+
+// expandContractColumn is a column whose type change is carried out as an
+// expand/contract plan instead of an in-place ALTER COLUMN, so that the table
+// is never locked while it is being rewritten. The plan is made up of
+// separate migrations that are run in order:
+//
+//  1. expand: add a shadow column with the new type.
+//  2. sync: install a trigger that copies every write to the old column into
+//     the shadow column.
+//  3. backfill: copy the existing rows into the shadow column in batches.
+//  4. swap: drop the trigger, then rename the old column out of the way and
+//     rename the shadow column in its place.
+//  5. contract: drop the old column. This is only generated if dropObjects
+//     is true, otherwise the old column is left behind for a later
+//     migration.
+type expandContractColumn struct {
+	tableSchema string
+	tableName   string
+	srcColumn   *Column
+	destColumn  *Column
+	contract    bool
+}
+
+// name returns the name used for the expand/contract migration filenames.
+func (c expandContractColumn) name(currentSchema string) string {
+	name := strings.ReplaceAll(c.tableName, " ", "_") + "_" + strings.ReplaceAll(c.destColumn.ColumnName, " ", "_")
+	if c.tableSchema != "" && c.tableSchema != currentSchema {
+		name = strings.ReplaceAll(c.tableSchema, " ", "_") + "_" + name
+	}
+	return name
+}
+
+// shadowName returns the name of the shadow column that takes over the
+// column once the columns are swapped.
+func (c expandContractColumn) shadowName() string {
+	return c.destColumn.ColumnName + "_new"
+}
+
+// oldName returns the name of the old column after the columns are swapped.
+func (c expandContractColumn) oldName() string {
+	return c.destColumn.ColumnName + "_old"
+}
+
+// syncName returns the name of the trigger that keeps the shadow column in
+// sync with the old column.
+func (c expandContractColumn) syncName() string {
+	return c.tableName + "_" + c.destColumn.ColumnName + "_sync"
+}
+
+// nullDefault returns the DEFAULT that the NULLs of the old column are
+// replaced with when they are copied into the shadow column. It is only
+// non-empty if a nullable column becomes NOT NULL: the shadow column has to
+// be free of NULLs by the time it is made NOT NULL, even though the old
+// column keeps accepting them until the swap.
+func (c expandContractColumn) nullDefault(dialect string) string {
+	if c.srcColumn.IsNotNull || !c.destColumn.IsNotNull {
+		return ""
+	}
+	return normalizeColumnDefault(dialect, c.destColumn.ColumnDefault)
+}
+
+// hasNulls reports whether the shadow column may still contain NULLs after
+// it has been backfilled, because a nullable column becomes NOT NULL without
+// a DEFAULT to replace its NULLs with.
+func (c expandContractColumn) hasNulls(dialect string) bool {
+	return !c.srcColumn.IsNotNull && c.destColumn.IsNotNull && c.nullDefault(dialect) == ""
+}
@@ -31,6 +31,10 @@ const helptext = `Usage:
   sqddl tables      # Generate table structs from database.
   sqddl views       # Generate view structs from database.
   sqddl generate    # Generate migrations from table structs.
+  sqddl plan        # Report the locks taken by each statement in a migration.
+  sqddl convert     # Convert a schema from one database dialect to another.
+  sqddl diff        # Show the differences between two schemas.
+  sqddl drift       # Show the differences between a database and its migrations.
   sqddl wipe        # Wipe a database of all views, tables, routines, enums, domains and extensions.
   sqddl dump        # Dump a database as sql scripts and csv files.
   sqddl load        # Load database dumps, sql scripts or csv files into a database.
@@ -154,6 +158,48 @@ func main() {
 		if err != nil {
 			exit(subcmd, err)
 		}
+	case "plan":
+		planCmd, err := ddl.PlanCommand(args...)
+		if err != nil {
+			exit(subcmd, err)
+		}
+		err = planCmd.Run()
+		if err != nil {
+			exit(subcmd, err)
+		}
+	case "convert":
+		convertCmd, err := ddl.ConvertCommand(args...)
+		if err != nil {
+			exit(subcmd, err)
+		}
+		err = convertCmd.Run()
+		if err != nil {
+			exit(subcmd, err)
+		}
+	case "diff":
+		diffCmd, err := ddl.DiffCommand(args...)
+		if err != nil {
+			exit(subcmd, err)
+		}
+		err = diffCmd.Run()
+		if errors.Is(err, ddl.ErrDiffFound) {
+			os.Exit(1)
+		}
+		if err != nil {
+			exit(subcmd, err)
+		}
+	case "drift":
+		driftCmd, err := ddl.DriftCommand(args...)
+		if err != nil {
+			exit(subcmd, err)
+		}
+		err = driftCmd.Run()
+		if errors.Is(err, ddl.ErrDiffFound) {
+			os.Exit(1)
+		}
+		if err != nil {
+			exit(subcmd, err)
+		}
 	case "wipe":
 		wipeCmd, err := ddl.WipeCommand(args...)
 		if err != nil {